@@ -0,0 +1,36 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/aggregates"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = aggregates.ProcessApp(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/backup"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	var params backup.Params
+	flag.StringVar(&params.Out, "out", "", "path to backup file, backups/auto_shop_<time>.zip by default")
+	flag.BoolVar(&params.Users, "users", false, "save users with hashes of their passwords")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = backup.ProcessApp(conf, &params)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
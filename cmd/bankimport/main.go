@@ -0,0 +1,44 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/bankimport"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	var params bankimport.Params
+	flag.StringVar(&params.File, "file", "", "path to bank statement")
+	flag.StringVar(&params.Format, "format", "1c", "format of statement: 1c (kl_to_1c.txt) or csv")
+	flag.StringVar(&params.Mapping, "map", "", "mapping of fields to columns of csv statement as field=Column,... "+
+		"(fields are date, amount, debit, counterparty, description and number)")
+	flag.StringVar(&params.Delimiter, "delimiter", "", "csv delimiter: comma, semicolon or tab, detected when empty")
+	flag.StringVar(&params.Encoding, "encoding", "", "csv encoding: utf-8 or windows-1251")
+	flag.BoolVar(&params.DryRun, "dry-run", false, "only show payments and their expense items")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = bankimport.ProcessApp(conf, &params)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
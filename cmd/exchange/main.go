@@ -0,0 +1,42 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/exchange"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	var params exchange.Params
+	flag.StringVar(&params.Export, "export", "", "kind of file to export: catalog, offers or sales")
+	flag.StringVar(&params.Import, "import", "", "path to CommerceML file to import")
+	flag.StringVar(&params.Out, "out", "", "path to exported file, exchange/<file name 1C expects> by default")
+	flag.StringVar(&params.From, "from", "", "first day of exported sales, YYYY-MM-DD, 30 days ago by default")
+	flag.StringVar(&params.To, "to", "", "last day of exported sales, YYYY-MM-DD, today by default")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = exchange.ProcessApp(conf, &params)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
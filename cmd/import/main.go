@@ -0,0 +1,45 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/csvimport"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	var params csvimport.Params
+	flag.StringVar(&params.Table, "table", "", "table to import to: warehouses, expense_items, charges or sales")
+	flag.StringVar(&params.File, "file", "", "path to csv file with header row")
+	flag.StringVar(&params.Mapping, "map", "", "mapping of fields to columns as field=Column,... "+
+		"(fields are mapped to columns named the same by default)")
+	flag.StringVar(&params.DateLayout, "date-layout", "", "Go layout of dates, detected when empty")
+	flag.StringVar(&params.Delimiter, "delimiter", "", "delimiter: comma, semicolon or tab, detected when empty")
+	flag.StringVar(&params.Encoding, "encoding", "", "encoding: utf-8 or windows-1251")
+	flag.BoolVar(&params.DryRun, "dry-run", false, "only validate rows")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = csvimport.ProcessApp(conf, &params)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
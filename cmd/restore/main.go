@@ -0,0 +1,41 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/restore"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	var params restore.Params
+	flag.StringVar(&params.File, "file", "", "path to backup file")
+	flag.BoolVar(&params.Replace, "replace", false, "delete existing shop data before restore")
+	flag.BoolVar(&params.Users, "users", false, "add users saved in backup, existing logins are kept")
+	flag.BoolVar(&params.Check, "check", false, "only verify checksums of backup")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = restore.ProcessApp(conf, &params)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/app/seed"
+	"automatedShop/internal/period"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func main() {
+	configFile := flag.String("config", "./configs/config.yaml", "path to config file")
+	var params seed.Params
+	flag.Int64Var(&params.Seed, "seed", 1, "random seed, the same seed gives the same data")
+	flag.IntVar(&params.Items, "items", 40, "number of warehouses items")
+	flag.IntVar(&params.Days, "days", 365, "number of days of sales and charges before today")
+	flag.IntVar(&params.SalesPerDay, "sales-per-day", 50, "average number of sales a day")
+	flag.IntVar(&params.Users, "users", 3, "number of users, the first one is admin")
+	flag.BoolVar(&params.Append, "append", false, "add data to database which already has warehouses items")
+	flag.Parse()
+
+	conf, err := configs.ReadConfigFromYAML[configs.ShopConfig](*configFile)
+	if err != nil {
+		panic(fmt.Errorf("read of config from '%s' failed: %w", *configFile, err))
+	}
+
+	err = configs.ValidateConfig(conf)
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", *configFile, err))
+	}
+	period.SetTimeZone(location)
+
+	err = seed.ProcessApp(conf, &params)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"automatedShop/configs"
 	"automatedShop/internal/app/shop"
+	"automatedShop/internal/period"
 	"fmt"
 	"log"
 )
@@ -22,6 +23,12 @@ func main() {
 		panic(fmt.Errorf("'%s' parsing failed: %w", configFile, err))
 	}
 
+	location, err := conf.Location()
+	if err != nil {
+		panic(fmt.Errorf("'%s' parsing failed: %w", configFile, err))
+	}
+	period.SetTimeZone(location)
+
 	err = shop.ProcessApp(conf)
 	if err != nil {
 		log.Fatal(err)
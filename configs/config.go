@@ -6,10 +6,33 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 	"os"
+	"time"
 )
 
 type ShopConfig struct {
 	DbConfig *DbConfig `yaml:"db"`
+	// TimeZone is IANA name of shop's time zone, dates of sales and charges are stored in it.
+	// Local time zone of the machine is used when empty.
+	TimeZone string `yaml:"time_zone"`
+	// ShopName is printed in the footer of reports.
+	ShopName string `yaml:"shop_name"`
+	// SchedulerInterval is how often the app runs due report schedules, e.g. "1m". Schedules are
+	// left to cmd/scheduler when it's zero.
+	SchedulerInterval time.Duration `yaml:"scheduler_interval"`
+}
+
+// Location returns shop's time zone, local time zone of the machine when it isn't set.
+func (c *ShopConfig) Location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load time zone '%s': %w", c.TimeZone, err)
+	}
+
+	return location, nil
 }
 
 type DbConfig struct {
@@ -8,16 +8,23 @@ CREATE TABLE IF NOT EXISTS "users"
 
 CREATE TABLE IF NOT EXISTS "expense_items"
 (
-    id   SERIAL PRIMARY KEY,
-    name VARCHAR(20)
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(20),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE,
+    version    INT NOT NULL DEFAULT 1
 );
 
 CREATE TABLE IF NOT EXISTS "warehouses"
 (
-    id       SERIAL PRIMARY KEY,
-    name     VARCHAR(20),
-    quantity INT,
-    amount   INT
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(20),
+    quantity   INT,
+    amount     INT,
+    cost       INT,
+    category   VARCHAR(30),
+    location   VARCHAR(30),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE,
+    version    INT NOT NULL DEFAULT 1
 );
 
 CREATE TABLE IF NOT EXISTS "charges"
@@ -26,10 +33,12 @@ CREATE TABLE IF NOT EXISTS "charges"
     amount          INT,
     charge_date     TIMESTAMP WITHOUT TIME ZONE,
     expense_item_id INT,
+    deleted_at      TIMESTAMP WITHOUT TIME ZONE,
+    version         INT NOT NULL DEFAULT 1,
     CONSTRAINT fk_charges_expense_items
         FOREIGN KEY (expense_item_id)
             REFERENCES "expense_items" (id)
-        ON DELETE CASCADE
+        ON DELETE RESTRICT
 );
 
 CREATE TABLE IF NOT EXISTS "sales"
@@ -39,8 +48,236 @@ CREATE TABLE IF NOT EXISTS "sales"
     quantity      INT,
     sale_date     TIMESTAMP WITHOUT TIME ZONE,
     warehouses_id INT,
+    customer      VARCHAR(100) NOT NULL DEFAULT '',
+    deleted_at    TIMESTAMP WITHOUT TIME ZONE,
+    version       INT NOT NULL DEFAULT 1,
     CONSTRAINT fk_sales_warehouses
         FOREIGN KEY (warehouses_id)
             REFERENCES "warehouses" (id)
+        ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS "audit_log"
+(
+    id          bigserial PRIMARY KEY,
+    user_login  VARCHAR(30)                 NOT NULL DEFAULT '',
+    changed_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    table_name  VARCHAR(30)                 NOT NULL,
+    record_id   INT                         NOT NULL,
+    action      VARCHAR(10)                 NOT NULL,
+    before_data JSONB,
+    after_data  JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_record ON "audit_log" (table_name, record_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_changed_at ON "audit_log" (changed_at);
+
+CREATE TABLE IF NOT EXISTS "report_archive"
+(
+    id          bigserial PRIMARY KEY,
+    report_type VARCHAR(50)                 NOT NULL,
+    params      TEXT                        NOT NULL DEFAULT '',
+    format      VARCHAR(10)                 NOT NULL,
+    file_name   VARCHAR(255)                NOT NULL,
+    content     BYTEA                       NOT NULL,
+    user_login  VARCHAR(30)                 NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_archive_created_at ON "report_archive" (created_at);
+
+CREATE TABLE IF NOT EXISTS "report_schedules"
+(
+    id          serial PRIMARY KEY,
+    name        VARCHAR(100)                NOT NULL,
+    report_type VARCHAR(50)                 NOT NULL,
+    params      JSONB                       NOT NULL DEFAULT '{}',
+    format      VARCHAR(10)                 NOT NULL,
+    destination VARCHAR(255)                NOT NULL,
+    frequency   VARCHAR(10)                 NOT NULL,
+    day         INT                         NOT NULL DEFAULT 0,
+    hour        INT                         NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_by  VARCHAR(30)                 NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_next_run_at ON "report_schedules" (next_run_at);
+
+CREATE TABLE IF NOT EXISTS "report_schedule_runs"
+(
+    id          bigserial PRIMARY KEY,
+    schedule_id INT                         NOT NULL,
+    started_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    status      VARCHAR(10)                 NOT NULL,
+    file_path   VARCHAR(500)                NOT NULL DEFAULT '',
+    error       TEXT                        NOT NULL DEFAULT '',
+    CONSTRAINT fk_report_schedule_runs_schedules
+        FOREIGN KEY (schedule_id)
+            REFERENCES "report_schedules" (id)
         ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedule_runs_schedule ON "report_schedule_runs" (schedule_id, started_at);
+
+CREATE TABLE IF NOT EXISTS "pivot_definitions"
+(
+    id         serial PRIMARY KEY,
+    name       VARCHAR(100)                NOT NULL,
+    definition JSONB                       NOT NULL,
+    user_login VARCHAR(30)                 NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pivot_definitions_user ON "pivot_definitions" (user_login);
+
+CREATE TABLE IF NOT EXISTS "anomaly_acknowledgements"
+(
+    kind            VARCHAR(30)                 NOT NULL,
+    record_key      VARCHAR(50)                 NOT NULL,
+    value           BIGINT                      NOT NULL,
+    user_login      VARCHAR(30)                 NOT NULL DEFAULT '',
+    acknowledged_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (kind, record_key)
+);
+
+CREATE TABLE IF NOT EXISTS "sales_daily"
+(
+    day           DATE   NOT NULL,
+    warehouses_id INT    NOT NULL,
+    revenue       BIGINT NOT NULL DEFAULT 0,
+    units         BIGINT NOT NULL DEFAULT 0,
+    sales_count   BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, warehouses_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sales_daily_warehouses ON "sales_daily" (warehouses_id, day);
+
+CREATE TABLE IF NOT EXISTS "charges_daily"
+(
+    day             DATE   NOT NULL,
+    expense_item_id INT    NOT NULL,
+    amount          BIGINT NOT NULL DEFAULT 0,
+    charges_count   BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, expense_item_id)
+);
+
+-- Adds sale to its day, sign is -1 to take it away. Days left without sales are removed.
+CREATE OR REPLACE FUNCTION sales_daily_add(s "sales", sign INT) RETURNS void AS
+$$
+BEGIN
+    IF s.deleted_at IS NOT NULL OR s.sale_date IS NULL OR s.warehouses_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO "sales_daily" (day, warehouses_id, revenue, units, sales_count)
+    VALUES (s.sale_date::date, s.warehouses_id, sign * COALESCE(s.quantity::bigint * s.amount, 0),
+            sign * COALESCE(s.quantity, 0), sign)
+    ON CONFLICT (day, warehouses_id) DO UPDATE
+        SET revenue     = sales_daily.revenue + EXCLUDED.revenue,
+            units       = sales_daily.units + EXCLUDED.units,
+            sales_count = sales_daily.sales_count + EXCLUDED.sales_count;
+
+    DELETE FROM "sales_daily"
+    WHERE day = s.sale_date::date AND warehouses_id = s.warehouses_id AND sales_count = 0;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sales_daily_apply() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM sales_daily_add(OLD, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM sales_daily_add(NEW, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_sales_daily ON "sales";
+CREATE TRIGGER trg_sales_daily
+    AFTER INSERT OR UPDATE OR DELETE
+    ON "sales"
+    FOR EACH ROW
+EXECUTE FUNCTION sales_daily_apply();
+
+-- Adds charge to its day, sign is -1 to take it away. Days left without charges are removed.
+CREATE OR REPLACE FUNCTION charges_daily_add(c "charges", sign INT) RETURNS void AS
+$$
+BEGIN
+    IF c.deleted_at IS NOT NULL OR c.charge_date IS NULL OR c.expense_item_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO "charges_daily" (day, expense_item_id, amount, charges_count)
+    VALUES (c.charge_date::date, c.expense_item_id, sign * COALESCE(c.amount, 0), sign)
+    ON CONFLICT (day, expense_item_id) DO UPDATE
+        SET amount        = charges_daily.amount + EXCLUDED.amount,
+            charges_count = charges_daily.charges_count + EXCLUDED.charges_count;
+
+    DELETE FROM "charges_daily"
+    WHERE day = c.charge_date::date AND expense_item_id = c.expense_item_id AND charges_count = 0;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION charges_daily_apply() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM charges_daily_add(OLD, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM charges_daily_add(NEW, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_charges_daily ON "charges";
+CREATE TRIGGER trg_charges_daily
+    AFTER INSERT OR UPDATE OR DELETE
+    ON "charges"
+    FOR EACH ROW
+EXECUTE FUNCTION charges_daily_apply();
+
+CREATE TABLE IF NOT EXISTS "exchange_ids"
+(
+    table_name  VARCHAR(30)  NOT NULL,
+    external_id VARCHAR(100) NOT NULL,
+    record_id   INT          NOT NULL,
+    PRIMARY KEY (table_name, external_id),
+    UNIQUE (table_name, record_id)
+);
+
+CREATE TABLE IF NOT EXISTS "bank_rules"
+(
+    id              SERIAL PRIMARY KEY,
+    counterparty    VARCHAR(200) NOT NULL DEFAULT '',
+    description     VARCHAR(200) NOT NULL DEFAULT '',
+    expense_item_id INT          NOT NULL,
+    CONSTRAINT fk_bank_rules_expense_items
+        FOREIGN KEY (expense_item_id)
+            REFERENCES "expense_items" (id)
+        ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS "bank_payments"
+(
+    key         VARCHAR(64) PRIMARY KEY,
+    charge_id   INT                         NOT NULL,
+    imported_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT fk_bank_payments_charges
+        FOREIGN KEY (charge_id)
+            REFERENCES "charges" (id)
+        ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS "schema_version"
+(
+    version INT NOT NULL
+);
+
+DELETE FROM "schema_version";
+INSERT INTO "schema_version" (version) VALUES (13);
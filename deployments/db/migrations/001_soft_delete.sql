@@ -0,0 +1,21 @@
+-- Replaces cascading hard deletes with soft deletes for databases
+-- created from an init.sql older than this migration.
+
+ALTER TABLE "expense_items" ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE "warehouses" ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE "charges" ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE "sales" ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITHOUT TIME ZONE;
+
+ALTER TABLE "charges" DROP CONSTRAINT IF EXISTS fk_charges_expense_items;
+ALTER TABLE "charges"
+    ADD CONSTRAINT fk_charges_expense_items
+        FOREIGN KEY (expense_item_id)
+            REFERENCES "expense_items" (id)
+        ON DELETE RESTRICT;
+
+ALTER TABLE "sales" DROP CONSTRAINT IF EXISTS fk_sales_warehouses;
+ALTER TABLE "sales"
+    ADD CONSTRAINT fk_sales_warehouses
+        FOREIGN KEY (warehouses_id)
+            REFERENCES "warehouses" (id)
+        ON DELETE RESTRICT;
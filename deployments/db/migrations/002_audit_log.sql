@@ -0,0 +1,16 @@
+-- Adds audit log of changes made through the shop service.
+
+CREATE TABLE IF NOT EXISTS "audit_log"
+(
+    id          bigserial PRIMARY KEY,
+    user_login  VARCHAR(30)                 NOT NULL DEFAULT '',
+    changed_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    table_name  VARCHAR(30)                 NOT NULL,
+    record_id   INT                         NOT NULL,
+    action      VARCHAR(10)                 NOT NULL,
+    before_data JSONB,
+    after_data  JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_record ON "audit_log" (table_name, record_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_changed_at ON "audit_log" (changed_at);
@@ -0,0 +1,6 @@
+-- Adds row versions used for optimistic concurrency control of updates.
+
+ALTER TABLE "expense_items" ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE "warehouses" ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE "charges" ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE "sales" ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- Adds purchase cost of warehouses items used to count cost of goods sold.
+
+ALTER TABLE "warehouses" ADD COLUMN IF NOT EXISTS cost INT;
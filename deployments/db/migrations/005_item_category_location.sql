@@ -0,0 +1,4 @@
+-- Adds category and storage location of warehouses items used to group reports.
+
+ALTER TABLE "warehouses" ADD COLUMN IF NOT EXISTS category VARCHAR(30);
+ALTER TABLE "warehouses" ADD COLUMN IF NOT EXISTS location VARCHAR(30);
@@ -0,0 +1,15 @@
+-- Adds archive of generated reports, files are stored in database.
+
+CREATE TABLE IF NOT EXISTS "report_archive"
+(
+    id          bigserial PRIMARY KEY,
+    report_type VARCHAR(50)                 NOT NULL,
+    params      TEXT                        NOT NULL DEFAULT '',
+    format      VARCHAR(10)                 NOT NULL,
+    file_name   VARCHAR(255)                NOT NULL,
+    content     BYTEA                       NOT NULL,
+    user_login  VARCHAR(30)                 NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_archive_created_at ON "report_archive" (created_at);
@@ -0,0 +1,35 @@
+-- Adds schedules of reports generated by cmd/scheduler and log of their runs.
+
+CREATE TABLE IF NOT EXISTS "report_schedules"
+(
+    id          serial PRIMARY KEY,
+    name        VARCHAR(100)                NOT NULL,
+    report_type VARCHAR(50)                 NOT NULL,
+    params      JSONB                       NOT NULL DEFAULT '{}',
+    format      VARCHAR(10)                 NOT NULL,
+    destination VARCHAR(255)                NOT NULL,
+    frequency   VARCHAR(10)                 NOT NULL,
+    day         INT                         NOT NULL DEFAULT 0,
+    hour        INT                         NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_by  VARCHAR(30)                 NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_next_run_at ON "report_schedules" (next_run_at);
+
+CREATE TABLE IF NOT EXISTS "report_schedule_runs"
+(
+    id          bigserial PRIMARY KEY,
+    schedule_id INT                         NOT NULL,
+    started_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    status      VARCHAR(10)                 NOT NULL,
+    file_path   VARCHAR(500)                NOT NULL DEFAULT '',
+    error       TEXT                        NOT NULL DEFAULT '',
+    CONSTRAINT fk_report_schedule_runs_schedules
+        FOREIGN KEY (schedule_id)
+            REFERENCES "report_schedules" (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedule_runs_schedule ON "report_schedule_runs" (schedule_id, started_at);
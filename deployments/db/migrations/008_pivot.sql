@@ -0,0 +1,14 @@
+-- Adds customer of sale and definitions of pivot reports saved by users.
+
+ALTER TABLE "sales" ADD COLUMN IF NOT EXISTS customer VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "pivot_definitions"
+(
+    id         serial PRIMARY KEY,
+    name       VARCHAR(100)                NOT NULL,
+    definition JSONB                       NOT NULL,
+    user_login VARCHAR(30)                 NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pivot_definitions_user ON "pivot_definitions" (user_login);
@@ -0,0 +1,11 @@
+-- Adds acknowledgements of anomalies found in sales and charges.
+
+CREATE TABLE IF NOT EXISTS "anomaly_acknowledgements"
+(
+    kind            VARCHAR(30)                 NOT NULL,
+    record_key      VARCHAR(50)                 NOT NULL,
+    value           BIGINT                      NOT NULL,
+    user_login      VARCHAR(30)                 NOT NULL DEFAULT '',
+    acknowledged_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (kind, record_key)
+);
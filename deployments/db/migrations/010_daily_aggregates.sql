@@ -0,0 +1,118 @@
+-- Adds daily aggregates of sales and charges read by reports. They're kept up to date by triggers
+-- and can be rebuilt from scratch by cmd/aggregates.
+
+CREATE TABLE IF NOT EXISTS "sales_daily"
+(
+    day           DATE   NOT NULL,
+    warehouses_id INT    NOT NULL,
+    revenue       BIGINT NOT NULL DEFAULT 0,
+    units         BIGINT NOT NULL DEFAULT 0,
+    sales_count   BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, warehouses_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sales_daily_warehouses ON "sales_daily" (warehouses_id, day);
+
+CREATE TABLE IF NOT EXISTS "charges_daily"
+(
+    day             DATE   NOT NULL,
+    expense_item_id INT    NOT NULL,
+    amount          BIGINT NOT NULL DEFAULT 0,
+    charges_count   BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, expense_item_id)
+);
+
+-- Adds sale to its day, sign is -1 to take it away. Days left without sales are removed.
+CREATE OR REPLACE FUNCTION sales_daily_add(s "sales", sign INT) RETURNS void AS
+$$
+BEGIN
+    IF s.deleted_at IS NOT NULL OR s.sale_date IS NULL OR s.warehouses_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO "sales_daily" (day, warehouses_id, revenue, units, sales_count)
+    VALUES (s.sale_date::date, s.warehouses_id, sign * COALESCE(s.quantity::bigint * s.amount, 0),
+            sign * COALESCE(s.quantity, 0), sign)
+    ON CONFLICT (day, warehouses_id) DO UPDATE
+        SET revenue     = sales_daily.revenue + EXCLUDED.revenue,
+            units       = sales_daily.units + EXCLUDED.units,
+            sales_count = sales_daily.sales_count + EXCLUDED.sales_count;
+
+    DELETE FROM "sales_daily"
+    WHERE day = s.sale_date::date AND warehouses_id = s.warehouses_id AND sales_count = 0;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sales_daily_apply() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM sales_daily_add(OLD, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM sales_daily_add(NEW, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_sales_daily ON "sales";
+CREATE TRIGGER trg_sales_daily
+    AFTER INSERT OR UPDATE OR DELETE
+    ON "sales"
+    FOR EACH ROW
+EXECUTE FUNCTION sales_daily_apply();
+
+-- Adds charge to its day, sign is -1 to take it away. Days left without charges are removed.
+CREATE OR REPLACE FUNCTION charges_daily_add(c "charges", sign INT) RETURNS void AS
+$$
+BEGIN
+    IF c.deleted_at IS NOT NULL OR c.charge_date IS NULL OR c.expense_item_id IS NULL THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO "charges_daily" (day, expense_item_id, amount, charges_count)
+    VALUES (c.charge_date::date, c.expense_item_id, sign * COALESCE(c.amount, 0), sign)
+    ON CONFLICT (day, expense_item_id) DO UPDATE
+        SET amount        = charges_daily.amount + EXCLUDED.amount,
+            charges_count = charges_daily.charges_count + EXCLUDED.charges_count;
+
+    DELETE FROM "charges_daily"
+    WHERE day = c.charge_date::date AND expense_item_id = c.expense_item_id AND charges_count = 0;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION charges_daily_apply() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM charges_daily_add(OLD, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM charges_daily_add(NEW, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_charges_daily ON "charges";
+CREATE TRIGGER trg_charges_daily
+    AFTER INSERT OR UPDATE OR DELETE
+    ON "charges"
+    FOR EACH ROW
+EXECUTE FUNCTION charges_daily_apply();
+
+-- Existing history
+TRUNCATE "sales_daily", "charges_daily";
+
+INSERT INTO "sales_daily" (day, warehouses_id, revenue, units, sales_count)
+SELECT sale_date::date, warehouses_id, COALESCE(SUM(quantity::bigint * amount), 0), COALESCE(SUM(quantity), 0), COUNT(*)
+FROM "sales"
+WHERE deleted_at IS NULL AND sale_date IS NOT NULL AND warehouses_id IS NOT NULL
+GROUP BY sale_date::date, warehouses_id;
+
+INSERT INTO "charges_daily" (day, expense_item_id, amount, charges_count)
+SELECT charge_date::date, expense_item_id, COALESCE(SUM(amount), 0), COUNT(*)
+FROM "charges"
+WHERE deleted_at IS NULL AND charge_date IS NOT NULL AND expense_item_id IS NOT NULL
+GROUP BY charge_date::date, expense_item_id;
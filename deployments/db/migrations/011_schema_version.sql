@@ -0,0 +1,11 @@
+-- Records schema version, i.e. number of the last applied migration. Backups are stamped with it,
+-- so that they're restored only into databases of the same or later version. Every later migration
+-- must update it.
+
+CREATE TABLE IF NOT EXISTS "schema_version"
+(
+    version INT NOT NULL
+);
+
+DELETE FROM "schema_version";
+INSERT INTO "schema_version" (version) VALUES (11);
@@ -0,0 +1,15 @@
+-- Maps ids of 1C objects exchanged in CommerceML files to records of the shop, so that the same
+-- product isn't created twice and the same document isn't imported twice. Every record has at most
+-- one id of each table.
+
+CREATE TABLE IF NOT EXISTS "exchange_ids"
+(
+    table_name  VARCHAR(30)  NOT NULL,
+    external_id VARCHAR(100) NOT NULL,
+    record_id   INT          NOT NULL,
+    PRIMARY KEY (table_name, external_id),
+    UNIQUE (table_name, record_id)
+);
+
+DELETE FROM "schema_version";
+INSERT INTO "schema_version" (version) VALUES (12);
@@ -0,0 +1,28 @@
+-- Adds rules matching payments of bank statements to expense items and keys of payments already
+-- imported as charges, so that statements imported again don't create duplicates.
+
+CREATE TABLE IF NOT EXISTS "bank_rules"
+(
+    id              SERIAL PRIMARY KEY,
+    counterparty    VARCHAR(200) NOT NULL DEFAULT '',
+    description     VARCHAR(200) NOT NULL DEFAULT '',
+    expense_item_id INT          NOT NULL,
+    CONSTRAINT fk_bank_rules_expense_items
+        FOREIGN KEY (expense_item_id)
+            REFERENCES "expense_items" (id)
+        ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS "bank_payments"
+(
+    key         VARCHAR(64) PRIMARY KEY,
+    charge_id   INT                         NOT NULL,
+    imported_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT fk_bank_payments_charges
+        FOREIGN KEY (charge_id)
+            REFERENCES "charges" (id)
+        ON DELETE RESTRICT
+);
+
+DELETE FROM "schema_version";
+INSERT INTO "schema_version" (version) VALUES (13);
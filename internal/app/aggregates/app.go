@@ -0,0 +1,42 @@
+package aggregates
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os/signal"
+	"syscall"
+)
+
+// systemUser is user aggregates are rebuilt on behalf of.
+var systemUser = &dto.UserData{Login: "aggregates", IsAdmin: true}
+
+// ProcessApp rebuilds daily aggregates of reports from sales and charges, e.g. after bulk changes
+// made bypassing triggers or to check that they're consistent.
+func ProcessApp(config *configs.ShopConfig) error {
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	res, err := s.AggregateService.RebuildAggregates(session.WithUser(ctx, systemUser))
+	if err != nil {
+		return fmt.Errorf("rebuild of aggregates failed: %w", err)
+	}
+
+	logrus.Infof("aggregates rebuilt: %d days of item sales, %d days of expense item charges",
+		res.SalesDays, res.ChargesDays)
+	return nil
+}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// backupDir is the directory backups are saved to by default.
+const backupDir = "backups"
+
+// systemUser is user backups are made on behalf of.
+var systemUser = &dto.UserData{Login: "backup", IsAdmin: true}
+
+// Params are command line parameters of backup.
+type Params struct {
+	Out   string
+	Users bool
+}
+
+// ProcessApp saves all shop data to backup file. The file appears only when backup is complete.
+func ProcessApp(config *configs.ShopConfig, params *Params) error {
+	out := params.Out
+	if out == "" {
+		out = filepath.Join(backupDir, "auto_shop_"+time.Now().Format("2006-01-02_150405")+".zip")
+	}
+	if err := os.MkdirAll(filepath.Dir(out), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create backup dir: %w", err)
+	}
+
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	file, err := os.CreateTemp(filepath.Dir(out), filepath.Base(out)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer func(name string) { _ = os.Remove(name) }(file.Name())
+
+	res, err := s.BackupService.Backup(session.WithUser(ctx, systemUser), file, &dto.BackupParams{Users: params.Users})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	if err = os.Rename(file.Name(), out); err != nil {
+		return fmt.Errorf("failed to save backup file: %w", err)
+	}
+
+	for _, table := range res.Tables {
+		logrus.Infof("%s: %d rows", table.Name, table.Rows)
+	}
+	logrus.Infof("backup of schema version %d saved to %s", res.SchemaVersion, out)
+	return nil
+}
@@ -0,0 +1,134 @@
+package bankimport
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/bankstatement"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/export"
+	"automatedShop/internal/importer"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// systemUser is user payments are imported on behalf of.
+var systemUser = &dto.UserData{Login: "bank", IsAdmin: true}
+
+// Params are command line parameters of import of bank statement.
+type Params struct {
+	File      string
+	Format    string
+	Mapping   string
+	Delimiter string
+	Encoding  string
+	DryRun    bool
+}
+
+// ProcessApp imports outgoing payments of bank statement as charges. Without review only payments
+// matched by rules are imported, the others are logged and left for the app.
+func ProcessApp(config *configs.ShopConfig, params *Params) error {
+	file, err := os.Open(params.File)
+	if err != nil {
+		return fmt.Errorf("failed to open bank statement: %w", err)
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	payments, err := readPayments(file, params)
+	if err != nil {
+		return err
+	}
+
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx = session.WithUser(ctx, systemUser)
+
+	review, err := s.BankService.ReviewStatement(ctx, payments)
+	if err != nil {
+		return fmt.Errorf("review failed: %w", err)
+	}
+	expenseItems, err := s.ShopService.ShowExpenseItemsTable(ctx)
+	if err != nil {
+		return err
+	}
+	names := make(map[int]string, len(expenseItems))
+	for _, item := range expenseItems {
+		names[item.Id] = item.Name
+	}
+
+	for _, line := range review.Lines {
+		status := names[line.ExpenseItemId]
+		switch {
+		case line.Duplicate:
+			status = "imported before"
+		case line.ExpenseItemId == 0:
+			status = "no matching rule"
+		}
+		logrus.Infof("%s %d %s %q: %s", line.Payment.Date, line.Payment.Amount, line.Payment.Counterparty,
+			line.Payment.Description, status)
+	}
+	logrus.Infof("%d outgoing and %d incoming payments", len(review.Lines), review.Incoming)
+	if params.DryRun {
+		return nil
+	}
+
+	res, err := s.BankService.ImportStatement(ctx, review.Lines)
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	logrus.Infof("%d charges created, %d duplicates and %d payments skipped", res.Created, res.Duplicates, res.Skipped)
+	return nil
+}
+
+func readPayments(r io.Reader, params *Params) ([]*dto.BankPaymentData, error) {
+	switch params.Format {
+	case dto.StatementClientBank:
+		return bankstatement.ReadClientBank(r)
+	case dto.StatementCSV:
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected 1c or csv", params.Format)
+	}
+
+	options := export.CSVOptions{Encoding: params.Encoding}
+	if params.Delimiter != "" {
+		var ok bool
+		if options.Delimiter, ok = export.Delimiters[params.Delimiter]; !ok {
+			return nil, fmt.Errorf("unknown delimiter %q", params.Delimiter)
+		}
+	}
+
+	data, err := importer.ReadCSV(r, options)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := importer.AutoMapping(data.Headers, dto.StatementFields)
+	if params.Mapping != "" {
+		for _, pair := range strings.Split(params.Mapping, ",") {
+			field, header, found := strings.Cut(pair, "=")
+			if !found {
+				return nil, fmt.Errorf("mapping %q isn't in form field=Column", pair)
+			}
+			mapping[strings.TrimSpace(field)] = strings.TrimSpace(header)
+		}
+	}
+
+	return bankstatement.ReadCSV(data, mapping)
+}
@@ -0,0 +1,108 @@
+package csvimport
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/export"
+	"automatedShop/internal/importer"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// systemUser is user rows are imported on behalf of.
+var systemUser = &dto.UserData{Login: "import", IsAdmin: true}
+
+// Params are command line parameters of import.
+type Params struct {
+	Table      string
+	File       string
+	Mapping    string
+	DateLayout string
+	Delimiter  string
+	Encoding   string
+	DryRun     bool
+}
+
+// ProcessApp imports CSV file to handbook or journal. Nothing is imported if any row is invalid,
+// errors of rows are logged instead.
+func ProcessApp(config *configs.ShopConfig, params *Params) error {
+	fields, ok := dto.ImportFields[params.Table]
+	if !ok {
+		return fmt.Errorf("unknown table %q, expected one of %s", params.Table, strings.Join(dto.ImportTables, ", "))
+	}
+
+	options := export.CSVOptions{Encoding: params.Encoding}
+	if params.Delimiter != "" {
+		if options.Delimiter, ok = export.Delimiters[params.Delimiter]; !ok {
+			return fmt.Errorf("unknown delimiter %q", params.Delimiter)
+		}
+	}
+
+	file, err := os.Open(params.File)
+	if err != nil {
+		return fmt.Errorf("failed to open csv file: %w", err)
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	data, err := importer.ReadCSV(file, options)
+	if err != nil {
+		return err
+	}
+
+	mapping := importer.AutoMapping(data.Headers, fields)
+	if params.Mapping != "" {
+		for _, pair := range strings.Split(params.Mapping, ",") {
+			field, header, found := strings.Cut(pair, "=")
+			if !found {
+				return fmt.Errorf("mapping %q isn't in form field=Column", pair)
+			}
+			mapping[strings.TrimSpace(field)] = strings.TrimSpace(header)
+		}
+	}
+
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	res, err := s.ImportService.ImportCSV(session.WithUser(ctx, systemUser), &dto.ImportParams{
+		Table:      params.Table,
+		Headers:    data.Headers,
+		Rows:       data.Rows,
+		Mapping:    mapping,
+		DateLayout: params.DateLayout,
+		DryRun:     params.DryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	for _, rowErr := range res.Errors {
+		logrus.Errorf("line %d: %s: %s", rowErr.Line, rowErr.Field, rowErr.Message)
+	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("%d errors in %d rows, nothing imported", len(res.Errors), res.Rows)
+	}
+
+	if params.DryRun {
+		logrus.Infof("dry run: %d rows are valid, dates are in %q", res.Rows, res.DateLayout)
+		return nil
+	}
+	logrus.Infof("%d rows imported to %s", res.Imported, params.Table)
+	return nil
+}
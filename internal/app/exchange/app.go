@@ -0,0 +1,139 @@
+package exchange
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/period"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// exchangeDir is the directory files are exported to by default.
+const exchangeDir = "exchange"
+
+// fileNames are names 1C gives to exchange files of each kind.
+var fileNames = map[string]string{
+	dto.ExchangeCatalog: "import.xml",
+	dto.ExchangeOffers:  "offers.xml",
+	dto.ExchangeSales:   "orders.xml",
+}
+
+// systemUser is user files are exchanged on behalf of.
+var systemUser = &dto.UserData{Login: "exchange", IsAdmin: true}
+
+// Params are command line parameters of exchange. Either Export or Import must be set.
+type Params struct {
+	Export string
+	Import string
+	Out    string
+	From   string
+	To     string
+}
+
+// ProcessApp exports or imports CommerceML file.
+func ProcessApp(config *configs.ShopConfig, params *Params) error {
+	if (params.Export == "") == (params.Import == "") {
+		return errors.New("either export or import must be given")
+	}
+
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx = session.WithUser(ctx, systemUser)
+
+	if params.Import != "" {
+		return importFile(ctx, s, params.Import)
+	}
+	return exportFile(ctx, s, params)
+}
+
+func exportFile(ctx context.Context, s *services.Service, params *Params) error {
+	if _, ok := fileNames[params.Export]; !ok {
+		return fmt.Errorf("unknown kind %q, expected catalog, offers or sales", params.Export)
+	}
+
+	now := time.Now()
+	p := period.LastDays(now, 30)
+	if params.From != "" || params.To != "" {
+		from, to := params.From, params.To
+		if from == "" {
+			from = p.From.Format(period.DateLayout)
+		}
+		if to == "" {
+			to = now.Format(period.DateLayout)
+		}
+		var err error
+		if p, err = period.Parse(from, to); err != nil {
+			return err
+		}
+	}
+
+	out := params.Out
+	if out == "" {
+		out = filepath.Join(exchangeDir, fileNames[params.Export])
+	}
+	if err := os.MkdirAll(filepath.Dir(out), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create exchange dir: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(out), filepath.Base(out)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create exchange file: %w", err)
+	}
+	defer func(name string) { _ = os.Remove(name) }(file.Name())
+
+	err = s.ExchangeService.Export(ctx, file, &dto.ExchangeExportParams{Kind: params.Export, Period: p, Now: now})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	if err = os.Rename(file.Name(), out); err != nil {
+		return fmt.Errorf("failed to save exchange file: %w", err)
+	}
+
+	logrus.Infof("%s exported to %s", params.Export, out)
+	return nil
+}
+
+func importFile(ctx context.Context, s *services.Service, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open exchange file: %w", err)
+	}
+	defer file.Close()
+
+	res, err := s.ExchangeService.Import(ctx, file)
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	for _, e := range res.Errors {
+		logrus.Error(e)
+	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("import failed: %d errors, nothing was imported", len(res.Errors))
+	}
+
+	logrus.Infof("created %d, updated %d, skipped %d records", res.Created, res.Updated, res.Skipped)
+	return nil
+}
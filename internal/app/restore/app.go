@@ -0,0 +1,81 @@
+package restore
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/backup"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// systemUser is user backups are restored on behalf of.
+var systemUser = &dto.UserData{Login: "restore", IsAdmin: true}
+
+// Params are command line parameters of restore.
+type Params struct {
+	File    string
+	Replace bool
+	Users   bool
+	Check   bool
+}
+
+// ProcessApp restores shop data from backup file, or only verifies the file when params.Check is set.
+func ProcessApp(config *configs.ShopConfig, params *Params) error {
+	file, err := os.Open(params.File)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+
+	if params.Check {
+		archive, err := backup.OpenReader(file, stat.Size())
+		if err != nil {
+			return err
+		}
+		if err = archive.Verify(); err != nil {
+			return err
+		}
+
+		logrus.Infof("backup of schema version %d made at %s is intact", archive.Manifest.SchemaVersion,
+			archive.Manifest.CreatedAt)
+		return nil
+	}
+
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	res, err := s.BackupService.Restore(session.WithUser(ctx, systemUser), file, stat.Size(), &dto.RestoreParams{
+		Replace: params.Replace,
+		Users:   params.Users,
+	})
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	for _, table := range res.Tables {
+		logrus.Infof("%s: %d rows", table.Name, table.Rows)
+	}
+	logrus.Infof("backup of schema version %d made at %s restored", res.SchemaVersion, res.CreatedAt)
+	return nil
+}
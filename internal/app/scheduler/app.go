@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/scheduler"
+	"automatedShop/internal/services"
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ProcessApp runs due report schedules once, e.g. from cron or systemd timer, or every interval
+// until the process is stopped when interval isn't zero.
+func ProcessApp(config *configs.ShopConfig, interval time.Duration) error {
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+	sc := scheduler.New(s, config.ShopName)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if interval > 0 {
+		sc.Loop(ctx, interval)
+		return nil
+	}
+
+	if _, err = sc.RunDue(ctx); err != nil {
+		return fmt.Errorf("scheduled reports failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package seed
+
+import (
+	"automatedShop/configs"
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// systemUser is user demo data is generated on behalf of.
+var systemUser = &dto.UserData{Login: "seed", IsAdmin: true}
+
+// Params are command line parameters of seeding.
+type Params struct {
+	Seed        int64
+	Items       int
+	Days        int
+	SalesPerDay int
+	Users       int
+	Append      bool
+}
+
+// ProcessApp fills database with demo data and prints passwords of created users.
+func ProcessApp(config *configs.ShopConfig, params *Params) error {
+	provider, err := dataprovider.NewPsqlProvider(config.DbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db with error: %w", err)
+	}
+
+	r := repository.NewRepository(provider)
+	s := services.NewService(r)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	res, err := s.SeedService.Seed(session.WithUser(ctx, systemUser), &dto.SeedParams{
+		Seed:        params.Seed,
+		Items:       params.Items,
+		Days:        params.Days,
+		SalesPerDay: params.SalesPerDay,
+		Users:       params.Users,
+		Now:         time.Now(),
+		Append:      params.Append,
+	})
+	if err != nil {
+		return fmt.Errorf("seeding failed: %w", err)
+	}
+
+	logrus.Infof("created %d warehouses items, %d expense items, %d charges and %d sales",
+		res.Items, res.ExpenseItems, res.Charges, res.Sales)
+	for _, user := range res.Users {
+		role := "user"
+		if user.IsAdmin {
+			role = "admin"
+		}
+		logrus.Infof("created %s %s with password %s", role, user.Login, user.Password)
+	}
+	return nil
+}
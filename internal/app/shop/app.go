@@ -5,7 +5,9 @@ import (
 	"automatedShop/internal/dataprovider"
 	"automatedShop/internal/graphics"
 	"automatedShop/internal/repository"
+	"automatedShop/internal/scheduler"
 	"automatedShop/internal/services"
+	"context"
 	"fmt"
 )
 
@@ -17,7 +19,14 @@ func ProcessApp(config *configs.ShopConfig) error {
 
 	r := repository.NewRepository(provider)
 	s := services.NewService(r)
-	g := graphics.NewAppManager(s)
+	sc := scheduler.New(s, config.ShopName)
+	g := graphics.NewAppManager(s, sc, config.ShopName)
+
+	if config.SchedulerInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go sc.Loop(ctx, config.SchedulerInterval)
+	}
 
 	g.Run()
 	return nil
@@ -0,0 +1,205 @@
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Format identifies backups of the shop. FormatVersion is version of layout of the archive itself,
+// versions of tables' columns are covered by schema version.
+const (
+	Format        = "automatedShop-backup"
+	FormatVersion = 1
+)
+
+const manifestName = "manifest.json"
+
+// ErrCorrupted is returned for archives whose tables don't match checksums or row counts of manifest.
+var ErrCorrupted = errors.New("backup is corrupted")
+
+// Manifest describes backup. It's the last file of archive, so archives cut short have no manifest.
+type Manifest struct {
+	Format        string       `json:"format"`
+	FormatVersion int          `json:"format_version"`
+	SchemaVersion int          `json:"schema_version"`
+	CreatedAt     string       `json:"created_at"`
+	Tables        []*TableInfo `json:"tables"`
+}
+
+// TableInfo describes table saved to archive as <Name>.ndjson, one JSON object per row.
+type TableInfo struct {
+	Name   string `json:"name"`
+	Rows   int64  `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// Writer writes backup as zip archive of tables and manifest.
+type Writer struct {
+	zw       *zip.Writer
+	manifest *Manifest
+}
+
+func NewWriter(w io.Writer, schemaVersion int, createdAt time.Time) *Writer {
+	return &Writer{
+		zw: zip.NewWriter(w),
+		manifest: &Manifest{
+			Format:        Format,
+			FormatVersion: FormatVersion,
+			SchemaVersion: schemaVersion,
+			CreatedAt:     createdAt.Format(time.RFC3339),
+		},
+	}
+}
+
+// WriteTable saves rows passed by dump to emit. Each row is JSON object without line breaks.
+func (w *Writer) WriteTable(name string, dump func(emit func(row []byte) error) error) error {
+	file, err := w.zw.Create(name + ".ndjson")
+	if err != nil {
+		return err
+	}
+
+	info := &TableInfo{Name: name}
+	sum := sha256.New()
+	out := io.MultiWriter(file, sum)
+	err = dump(func(row []byte) error {
+		if bytes.ContainsAny(row, "\r\n") {
+			return fmt.Errorf("row of %s contains line break", name)
+		}
+		if _, err := out.Write(append(row, '\n')); err != nil {
+			return err
+		}
+		info.Rows++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", name, err)
+	}
+
+	info.SHA256 = hex.EncodeToString(sum.Sum(nil))
+	w.manifest.Tables = append(w.manifest.Tables, info)
+	return nil
+}
+
+// Close writes manifest and finishes archive.
+func (w *Writer) Close() (*Manifest, error) {
+	file, err := w.zw.Create(manifestName)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(w.manifest); err != nil {
+		return nil, err
+	}
+
+	if err = w.zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return w.manifest, nil
+}
+
+// Reader reads backup written by Writer.
+type Reader struct {
+	zr       *zip.Reader
+	Manifest *Manifest
+}
+
+// OpenReader reads manifest of archive and checks that it's a backup of known format.
+func OpenReader(r io.ReaderAt, size int64) (*Reader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	file, err := zr.Open(manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: no manifest", ErrCorrupted)
+	}
+	defer func(file io.Closer) { _ = file.Close() }(file)
+
+	var manifest Manifest
+	if err = json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %w", ErrCorrupted, err)
+	}
+	if manifest.Format != Format {
+		return nil, fmt.Errorf("%w: not a backup of the shop", ErrCorrupted)
+	}
+	if manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("backup format %d is newer than supported %d", manifest.FormatVersion, FormatVersion)
+	}
+
+	return &Reader{zr: zr, Manifest: &manifest}, nil
+}
+
+// Table returns description of table or nil if backup has no such table.
+func (r *Reader) Table(name string) *TableInfo {
+	for _, table := range r.Manifest.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+
+	return nil
+}
+
+// Verify reads all tables and checks them against manifest.
+func (r *Reader) Verify() error {
+	for _, table := range r.Manifest.Tables {
+		if err := r.ReadTable(table.Name, func([]byte) error { return nil }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadTable passes rows of table to fn. Checksum and number of rows are checked once the table is
+// read, so fn should be run in transaction which is rolled back on error.
+func (r *Reader) ReadTable(name string, fn func(row []byte) error) error {
+	info := r.Table(name)
+	if info == nil {
+		return fmt.Errorf("%w: no table %s", ErrCorrupted, name)
+	}
+
+	file, err := r.zr.Open(name + ".ndjson")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	defer func(file io.Closer) { _ = file.Close() }(file)
+
+	sum := sha256.New()
+	reader := bufio.NewReader(io.TeeReader(file, sum))
+
+	var rows int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			rows++
+			if err := fn(bytes.TrimSuffix(line, []byte{'\n'})); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrCorrupted, err)
+		}
+	}
+
+	if rows != info.Rows || hex.EncodeToString(sum.Sum(nil)) != info.SHA256 {
+		return fmt.Errorf("%w: %s doesn't match its checksum", ErrCorrupted, name)
+	}
+
+	return nil
+}
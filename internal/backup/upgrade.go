@@ -0,0 +1,28 @@
+package backup
+
+import "fmt"
+
+// Upgrade converts row of table saved with schema version Version-1 to schema version Version.
+type Upgrade struct {
+	Version int
+	Apply   func(table string, row map[string]any) error
+}
+
+// upgrades are steps of conversion of rows of older backups, in order of versions. Columns added by
+// migrations need no steps, rows missing them get default values on restore. Steps are needed when
+// migration renames or drops column or changes meaning of its values.
+var upgrades []Upgrade
+
+// UpgradeRow converts row of table saved with schema version from to schema version to.
+func UpgradeRow(from, to int, table string, row map[string]any) error {
+	for _, upgrade := range upgrades {
+		if upgrade.Version <= from || upgrade.Version > to {
+			continue
+		}
+		if err := upgrade.Apply(table, row); err != nil {
+			return fmt.Errorf("upgrade of %s to schema version %d failed: %w", table, upgrade.Version, err)
+		}
+	}
+
+	return nil
+}
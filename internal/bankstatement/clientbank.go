@@ -0,0 +1,121 @@
+package bankstatement
+
+import (
+	"automatedShop/internal/services/dto"
+	"bufio"
+	"bytes"
+	"fmt"
+	"golang.org/x/text/encoding/charmap"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// clientBankHeader is the first line of client bank exchange files.
+const clientBankHeader = "1CClientBankExchange"
+
+// ReadClientBank reads payments of 1C client bank exchange file. Files are written in windows-1251
+// or, when they say Кодировка=DOS, in cp866. Payments from accounts listed in the file are
+// outgoing, when the file lists none, payments with debit date are.
+func ReadClientBank(r io.Reader) ([]*dto.BankPaymentData, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+	if !bytes.HasPrefix(data, []byte(clientBankHeader)) {
+		return nil, fmt.Errorf("file isn't a client bank exchange file")
+	}
+
+	text := string(data)
+	if !utf8.Valid(data) {
+		decoder := charmap.Windows1251.NewDecoder()
+		if bytes.Contains(data, []byte("=DOS")) {
+			decoder = charmap.CodePage866.NewDecoder()
+		}
+		if text, err = decoder.String(text); err != nil {
+			return nil, fmt.Errorf("failed to decode statement: %w", err)
+		}
+	}
+
+	accounts := make(map[string]bool)
+	var documents []map[string]string
+	var document map[string]string
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, _ := strings.Cut(line, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch {
+		case key == "СекцияДокумент":
+			document = map[string]string{}
+		case key == "КонецДокумента":
+			if document != nil {
+				documents = append(documents, document)
+			}
+			document = nil
+		case document != nil:
+			// some banks repeat fields, the first value is kept
+			if _, ok := document[key]; !ok {
+				document[key] = value
+			}
+		case key == "РасчСчет" && value != "":
+			accounts[value] = true
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	k := newKeyer()
+	payments := make([]*dto.BankPaymentData, 0, len(documents))
+	for _, document := range documents {
+		payment, err := clientBankPayment(k, document, accounts)
+		if err != nil {
+			return nil, fmt.Errorf("document %s: %w", document["Номер"], err)
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+func clientBankPayment(k *keyer, document map[string]string, accounts map[string]bool) (*dto.BankPaymentData, error) {
+	payer := first(document, "ПлательщикСчет", "ПлательщикРасчСчет")
+	recipient := first(document, "ПолучательСчет", "ПолучательРасчСчет")
+
+	outgoing := document["ДатаСписано"] != ""
+	if len(accounts) > 0 {
+		outgoing = accounts[payer]
+	}
+
+	date, err := parseDate(first(document, "ДатаСписано", "ДатаПоступило", "Дата"))
+	if err != nil {
+		return nil, err
+	}
+	amount, err := parseAmount(document["Сумма"])
+	if err != nil {
+		return nil, err
+	}
+
+	counterparty := first(document, "Плательщик1", "Плательщик")
+	if outgoing {
+		counterparty = first(document, "Получатель1", "Получатель")
+	}
+
+	return newPayment(k, document["Номер"], date, amount, counterparty, document["НазначениеПлатежа"], outgoing,
+		payer, recipient), nil
+}
+
+// first returns the first non-empty field of document.
+func first(document map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := document[key]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,193 @@
+package bankstatement
+
+import (
+	"automatedShop/internal/services/dto"
+	"golang.org/x/text/encoding/charmap"
+	"strings"
+	"testing"
+)
+
+// statement is client bank exchange file of account 40702810000000000001 with rent paid twice the
+// same day, payment received and commission of bank.
+const statement = `1CClientBankExchange
+ВерсияФормата=1.03
+Кодировка=Windows
+РасчСчет=40702810000000000001
+СекцияДокумент=Платежное поручение
+Номер=12
+Дата=05.03.2024
+Сумма=15000.50
+Сумма=1.00
+ПлательщикСчет=40702810000000000001
+Плательщик1=ООО Магазин
+ПолучательСчет=40702810000000000002
+Получатель1=ООО Аренда
+ДатаСписано=05.03.2024
+НазначениеПлатежа=Аренда за март
+КонецДокумента
+СекцияДокумент=Платежное поручение
+Номер=12
+Дата=05.03.2024
+Сумма=15000.50
+ПлательщикСчет=40702810000000000001
+Плательщик1=ООО Магазин
+ПолучательСчет=40702810000000000002
+Получатель1=ООО Аренда
+ДатаСписано=05.03.2024
+НазначениеПлатежа=Аренда за март
+КонецДокумента
+СекцияДокумент=Платежное поручение
+Номер=7
+Дата=06.03.2024
+Сумма=2 000,00
+ПлательщикСчет=40702810000000000003
+Плательщик1=ИП Покупатель
+ПолучательСчет=40702810000000000001
+Получатель1=ООО Магазин
+ДатаПоступило=06.03.2024
+НазначениеПлатежа=Оплата по счету 5
+КонецДокумента
+СекцияДокумент=Банковский ордер
+Номер=3
+Дата=07.03.2024
+Сумма=99.4
+ПлательщикРасчСчет=40702810000000000001
+Плательщик=ООО Магазин
+ПолучательРасчСчет=30101810000000000001
+Получатель=Банк
+ДатаСписано=07.03.2024
+НазначениеПлатежа=Комиссия за ведение счета
+КонецДокумента
+КонецФайла
+`
+
+func readStatement(t *testing.T, text string) []*dto.BankPaymentData {
+	t.Helper()
+
+	payments, err := ReadClientBank(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ReadClientBank() error = %v", err)
+	}
+
+	return payments
+}
+
+func encode(t *testing.T, encoding *charmap.Charmap, text string) string {
+	t.Helper()
+
+	encoded, err := encoding.NewEncoder().String(text)
+	if err != nil {
+		t.Fatalf("failed to encode statement: %v", err)
+	}
+
+	return encoded
+}
+
+func TestReadClientBank(t *testing.T) {
+	payments := readStatement(t, encode(t, charmap.Windows1251, statement))
+
+	want := []dto.BankPaymentData{
+		// the first value of repeated field is kept
+		{Number: "12", Date: "2024-03-05", Amount: 15001, Counterparty: "ООО Аренда", Description: "Аренда за март",
+			Outgoing: true},
+		{Number: "12", Date: "2024-03-05", Amount: 15001, Counterparty: "ООО Аренда", Description: "Аренда за март",
+			Outgoing: true},
+		{Number: "7", Date: "2024-03-06", Amount: 2000, Counterparty: "ИП Покупатель",
+			Description: "Оплата по счету 5"},
+		{Number: "3", Date: "2024-03-07", Amount: 99, Counterparty: "Банк", Description: "Комиссия за ведение счета",
+			Outgoing: true},
+	}
+	if len(payments) != len(want) {
+		t.Fatalf("ReadClientBank() returned %d payments, want %d", len(payments), len(want))
+	}
+	for i, payment := range payments {
+		got := *payment
+		got.Key = ""
+		if got != want[i] {
+			t.Errorf("payment %d = %+v, want %+v", i+1, got, want[i])
+		}
+	}
+}
+
+func TestReadClientBankEncodings(t *testing.T) {
+	want := readStatement(t, statement)
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"windows-1251", encode(t, charmap.Windows1251, statement)},
+		{"cp866", encode(t, charmap.CodePage866, strings.Replace(statement, "Кодировка=Windows", "Кодировка=DOS", 1))},
+		{"utf-8 with BOM", "\xEF\xBB\xBF" + statement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments := readStatement(t, tt.text)
+			if len(payments) != len(want) {
+				t.Fatalf("ReadClientBank() returned %d payments, want %d", len(payments), len(want))
+			}
+			for i := range payments {
+				if *payments[i] != *want[i] {
+					t.Errorf("payment %d = %+v, want %+v", i+1, *payments[i], *want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadClientBankOutgoingByDebitDate(t *testing.T) {
+	// without accounts of the file payments with debit date are outgoing
+	payments := readStatement(t, strings.Replace(statement, "РасчСчет=40702810000000000001\n", "", 1))
+
+	for i, want := range []bool{true, true, false, true} {
+		if payments[i].Outgoing != want {
+			t.Errorf("payment %d outgoing = %v, want %v", i+1, payments[i].Outgoing, want)
+		}
+	}
+}
+
+func TestReadClientBankKeys(t *testing.T) {
+	payments := readStatement(t, statement)
+	again := readStatement(t, statement)
+
+	keys := make(map[string]bool)
+	for i, payment := range payments {
+		if payment.Key != again[i].Key {
+			t.Errorf("payment %d has key %s, then %s when statement is read again", i+1, payment.Key, again[i].Key)
+		}
+		keys[payment.Key] = true
+	}
+	// equal payments of one day are different payments
+	if len(keys) != len(payments) {
+		t.Errorf("%d payments have %d different keys", len(payments), len(keys))
+	}
+
+	// statement of the first day only gives its payments the same keys
+	overlapping := readStatement(t, statement[:strings.Index(statement, "СекцияДокумент=Платежное поручение\nНомер=7")]+
+		"КонецФайла\n")
+	for i, payment := range overlapping {
+		if payment.Key != payments[i].Key {
+			t.Errorf("payment %d of overlapping statement has key %s, want %s", i+1, payment.Key, payments[i].Key)
+		}
+	}
+}
+
+func TestReadClientBankErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"not a statement", "Дата;Сумма\n05.03.2024;100\n"},
+		{"invalid amount", strings.Replace(statement, "Сумма=99.4", "Сумма=девяносто", 1)},
+		{"invalid date", strings.Replace(statement, "ДатаСписано=07.03.2024", "ДатаСписано=07 марта", 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadClientBank(strings.NewReader(tt.text)); err == nil {
+				t.Error("ReadClientBank() error = nil, want error")
+			}
+		})
+	}
+}
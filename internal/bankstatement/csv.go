@@ -0,0 +1,74 @@
+package bankstatement
+
+import (
+	"automatedShop/internal/importer"
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// ReadCSV reads payments of CSV statement. mapping maps fields of dto.StatementFields to headers
+// of file, date and either amount or debit must be mapped. Negative amounts and amounts of debit
+// column are outgoing payments.
+func ReadCSV(file *importer.File, mapping map[string]string) ([]*dto.BankPaymentData, error) {
+	columns := make(map[string]int, len(mapping))
+	for field, header := range mapping {
+		if header == "" {
+			continue
+		}
+		index := slices.Index(file.Headers, header)
+		if index < 0 {
+			return nil, fmt.Errorf("file has no column %q", header)
+		}
+		columns[field] = index
+	}
+
+	if _, ok := columns[dto.StatementFieldDate]; !ok {
+		return nil, fmt.Errorf("field %s isn't mapped", dto.StatementFieldDate)
+	}
+	_, byAmount := columns[dto.StatementFieldAmount]
+	_, byDebit := columns[dto.StatementFieldDebit]
+	if !byAmount && !byDebit {
+		return nil, fmt.Errorf("either %s or %s must be mapped", dto.StatementFieldAmount, dto.StatementFieldDebit)
+	}
+
+	k := newKeyer()
+	payments := make([]*dto.BankPaymentData, 0, len(file.Rows))
+	for i, row := range file.Rows {
+		value := func(field string) string {
+			index, ok := columns[field]
+			if !ok || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+
+		// the first line of file is header
+		date, err := parseDate(value(dto.StatementFieldDate))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+
+		var amount float64
+		var outgoing bool
+		if debit := value(dto.StatementFieldDebit); byDebit && debit != "" {
+			if amount, err = parseAmount(debit); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+			amount, outgoing = math.Abs(amount), amount != 0
+		} else if text := value(dto.StatementFieldAmount); byAmount && text != "" {
+			if amount, err = parseAmount(text); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+			amount, outgoing = math.Abs(amount), amount < 0
+		}
+
+		counterparty, description := value(dto.StatementFieldCounterparty), value(dto.StatementFieldDescription)
+		payments = append(payments, newPayment(k, value(dto.StatementFieldNumber), date, amount, counterparty,
+			description, outgoing, counterparty, description))
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,83 @@
+// Package bankstatement reads payments of bank statements: files of 1C client bank exchange
+// (kl_to_1c.txt) and CSV files exported by internet banks.
+package bankstatement
+
+import (
+	"automatedShop/internal/services/dto"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are layouts of dates statements are written in, tried in order.
+var dateLayouts = []string{
+	"02.01.2006",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"02/01/2006",
+	"02.01.06",
+}
+
+func parseDate(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid date %q", text)
+}
+
+// parseAmount parses sum of money written with dot or comma and spaces between thousands.
+func parseAmount(text string) (float64, error) {
+	clean := strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(strings.TrimSpace(text))
+	value, err := strconv.ParseFloat(clean, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid amount %q", text)
+	}
+
+	return value, nil
+}
+
+// keyer makes keys of payments. Key is hash of fields identifying payment and number of the same
+// payments before it in statement, so that equal payments of one day are told apart and statement
+// gives the same keys every time it's read.
+type keyer struct {
+	seen map[string]int
+}
+
+func newKeyer() *keyer {
+	return &keyer{seen: make(map[string]int)}
+}
+
+func (k *keyer) key(fields ...string) string {
+	joined := strings.Join(fields, "\x1f")
+	n := k.seen[joined]
+	k.seen[joined]++
+
+	sum := sha256.Sum256([]byte(joined + "\x1f" + strconv.Itoa(n)))
+	return hex.EncodeToString(sum[:])
+}
+
+func newPayment(k *keyer, number, date string, amount float64, counterparty, description string, outgoing bool,
+	keyFields ...string) *dto.BankPaymentData {
+	fields := append([]string{number, date, strconv.FormatFloat(amount, 'f', 2, 64)}, keyFields...)
+
+	return &dto.BankPaymentData{
+		Key:          k.key(fields...),
+		Number:       number,
+		Date:         date,
+		Amount:       int(math.Round(amount)),
+		Counterparty: counterparty,
+		Description:  description,
+		Outgoing:     outgoing,
+	}
+}
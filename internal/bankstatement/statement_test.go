@@ -0,0 +1,73 @@
+package bankstatement
+
+import "testing"
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{"05.03.2024", "2024-03-05", false},
+		{" 2024-03-05 ", "2024-03-05", false},
+		{"05.03.2024 14:30", "2024-03-05", false},
+		{"2024-03-05T14:30:00", "2024-03-05", false},
+		{"05/03/2024", "2024-03-05", false},
+		{"05.03.24", "2024-03-05", false},
+		{"31.02.2024", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parseDate(tt.text)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("parseDate() = %q, %v, want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    float64
+		wantErr bool
+	}{
+		{"15000.50", 15000.5, false},
+		{"2 000,00", 2000, false},
+		{"1 234,5", 1234.5, false},
+		{"-99.4", -99.4, false},
+		{"NaN", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := parseAmount(tt.text)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("parseAmount() = %v, %v, want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyer(t *testing.T) {
+	k := newKeyer()
+	first, second := k.key("12", "2024-03-05", "100.00"), k.key("12", "2024-03-05", "100.00")
+	if first == second {
+		t.Error("key() of the second equal payment is the key of the first one")
+	}
+
+	again := newKeyer()
+	if got := again.key("12", "2024-03-05", "100.00"); got != first {
+		t.Errorf("key() = %s, then %s for the same payment", first, got)
+	}
+	if got := again.key("12", "2024-03-05", "100.01"); got == second {
+		t.Error("key() of different payment is the key of equal payment")
+	}
+	// fields aren't simply joined, so that values moved between fields give other keys
+	if newKeyer().key("1", "23") == newKeyer().key("12", "3") {
+		t.Error("key() of different fields is the same")
+	}
+}
@@ -0,0 +1,262 @@
+// Package commerceml reads and writes files of CommerceML 2, the format 1C exchanges catalogs,
+// offers and documents with shops in. Only elements the shop has data for are described.
+package commerceml
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"golang.org/x/text/encoding/charmap"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SchemaVersion is version of CommerceML files written.
+const SchemaVersion = "2.05"
+
+// Business operations of documents.
+const (
+	OperationOrder  = "Заказ товара"
+	OperationSale   = "Отпуск товара"
+	OperationReturn = "Возврат товара"
+)
+
+// Roles of document parties.
+const (
+	RoleSeller = "Продавец"
+	RoleBuyer  = "Покупатель"
+)
+
+// Piece is the base unit of goods of the shop.
+var Piece = &Unit{Code: "796", FullName: "Штука", Abbreviation: "PCE", Name: "шт"}
+
+// Document is root element of exchange file. Files carry either classifier with catalog, package
+// of offers or documents.
+type Document struct {
+	XMLName       xml.Name      `xml:"КоммерческаяИнформация"`
+	SchemaVersion string        `xml:"ВерсияСхемы,attr"`
+	CreatedAt     string        `xml:"ДатаФормирования,attr"`
+	Classifier    *Classifier   `xml:"Классификатор,omitempty"`
+	Catalog       *Catalog      `xml:"Каталог,omitempty"`
+	Offers        *OfferPackage `xml:"ПакетПредложений,omitempty"`
+	Orders        []*Order      `xml:"Документ"`
+}
+
+type Classifier struct {
+	Id     string   `xml:"Ид"`
+	Name   string   `xml:"Наименование"`
+	Groups []*Group `xml:"Группы>Группа"`
+}
+
+// Group of products, groups may be nested.
+type Group struct {
+	Id        string     `xml:"Ид"`
+	Name      string     `xml:"Наименование"`
+	Subgroups *Subgroups `xml:"Группы,omitempty"`
+}
+
+// Subgroups are kept apart, so that groups without subgroups are written without empty element.
+type Subgroups struct {
+	Groups []*Group `xml:"Группа"`
+}
+
+type Catalog struct {
+	OnlyChanges  string     `xml:"СодержитТолькоИзменения,attr,omitempty"`
+	Id           string     `xml:"Ид"`
+	ClassifierId string     `xml:"ИдКлассификатора"`
+	Name         string     `xml:"Наименование"`
+	Products     []*Product `xml:"Товары>Товар"`
+}
+
+type Product struct {
+	Id       string    `xml:"Ид"`
+	Name     string    `xml:"Наименование"`
+	BaseUnit *Unit     `xml:"БазоваяЕдиница,omitempty"`
+	Groups   *GroupIds `xml:"Группы,omitempty"`
+}
+
+type GroupIds struct {
+	Ids []string `xml:"Ид"`
+}
+
+type Unit struct {
+	Code         string `xml:"Код,attr,omitempty"`
+	FullName     string `xml:"НаименованиеПолное,attr,omitempty"`
+	Abbreviation string `xml:"МеждународноеСокращение,attr,omitempty"`
+	Name         string `xml:",chardata"`
+}
+
+type OfferPackage struct {
+	OnlyChanges  string       `xml:"СодержитТолькоИзменения,attr,omitempty"`
+	Id           string       `xml:"Ид"`
+	Name         string       `xml:"Наименование"`
+	CatalogId    string       `xml:"ИдКаталога"`
+	ClassifierId string       `xml:"ИдКлассификатора"`
+	PriceTypes   []*PriceType `xml:"ТипыЦен>ТипЦены"`
+	Offers       []*Offer     `xml:"Предложения>Предложение"`
+}
+
+type PriceType struct {
+	Id       string `xml:"Ид"`
+	Name     string `xml:"Наименование"`
+	Currency string `xml:"Валюта"`
+}
+
+// Offer is price and stock of product. Id of offers of product characteristics is id of product
+// and id of characteristic joined by #.
+type Offer struct {
+	Id       string   `xml:"Ид"`
+	Name     string   `xml:"Наименование"`
+	BaseUnit *Unit    `xml:"БазоваяЕдиница,omitempty"`
+	Prices   []*Price `xml:"Цены>Цена"`
+	Quantity string   `xml:"Количество,omitempty"`
+}
+
+type Price struct {
+	Presentation string `xml:"Представление,omitempty"`
+	PriceTypeId  string `xml:"ИдТипаЦены"`
+	PerUnit      string `xml:"ЦенаЗаЕдиницу"`
+	Currency     string `xml:"Валюта,omitempty"`
+	Unit         string `xml:"Единица,omitempty"`
+	Ratio        string `xml:"Коэффициент,omitempty"`
+}
+
+// Order is business document, e.g. order or sale of goods.
+type Order struct {
+	Id             string          `xml:"Ид"`
+	Number         string          `xml:"Номер"`
+	Date           string          `xml:"Дата"`
+	Operation      string          `xml:"ХозОперация"`
+	Role           string          `xml:"Роль"`
+	Currency       string          `xml:"Валюта"`
+	Rate           string          `xml:"Курс"`
+	Sum            string          `xml:"Сумма"`
+	Counterparties []*Counterparty `xml:"Контрагенты>Контрагент"`
+	Time           string          `xml:"Время"`
+	Products       []*OrderProduct `xml:"Товары>Товар"`
+}
+
+type Counterparty struct {
+	Id   string `xml:"Ид"`
+	Name string `xml:"Наименование"`
+	Role string `xml:"Роль"`
+}
+
+type OrderProduct struct {
+	Id       string `xml:"Ид"`
+	Name     string `xml:"Наименование"`
+	BaseUnit *Unit  `xml:"БазоваяЕдиница,omitempty"`
+	PerUnit  string `xml:"ЦенаЗаЕдиницу"`
+	Quantity string `xml:"Количество"`
+	Sum      string `xml:"Сумма"`
+}
+
+// Read decodes exchange file. Files in UTF-8, with or without byte order mark, and windows-1251
+// are read.
+func Read(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CommerceML file: %w", err)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(charset) {
+		case "utf-8":
+			return input, nil
+		case "windows-1251", "cp1251":
+			return charmap.Windows1251.NewDecoder().Reader(input), nil
+		default:
+			return nil, fmt.Errorf("unsupported encoding %s", charset)
+		}
+	}
+
+	var doc Document
+	if err = decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse CommerceML file: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// Write encodes exchange file in UTF-8.
+func Write(w io.Writer, doc *Document) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write CommerceML file: %w", err)
+	}
+
+	return encoder.Close()
+}
+
+// NewId returns random id in GUID format 1C uses for its objects.
+func NewId() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return formatId(b[:])
+}
+
+// StableId returns id in GUID format which is the same for the same name, e.g. for groups made of
+// categories.
+func StableId(name string) string {
+	sum := sha1.Sum([]byte("automatedShop:" + name))
+	b := sum[:16]
+	b[6] = b[6]&0x0f | 0x50
+	b[8] = b[8]&0x3f | 0x80
+
+	return formatId(b)
+}
+
+func formatId(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ParseNumber parses decimal number written with dot or comma and rounds it to integer.
+func ParseNumber(text string) (int, error) {
+	value, err := parseDecimal(text)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(math.Round(value)), nil
+}
+
+// ParseCount parses number of pieces. 1C writes them as decimals, fractional ones are an error.
+func ParseCount(text string) (int, error) {
+	value, err := parseDecimal(text)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 || value != math.Trunc(value) {
+		return 0, fmt.Errorf("%q isn't a number of pieces", text)
+	}
+
+	return int(value), nil
+}
+
+func parseDecimal(text string) (float64, error) {
+	clean := strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(strings.TrimSpace(text))
+	value, err := strconv.ParseFloat(clean, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%q isn't a number", text)
+	}
+
+	return value, nil
+}
+
+// FormatNumber writes integer amount the way 1C writes decimals.
+func FormatNumber(value int64) string {
+	return strconv.FormatInt(value, 10) + ".00"
+}
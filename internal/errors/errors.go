@@ -13,6 +13,24 @@ var (
 	ErrTooSmallPwdLen = errors.New("password must contain at least 5 symbols")
 )
 
+var (
+	ErrRecordNotFound = errors.New("record not found")
+	ErrRecordInUse    = errors.New("record is referenced by other records")
+	ErrUnknownTable   = errors.New("unknown table")
+	ErrAccessDenied   = errors.New("access denied")
+	// ErrVersionConflict is returned when record was changed by someone else since it was read.
+	ErrVersionConflict = errors.New("record was changed by another user")
+
+	ErrInvalidReportParams = errors.New("invalid report parameters")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrInvalidImport       = errors.New("invalid import")
+	ErrInvalidBackup       = errors.New("invalid backup")
+	ErrInvalidSeedParams   = errors.New("invalid seed parameters")
+	ErrInvalidExchange     = errors.New("invalid exchange file")
+	ErrInvalidStatement    = errors.New("invalid bank statement")
+	ErrInvalidBankRule     = errors.New("invalid bank rule")
+)
+
 var ErrHttpInternal = errors.New("some internal error happened")
 var ErrHttpConflict = errors.New("server state conflict")
 var ErrHttpTimeout = errors.New("request timeout")
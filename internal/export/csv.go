@@ -0,0 +1,84 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+	"io"
+)
+
+// Encodings of CSV files.
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingUTF8BOM     = "utf-8 with BOM"
+	EncodingWindows1251 = "windows-1251"
+)
+
+var Encodings = []string{EncodingUTF8, EncodingUTF8BOM, EncodingWindows1251}
+
+// Delimiters maps names of CSV delimiters shown to user to delimiters.
+var Delimiters = map[string]rune{
+	"comma":     ',',
+	"semicolon": ';',
+	"tab":       '\t',
+}
+
+// utf8BOM makes Excel open UTF-8 files with Cyrillic text correctly.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// CSVOptions sets up delimiter and encoding of CSV files. Zero values mean comma and UTF-8.
+type CSVOptions struct {
+	Delimiter rune
+	Encoding  string
+}
+
+type CSVExporter struct {
+	Options CSVOptions
+}
+
+func (e *CSVExporter) Extension() string {
+	return ".csv"
+}
+
+// Export writes header row and rows of table. Characters which are missing in the chosen
+// encoding fail export.
+func (e *CSVExporter) Export(w io.Writer, table *Table) error {
+	switch e.Options.Encoding {
+	case "", EncodingUTF8:
+	case EncodingUTF8BOM:
+		if _, err := w.Write(utf8BOM); err != nil {
+			return err
+		}
+	case EncodingWindows1251:
+		encoder := transform.NewWriter(w, charmap.Windows1251.NewEncoder())
+		if err := e.write(encoder, table); err != nil {
+			return err
+		}
+		// encoder keeps the tail of text until it's closed
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown csv encoding %q", e.Options.Encoding)
+	}
+
+	return e.write(w, table)
+}
+
+func (e *CSVExporter) write(w io.Writer, table *Table) error {
+	writer := csv.NewWriter(w)
+	if e.Options.Delimiter != 0 {
+		writer.Comma = e.Options.Delimiter
+	}
+
+	if err := writer.Write(table.Headers); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return nil
+}
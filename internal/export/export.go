@@ -0,0 +1,51 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Formats tables are exported to.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatHTML = "html"
+	FormatJSON = "json"
+)
+
+var Formats = []string{FormatCSV, FormatXLSX, FormatHTML, FormatJSON}
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Table is titled table of text cells exported as a whole. Lines describe parameters of report
+// the table belongs to, formats without place for them skip them.
+type Table struct {
+	Title   string
+	Lines   []string
+	Headers []string
+	Rows    [][]string
+}
+
+// Exporter writes table to a file of one format.
+type Exporter interface {
+	// Extension returns extension of exported files with leading dot.
+	Extension() string
+	Export(w io.Writer, table *Table) error
+}
+
+// New returns exporter of given format. Options are used by CSV exporter only.
+func New(format string, options CSVOptions) (Exporter, error) {
+	switch format {
+	case FormatCSV:
+		return &CSVExporter{Options: options}, nil
+	case FormatXLSX:
+		return &XLSXExporter{}, nil
+	case FormatHTML:
+		return &HTMLExporter{}, nil
+	case FormatJSON:
+		return &JSONExporter{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
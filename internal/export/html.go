@@ -0,0 +1,43 @@
+package export
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlPage is standalone page: styles are inline, so it opens the same way without network.
+var htmlPage = template.Must(template.New("table").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #eee; }
+tr:nth-child(even) td { background: #f8f8f8; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Lines}}<p>{{.}}</p>
+{{end}}<table>
+<thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`))
+
+type HTMLExporter struct{}
+
+func (e *HTMLExporter) Extension() string {
+	return ".html"
+}
+
+func (e *HTMLExporter) Export(w io.Writer, table *Table) error {
+	return htmlPage.Execute(w, table)
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type JSONExporter struct{}
+
+func (e *JSONExporter) Extension() string {
+	return ".json"
+}
+
+// Export writes table as object with title, lines, headers and array of rows, each row is array
+// of cells in order of headers.
+func (e *JSONExporter) Export(w io.Writer, table *Table) error {
+	rows := table.Rows
+	if rows == nil {
+		rows = [][]string{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(struct {
+		Title   string     `json:"title"`
+		Lines   []string   `json:"lines,omitempty"`
+		Headers []string   `json:"headers"`
+		Rows    [][]string `json:"rows"`
+	}{Title: table.Title, Lines: table.Lines, Headers: table.Headers, Rows: rows})
+}
@@ -0,0 +1,140 @@
+package graphics
+
+import (
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// saveGeneratedReport archives generated report under reportType, the registry name of report, and lets
+// user choose where to save it. File name is file prefix with generation time, so reports saved before
+// aren't overwritten unless user chooses so.
+func (m *AppManager) saveGeneratedReport(window fyne.Window, reportType, file string, params []string, ext string,
+	content []byte) {
+	fileName := file + "_" + time.Now().Format("2006-01-02_150405") + ext
+
+	err := m.ArchiveService.SaveReport(m.ctx(), &dto.ArchivedReportData{
+		ReportType: reportType,
+		Params:     strings.Join(params, "; "),
+		Format:     strings.TrimPrefix(ext, "."),
+		FileName:   fileName,
+		Content:    content,
+	})
+	if err != nil {
+		errDialog := dialog.NewError(fmt.Errorf("report wasn't archived: %w", err), window)
+		errDialog.SetOnClosed(func() {
+			m.showSaveDialog(window, fileName, content)
+		})
+		errDialog.Show()
+		return
+	}
+
+	m.showSaveDialog(window, fileName, content)
+}
+
+// ShowReportArchive outputs reports generated earlier, they can be opened or downloaded again
+func (m *AppManager) ShowReportArchive(window fyne.Window) {
+	data, err := m.ArchiveService.ShowReports(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"id", "created_at", "user", "report", "format", "parameters"}
+	rows := make([][]string, 0, len(data))
+	for _, report := range data {
+		rows = append(rows, []string{
+			strconv.FormatInt(report.Id, 10),
+			report.CreatedAt,
+			report.UserLogin,
+			report.ReportType,
+			report.Format,
+			report.Params,
+		})
+	}
+
+	table := newStringTable(headers, rows, []float32{60, 170, 100, 180, 70, 400})
+
+	selected := -1
+	table.OnSelected = func(id widget.TableCellID) {
+		selected = id.Row - 1
+	}
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("report_archive", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			widget.NewLabelWithStyle("Select report and open or download it", fyne.TextAlignLeading,
+				fyne.TextStyle{Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(1000, 400), table),
+		),
+	)
+
+	// withSelected loads file of selected report
+	withSelected := func(action func(*dto.ArchivedReportData)) {
+		if selected < 0 || selected >= len(data) {
+			dialog.ShowInformation("Report archive", "Please, select report first", window)
+			return
+		}
+
+		report, err := m.ArchiveService.GetReport(m.ctx(), data[selected].Id)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		action(report)
+	}
+
+	openButton := widget.NewButton("Open", func() {
+		withSelected(func(report *dto.ArchivedReportData) {
+			if err := openArchivedReport(report); err != nil {
+				dialog.ShowError(err, window)
+			}
+		})
+	})
+
+	downloadButton := widget.NewButton("Download", func() {
+		withSelected(func(report *dto.ArchivedReportData) {
+			m.showSaveDialog(window, report.FileName, report.Content)
+		})
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	buttons := container.NewHBox(openButton, downloadButton, exitButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, tableContainer)
+	window.SetContent(content)
+}
+
+// openArchivedReport writes report to temporary file and opens it with application of the system
+func openArchivedReport(report *dto.ArchivedReportData) error {
+	dir := filepath.Join(os.TempDir(), "shop-reports")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	path := filepath.Join(dir, filepath.Base(report.FileName))
+	if err := os.WriteFile(path, report.Content, 0o600); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	fileURL, err := url.Parse(storage.NewFileURI(path).String())
+	if err != nil {
+		return err
+	}
+
+	return fyne.CurrentApp().OpenURL(fileURL)
+}
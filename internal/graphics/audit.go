@@ -0,0 +1,162 @@
+package graphics
+
+import (
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"strconv"
+)
+
+// ShowAuditScreen shows screen with audit log features to admin
+func (m *AppManager) ShowAuditScreen(window fyne.Window) fyne.CanvasObject {
+	auditButton := widget.NewButton("Audit log", func() {
+		m.ShowAuditFilterDialog(window)
+	})
+
+	return container.NewVBox(
+		widget.NewLabelWithStyle("Who changed what and when:", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		auditButton,
+	)
+}
+
+// ShowAuditFilterDialog shows user's form for audit log filtering
+func (m *AppManager) ShowAuditFilterDialog(window fyne.Window) {
+	userEntry := widget.NewEntry()
+	tableSelect := widget.NewSelect(append([]string{""}, editableTables...), nil)
+	recordIdEntry := widget.NewEntry()
+	fromEntry := widget.NewEntry()
+	fromEntry.SetPlaceHolder("YYYY-MM-DD")
+	toEntry := widget.NewEntry()
+	toEntry.SetPlaceHolder("YYYY-MM-DD")
+
+	dialog.ShowForm("Filter audit log", "Show", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("user", userEntry),
+			widget.NewFormItem("table", tableSelect),
+			widget.NewFormItem("record id", recordIdEntry),
+			widget.NewFormItem("from", fromEntry),
+			widget.NewFormItem("to", toEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				var recordId int
+				if recordIdEntry.Text != "" {
+					id, err := strconv.Atoi(recordIdEntry.Text)
+					if err != nil {
+						dialog.ShowError(fmt.Errorf("cannot convert text record id to integer: %w", err), window)
+						return
+					}
+					recordId = id
+				}
+
+				m.ShowAuditTable(window, &dto.AuditFilterData{
+					UserLogin: userEntry.Text,
+					Table:     tableSelect.Selected,
+					RecordId:  recordId,
+					From:      fromEntry.Text,
+					To:        toEntry.Text,
+				})
+			}
+		}, window)
+}
+
+// ShowAuditTable outputs audit records matching filter. Selecting a row shows full record's state.
+func (m *AppManager) ShowAuditTable(window fyne.Window, filter *dto.AuditFilterData) {
+	data, err := m.AuditService.ShowAuditLog(m.ctx(), filter)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"changed_at", "user", "table", "record_id", "action", "before", "after"}
+
+	table := widget.NewTable(
+		func() (int, int) { return len(data) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			label := cell.(*widget.Label)
+
+			if id.Row == 0 {
+				label.SetText(headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Monospace: true}
+				return
+			}
+
+			row := id.Row - 1
+			switch id.Col {
+			case 0:
+				label.SetText(data[row].ChangedAt)
+			case 1:
+				label.SetText(data[row].UserLogin)
+			case 2:
+				label.SetText(data[row].Table)
+			case 3:
+				label.SetText(strconv.Itoa(data[row].RecordId))
+			case 4:
+				label.SetText(data[row].Action)
+			case 5:
+				label.SetText(string(data[row].Before))
+			case 6:
+				label.SetText(string(data[row].After))
+			}
+			label.TextStyle = fyne.TextStyle{Monospace: true}
+		},
+	)
+
+	table.SetColumnWidth(0, 170) // Changed at
+	table.SetColumnWidth(1, 100) // User
+	table.SetColumnWidth(2, 120) // Table
+	table.SetColumnWidth(3, 80)  // Record id
+	table.SetColumnWidth(4, 80)  // Action
+	table.SetColumnWidth(5, 300) // Before
+	table.SetColumnWidth(6, 300) // After
+
+	table.OnSelected = func(id widget.TableCellID) {
+		if id.Row == 0 {
+			return
+		}
+		record := data[id.Row-1]
+		details := widget.NewLabelWithStyle(fmt.Sprintf("before: %s\n\nafter: %s", record.Before, record.After),
+			fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+		details.Wrapping = fyne.TextWrapBreak
+		dialog.ShowCustom(fmt.Sprintf("%s %s #%d", record.Action, record.Table, record.RecordId), "Close",
+			container.NewGridWrap(fyne.NewSize(500, 200), details), window)
+	}
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("audit_log", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(900, 400), table),
+		),
+	)
+
+	filterButton := widget.NewButton("Filter", func() {
+		m.ShowAuditFilterDialog(window)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	topButtons := container.NewHBox(
+		widget.NewSeparator(),
+		container.NewGridWithColumns(1, exitButton),
+	)
+
+	buttons := container.NewHBox(
+		widget.NewSeparator(),
+		container.NewGridWithColumns(1, filterButton),
+	)
+
+	content := container.NewBorder(
+		topButtons,
+		buttons,
+		nil,
+		nil,
+		tableContainer,
+	)
+
+	window.SetContent(content)
+}
@@ -0,0 +1,341 @@
+package graphics
+
+import (
+	"automatedShop/internal/bankstatement"
+	"automatedShop/internal/export"
+	"automatedShop/internal/importer"
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"sort"
+	"strconv"
+)
+
+// ShowBankImportDialog asks user for format of bank statement, then lets user choose the file.
+func (m *AppManager) ShowBankImportDialog(window fyne.Window) {
+	delimiters := []string{importDetect}
+	for name := range export.Delimiters {
+		delimiters = append(delimiters, name)
+	}
+	sort.Strings(delimiters[1:])
+
+	formatSelect := widget.NewSelect([]string{dto.StatementClientBank, dto.StatementCSV}, nil)
+	formatSelect.SetSelected(dto.StatementClientBank)
+	delimiterSelect := widget.NewSelect(delimiters, nil)
+	delimiterSelect.SetSelected(importDetect)
+	encodingSelect := widget.NewSelect([]string{export.EncodingUTF8, export.EncodingWindows1251}, nil)
+	encodingSelect.SetSelected(export.EncodingUTF8)
+
+	dialog.ShowForm("Import bank statement", "Choose file", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("format", formatSelect),
+			widget.NewFormItem("csv delimiter", delimiterSelect),
+			widget.NewFormItem("csv encoding", encodingSelect),
+		}, func(confirmed bool) {
+			if confirmed {
+				options := export.CSVOptions{
+					Delimiter: export.Delimiters[delimiterSelect.Selected],
+					Encoding:  encodingSelect.Selected,
+				}
+				m.showBankFileDialog(window, formatSelect.Selected, options)
+			}
+		}, window)
+}
+
+// showBankFileDialog lets user choose bank statement and reads it.
+func (m *AppManager) showBankFileDialog(window fyne.Window, format string, options export.CSVOptions) {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer func(reader fyne.URIReadCloser) { _ = reader.Close() }(reader)
+
+		if format == dto.StatementClientBank {
+			payments, err := bankstatement.ReadClientBank(reader)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			m.showBankReview(window, payments)
+			return
+		}
+
+		file, err := importer.ReadCSV(reader, options)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		m.showBankMapping(window, reader.URI().Name(), file)
+	}, window)
+
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".txt", ".csv"}))
+	open.Show()
+}
+
+// showBankMapping shows form mapping fields of payments to columns of CSV statement.
+func (m *AppManager) showBankMapping(window fyne.Window, fileName string, file *importer.File) {
+	columns := append([]string{importNone}, file.Headers...)
+	mapping := importer.AutoMapping(file.Headers, dto.StatementFields)
+
+	selects := make([]*widget.Select, len(dto.StatementFields))
+	form := widget.NewForm()
+	for i, field := range dto.StatementFields {
+		selects[i] = widget.NewSelect(columns, nil)
+		if header, ok := mapping[field.Key]; ok {
+			selects[i].SetSelected(header)
+		} else {
+			selects[i].SetSelected(importNone)
+		}
+
+		label := field.Key
+		if field.Required {
+			label += " *"
+		}
+		form.Append(label, selects[i])
+	}
+
+	readButton := widget.NewButton("Read", func() {
+		current := make(map[string]string, len(selects))
+		for i, field := range dto.StatementFields {
+			if selects[i].Selected != importNone && selects[i].Selected != "" {
+				current[field.Key] = selects[i].Selected
+			}
+		}
+
+		payments, err := bankstatement.ReadCSV(file, current)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		m.showBankReview(window, payments)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("bank statement", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+		widget.NewLabelWithStyle(fmt.Sprintf("%s: %d rows, negative amounts or debit are outgoing payments",
+			fileName, len(file.Rows)), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		form,
+	)
+
+	window.SetContent(container.NewBorder(nil, container.NewHBox(readButton, exitButton), nil, nil,
+		container.NewVScroll(content)))
+}
+
+// showBankReview lists outgoing payments with expense items suggested by rules. User picks
+// expense items and payments to import, payments imported before can't be picked.
+func (m *AppManager) showBankReview(window fyne.Window, payments []*dto.BankPaymentData) {
+	review, err := m.BankService.ReviewStatement(m.ctx(), payments)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+	expenseItems, err := m.ShopService.ShowExpenseItemsTable(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	choices := []string{importNone}
+	ids := map[string]int{importNone: 0}
+	labels := map[int]string{0: importNone}
+	for _, item := range expenseItems {
+		label := fmt.Sprintf("%s (%d)", item.Name, item.Id)
+		choices = append(choices, label)
+		ids[label], labels[item.Id] = item.Id, label
+	}
+
+	rows := container.NewVBox()
+	for _, line := range review.Lines {
+		payment := line.Payment
+
+		check := widget.NewCheck("", func(checked bool) { line.Confirmed = checked })
+		check.SetChecked(line.Confirmed)
+		itemSelect := widget.NewSelect(choices, nil)
+		itemSelect.SetSelected(labels[line.ExpenseItemId])
+		// picking expense item picks payment too, so it's set after the suggested item is shown
+		itemSelect.OnChanged = func(choice string) {
+			line.ExpenseItemId = ids[choice]
+			check.SetChecked(line.ExpenseItemId != 0)
+		}
+
+		status := "no matching rule"
+		switch {
+		case line.Duplicate:
+			status = "imported before"
+			check.Disable()
+			itemSelect.Disable()
+		case line.RuleId != 0:
+			status = "rule " + strconv.Itoa(line.RuleId)
+		}
+
+		text := fmt.Sprintf("%s  %10d  %s — %s", payment.Date, payment.Amount, payment.Counterparty, payment.Description)
+		rows.Add(container.NewBorder(nil, nil, check, container.NewHBox(widget.NewLabel(status),
+			container.NewGridWrap(fyne.NewSize(220, 36), itemSelect)), widget.NewLabel(text)))
+	}
+
+	importButton := widget.NewButton("Import", func() {
+		confirmed := 0
+		for _, line := range review.Lines {
+			if line.Confirmed {
+				confirmed++
+			}
+		}
+		dialog.ShowConfirm("Import", fmt.Sprintf("Create %d charges?", confirmed), func(ok bool) {
+			if !ok {
+				return
+			}
+			res, err := m.BankService.ImportStatement(m.ctx(), review.Lines)
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			dialog.ShowInformation("Bank statement", fmt.Sprintf(
+				"%d charges created, %d duplicates and %d payments skipped", res.Created, res.Duplicates, res.Skipped),
+				window)
+			m.ShowMainScreen(window, m.UserLabel.Text)
+		}, window)
+	})
+
+	rulesButton := widget.NewButton("Rules", func() {
+		m.ShowBankRulesTable(window)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	content := container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle("bank statement review", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			widget.NewLabelWithStyle(fmt.Sprintf("%d outgoing payments, %d incoming payments aren't imported",
+				len(review.Lines), review.Incoming), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		),
+		container.NewHBox(importButton, rulesButton, exitButton),
+		nil,
+		nil,
+		container.NewVScroll(rows),
+	)
+	window.SetContent(content)
+}
+
+// ShowBankRulesTable outputs rules matching bank payments to expense items
+func (m *AppManager) ShowBankRulesTable(window fyne.Window) {
+	rules, err := m.BankService.ShowBankRules(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"id", "counterparty contains", "description contains", "expense item"}
+	rows := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		rows = append(rows, []string{strconv.Itoa(rule.Id), rule.Counterparty, rule.Description, rule.ExpenseItem})
+	}
+
+	table := newStringTable(headers, rows, []float32{50, 220, 280, 150})
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("bank rules", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			widget.NewLabelWithStyle("payments get expense item of the first matching rule",
+				fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(720, 400), table),
+		),
+	)
+
+	createButton := widget.NewButton("Create", func() {
+		m.showCreateBankRuleDialog(window)
+	})
+
+	deleteButton := widget.NewButton("Delete", func() {
+		m.showDeleteBankRuleDialog(window)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	content := container.NewBorder(nil, container.NewHBox(createButton, deleteButton, exitButton), nil, nil,
+		tableContainer)
+	window.SetContent(content)
+}
+
+func (m *AppManager) showCreateBankRuleDialog(window fyne.Window) {
+	expenseItems, err := m.ShopService.ShowExpenseItemsTable(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	choices := make([]string, 0, len(expenseItems))
+	ids := make(map[string]int, len(expenseItems))
+	for _, item := range expenseItems {
+		label := fmt.Sprintf("%s (%d)", item.Name, item.Id)
+		choices = append(choices, label)
+		ids[label] = item.Id
+	}
+
+	counterpartyEntry := widget.NewEntry()
+	counterpartyEntry.SetPlaceHolder("e.g. Rent LLC")
+	descriptionEntry := widget.NewEntry()
+	descriptionEntry.SetPlaceHolder("e.g. rent")
+	itemSelect := widget.NewSelect(choices, nil)
+
+	dialog.ShowForm("Create bank rule", "Create", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("counterparty contains", counterpartyEntry),
+			widget.NewFormItem("description contains", descriptionEntry),
+			widget.NewFormItem("expense item", itemSelect),
+		}, func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			err := m.BankService.CreateBankRule(m.ctx(), &dto.BankRuleData{
+				Counterparty:  counterpartyEntry.Text,
+				Description:   descriptionEntry.Text,
+				ExpenseItemId: ids[itemSelect.Selected],
+			})
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			m.ShowBankRulesTable(window)
+		}, window)
+}
+
+func (m *AppManager) showDeleteBankRuleDialog(window fyne.Window) {
+	idEntry := widget.NewEntry()
+
+	dialog.ShowForm("Please, enter Id", "Delete", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("id", idEntry),
+		}, func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			id, err := strconv.Atoi(idEntry.Text)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("id must be a number"), window)
+				return
+			}
+			if err = m.BankService.DeleteBankRule(m.ctx(), id); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			m.ShowBankRulesTable(window)
+		}, window)
+}
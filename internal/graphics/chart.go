@@ -0,0 +1,100 @@
+package graphics
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"image/color"
+	"strconv"
+)
+
+const (
+	chartLeftMargin   = 70
+	chartBottomMargin = 30
+	chartTopMargin    = 10
+)
+
+// newChart draws chart of given size
+func newChart(data *reportDoc.Chart, size fyne.Size) fyne.CanvasObject {
+	axisColor := theme.ForegroundColor()
+	seriesColor := theme.PrimaryColor()
+
+	plotWidth := size.Width - chartLeftMargin
+	plotHeight := size.Height - chartBottomMargin - chartTopMargin
+	maxValue := data.MaxValue()
+
+	var objects []fyne.CanvasObject
+	objects = append(objects,
+		chartLine(axisColor, chartLeftMargin, chartTopMargin, chartLeftMargin, chartTopMargin+plotHeight),
+		chartLine(axisColor, chartLeftMargin, chartTopMargin+plotHeight, size.Width, chartTopMargin+plotHeight),
+		chartText(strconv.FormatFloat(maxValue, 'f', 0, 64), 0, chartTopMargin-6),
+		chartText("0", 0, chartTopMargin+plotHeight-12),
+	)
+
+	if len(data.Values) == 0 {
+		return container.NewGridWrap(size, container.NewWithoutLayout(objects...))
+	}
+
+	step := plotWidth / float32(len(data.Values))
+	y := func(value float64) float32 {
+		return chartTopMargin + plotHeight - float32(value/maxValue)*plotHeight
+	}
+
+	for i, value := range data.Values {
+		x := chartLeftMargin + step*float32(i)
+		if data.Bars {
+			bar := canvas.NewRectangle(seriesColor)
+			bar.Move(fyne.NewPos(x+step*0.1, y(value)))
+			bar.Resize(fyne.NewSize(step*0.8, chartTopMargin+plotHeight-y(value)))
+			objects = append(objects, bar)
+		} else if i > 0 {
+			objects = append(objects, chartLine(seriesColor, x-step/2, y(data.Values[i-1]), x+step/2, y(value)))
+		}
+
+		if i%data.LabelStep() == 0 {
+			objects = append(objects, chartText(data.Labels[i], x, chartTopMargin+plotHeight+4))
+		}
+	}
+
+	return container.NewGridWrap(size, container.NewWithoutLayout(objects...))
+}
+
+func chartLine(c color.Color, x1, y1, x2, y2 float32) *canvas.Line {
+	line := canvas.NewLine(c)
+	line.StrokeWidth = 2
+	line.Position1 = fyne.NewPos(x1, y1)
+	line.Position2 = fyne.NewPos(x2, y2)
+
+	return line
+}
+
+func chartText(text string, x, y float32) *canvas.Text {
+	label := canvas.NewText(text, theme.ForegroundColor())
+	label.TextSize = 10
+	label.Move(fyne.NewPos(x, y))
+
+	return label
+}
+
+// newSparkline draws values as line without axes and labels
+func newSparkline(values []float64, size fyne.Size) fyne.CanvasObject {
+	data := &reportDoc.Chart{Values: values}
+	maxValue := data.MaxValue()
+
+	var objects []fyne.CanvasObject
+	if len(values) > 1 {
+		step := size.Width / float32(len(values)-1)
+		y := func(value float64) float32 {
+			return size.Height - float32(value/maxValue)*size.Height
+		}
+
+		for i := 1; i < len(values); i++ {
+			objects = append(objects, chartLine(theme.PrimaryColor(), step*float32(i-1), y(values[i-1]),
+				step*float32(i), y(values[i])))
+		}
+	}
+
+	return container.NewGridWrap(size, container.NewWithoutLayout(objects...))
+}
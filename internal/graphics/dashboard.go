@@ -0,0 +1,126 @@
+package graphics
+
+import (
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"strconv"
+	"time"
+)
+
+const (
+	dashboardRefreshInterval = 30 * time.Second
+	// lowStockThreshold is quantity below which item is counted as low in stock
+	lowStockThreshold = 5
+)
+
+// ShowDashboardScreen shows key figures of the shop. They are loaded in background and refreshed
+// on timer while dashboard is on screen until main screen is shown again or user logs out.
+func (m *AppManager) ShowDashboardScreen(window fyne.Window) fyne.CanvasObject {
+	m.stopDashboardRefresh()
+
+	valueStyle := fyne.TextStyle{Bold: true, Monospace: true}
+	dayRevenue := widget.NewLabelWithStyle("-", fyne.TextAlignTrailing, valueStyle)
+	monthProfit := widget.NewLabelWithStyle("-", fyne.TextAlignTrailing, valueStyle)
+	salesCount := widget.NewLabelWithStyle("-", fyne.TextAlignTrailing, valueStyle)
+	averageReceipt := widget.NewLabelWithStyle("-", fyne.TextAlignTrailing, valueStyle)
+	lowStock := widget.NewLabelWithStyle("-", fyne.TextAlignTrailing, valueStyle)
+	topProducts := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	sparkline := container.NewStack(newSparkline(nil, fyne.NewSize(300, 60)))
+	status := widget.NewLabelWithStyle("loading...", fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+
+	kpiLabel := func(text string) *widget.Label {
+		return widget.NewLabelWithStyle(text, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	}
+
+	content := container.NewVBox(
+		container.NewGridWithColumns(2,
+			kpiLabel("Today's revenue"), dayRevenue,
+			kpiLabel("Today's sales"), salesCount,
+			kpiLabel("Average receipt"), averageReceipt,
+			kpiLabel("This month's net profit (known cost)"), monthProfit,
+			kpiLabel(fmt.Sprintf("Items with less than %d in stock", lowStockThreshold)), lowStock,
+		),
+		kpiLabel("Revenue of the last 30 days:"),
+		sparkline,
+		kpiLabel("Top products this week:"),
+		topProducts,
+		status,
+	)
+
+	// Context of the logged-in user is taken once, so refreshing never races with logout
+	requestCtx := m.ctx()
+	refresh := func() {
+		data, err := m.ShopService.GetDashboard(requestCtx, &dto.DashboardParams{
+			Now:      time.Now(),
+			LowStock: lowStockThreshold,
+		})
+		if err != nil {
+			status.SetText("failed to refresh: " + err.Error())
+			return
+		}
+
+		dayRevenue.SetText(strconv.FormatInt(data.DayRevenue, 10))
+		salesCount.SetText(strconv.FormatInt(data.DaySalesCount, 10))
+		averageReceipt.SetText(strconv.FormatInt(data.AverageReceipt, 10))
+		monthProfit.SetText(strconv.FormatInt(data.MonthNetProfit, 10))
+		lowStock.SetText(strconv.Itoa(data.LowStockCount))
+
+		top := ""
+		for i, item := range data.TopProducts {
+			top += fmt.Sprintf("%d. %s - %d\n", i+1, item.Name, item.Revenue)
+		}
+		if top == "" {
+			top = "no sales yet"
+		}
+		topProducts.SetText(top)
+
+		values := make([]float64, 0, len(data.Sparkline))
+		for _, point := range data.Sparkline {
+			values = append(values, float64(point.Revenue))
+		}
+		sparkline.Objects = []fyne.CanvasObject{newSparkline(values, fyne.NewSize(300, 60))}
+		sparkline.Refresh()
+
+		status.SetText("updated at " + time.Now().Format("15:04:05"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stopDashboard = cancel
+
+	go func() {
+		refresh()
+
+		ticker := time.NewTicker(dashboardRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if content.Visible() && onCanvas(window, content) {
+					refresh()
+				}
+			}
+		}
+	}()
+
+	return content
+}
+
+// stopDashboardRefresh stops refreshing of dashboard shown before
+func (m *AppManager) stopDashboardRefresh() {
+	if m.stopDashboard != nil {
+		m.stopDashboard()
+		m.stopDashboard = nil
+	}
+}
+
+// onCanvas reports whether object is part of window's current content
+func onCanvas(window fyne.Window, object fyne.CanvasObject) bool {
+	return fyne.CurrentApp().Driver().CanvasForObject(object) == window.Canvas()
+}
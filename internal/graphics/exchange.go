@@ -0,0 +1,128 @@
+package graphics
+
+import (
+	"automatedShop/internal/period"
+	"automatedShop/internal/services/dto"
+	"bytes"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"time"
+)
+
+// exchangeImport is choice of exchange dialog which imports file.
+const exchangeImport = "import file"
+
+// exchangeFileNames are names 1C gives to exchange files of each kind.
+var exchangeFileNames = map[string]string{
+	dto.ExchangeCatalog: "import.xml",
+	dto.ExchangeOffers:  "offers.xml",
+	dto.ExchangeSales:   "orders.xml",
+}
+
+// ShowExchangeDialog asks user whether to export catalog, offers or sales to CommerceML file or to
+// import one. Sales are exported for the given period, the last 30 days by default.
+func (m *AppManager) ShowExchangeDialog(window fyne.Window) {
+	actionSelect := widget.NewSelect(append(append([]string{}, dto.ExchangeKinds...), exchangeImport), nil)
+	actionSelect.SetSelected(dto.ExchangeCatalog)
+	fromEntry := widget.NewEntry()
+	fromEntry.SetPlaceHolder("YYYY-MM-DD")
+	toEntry := widget.NewEntry()
+	toEntry.SetPlaceHolder("YYYY-MM-DD")
+
+	dialog.ShowForm("1C exchange", "OK", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("action", actionSelect),
+			widget.NewFormItem("sales from", fromEntry),
+			widget.NewFormItem("sales to", toEntry),
+		}, func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if actionSelect.Selected == exchangeImport {
+				m.showExchangeFileDialog(window)
+				return
+			}
+
+			now := time.Now()
+			p := period.LastDays(now, 30)
+			if fromEntry.Text != "" || toEntry.Text != "" {
+				var err error
+				if p, err = period.Parse(fromEntry.Text, toEntry.Text); err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+			}
+
+			var buf bytes.Buffer
+			err := m.ExchangeService.Export(m.ctx(), &buf, &dto.ExchangeExportParams{
+				Kind:   actionSelect.Selected,
+				Period: p,
+				Now:    now,
+			})
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+
+			m.showSaveDialog(window, exchangeFileNames[actionSelect.Selected], buf.Bytes())
+		}, window)
+}
+
+// showExchangeFileDialog lets user choose CommerceML file and imports it.
+func (m *AppManager) showExchangeFileDialog(window fyne.Window) {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer func(reader fyne.URIReadCloser) { _ = reader.Close() }(reader)
+
+		res, err := m.ExchangeService.Import(m.ctx(), reader)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		m.showExchangeResult(window, reader.URI().Name(), res)
+	}, window)
+
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".xml"}))
+	open.Show()
+}
+
+// showExchangeResult outputs numbers of imported records or errors of file.
+func (m *AppManager) showExchangeResult(window fyne.Window, fileName string, res *dto.ExchangeResultData) {
+	summary := fmt.Sprintf("%s: %d created, %d updated, %d skipped", fileName, res.Created, res.Updated, res.Skipped)
+	if len(res.Errors) > 0 {
+		summary = fmt.Sprintf("%s: %d errors, nothing imported", fileName, len(res.Errors))
+	}
+
+	rows := make([][]string, 0, len(res.Errors))
+	for _, e := range res.Errors {
+		rows = append(rows, []string{e})
+	}
+
+	errorsTable := newStringTable([]string{"error"}, rows, []float32{700})
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("exchange result", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			widget.NewLabelWithStyle(summary, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(720, 400), errorsTable),
+		),
+	)
+
+	backButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	content := container.NewBorder(nil, container.NewHBox(backButton), nil, nil, tableContainer)
+	window.SetContent(content)
+}
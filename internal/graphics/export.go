@@ -0,0 +1,112 @@
+package graphics
+
+import (
+	"automatedShop/internal/export"
+	"automatedShop/internal/services/dto"
+	"bytes"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"sort"
+	"strconv"
+)
+
+// showExportDialog asks user for file format and, for CSV, its delimiter and encoding, then
+// exports and archives table under reportType and lets user choose where to save it. File name is given
+// without extension.
+func (m *AppManager) showExportDialog(window fyne.Window, table *export.Table, reportType, fileName string) {
+	delimiters := make([]string, 0, len(export.Delimiters))
+	for name := range export.Delimiters {
+		delimiters = append(delimiters, name)
+	}
+	sort.Strings(delimiters)
+
+	formatSelect := widget.NewSelect(export.Formats, nil)
+	formatSelect.SetSelected(export.FormatXLSX)
+	delimiterSelect := widget.NewSelect(delimiters, nil)
+	delimiterSelect.SetSelected("comma")
+	encodingSelect := widget.NewSelect(export.Encodings, nil)
+	encodingSelect.SetSelected(export.EncodingUTF8)
+
+	dialog.ShowForm("Export "+table.Title, "Export", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("format", formatSelect),
+			widget.NewFormItem("csv delimiter", delimiterSelect),
+			widget.NewFormItem("csv encoding", encodingSelect),
+		}, func(confirmed bool) {
+			if confirmed {
+				exporter, err := export.New(formatSelect.Selected, export.CSVOptions{
+					Delimiter: export.Delimiters[delimiterSelect.Selected],
+					Encoding:  encodingSelect.Selected,
+				})
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				var buf bytes.Buffer
+				if err = exporter.Export(&buf, table); err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				m.saveGeneratedReport(window, reportType, fileName, table.Lines, exporter.Extension(), buf.Bytes())
+			}
+		}, window)
+}
+
+func warehousesExportTable(headers []string, data []*dto.WarehousesData) *export.Table {
+	table := &export.Table{Title: "warehouses", Headers: headers}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(item.Id),
+			item.Name,
+			strconv.Itoa(item.Quantity),
+			strconv.Itoa(item.Amount),
+			formatOptionalInt(item.Cost),
+			item.Category,
+			item.Location,
+		})
+	}
+
+	return table
+}
+
+func expenseItemsExportTable(headers []string, data []*dto.ExpenseItemsData) *export.Table {
+	table := &export.Table{Title: "expense_items", Headers: headers}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{strconv.Itoa(item.Id), item.Name})
+	}
+
+	return table
+}
+
+func chargesExportTable(headers []string, data []*dto.ChargesData) *export.Table {
+	table := &export.Table{Title: "charges", Headers: headers}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(item.Id),
+			strconv.Itoa(item.Amount),
+			item.ChargeDate,
+			strconv.Itoa(item.ExpenseItemId),
+		})
+	}
+
+	return table
+}
+
+func salesExportTable(headers []string, data []*dto.SalesData) *export.Table {
+	table := &export.Table{Title: "sales", Headers: headers}
+	for _, item := range data {
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(item.Id),
+			strconv.Itoa(item.Amount),
+			strconv.Itoa(item.Quantity),
+			item.SaleDate,
+			strconv.Itoa(item.WarehousesId),
+			item.Customer,
+		})
+	}
+
+	return table
+}
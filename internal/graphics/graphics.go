@@ -1,18 +1,20 @@
 package graphics
 
 import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/reports"
+	"automatedShop/internal/scheduler"
 	"automatedShop/internal/services"
 	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
 	"context"
+	"errors"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
-	"github.com/jung-kurt/gofpdf"
-	"os"
-	"path/filepath"
 	"strconv"
 )
 
@@ -21,21 +23,58 @@ const (
 )
 
 type AppManager struct {
-	AuthService services.IAuthService
-	ShopService services.IShopService
-	UserLabel   *widget.Entry
+	AuthService     services.IAuthService
+	ShopService     services.IShopService
+	AuditService    services.IAuditService
+	AnalysisService services.IAnalysisService
+	ForecastService services.IForecastService
+	ArchiveService  services.IArchiveService
+	ScheduleService services.IScheduleService
+	PivotService    services.IPivotService
+	AnomalyService  services.IAnomalyService
+	ImportService   services.IImportService
+	ExchangeService services.IExchangeService
+	BankService     services.IBankService
+	UserLabel       *widget.Entry
+	User            *dto.UserData
+	// ShopName is printed on reports
+	ShopName string
+	// Scheduler generates scheduled reports on admin's demand
+	Scheduler *scheduler.Scheduler
+	// Services are passed to report plugins
+	Services *services.Service
+	// stopDashboard stops refreshing of dashboard tab
+	stopDashboard func()
 }
 
-func NewAppManager(s *services.Service) *AppManager {
+func NewAppManager(s *services.Service, sc *scheduler.Scheduler, shopName string) *AppManager {
 	userLabel := widget.NewEntry()
 
 	return &AppManager{
-		AuthService: s.AuthService,
-		ShopService: s.ShopService,
-		UserLabel:   userLabel,
+		AuthService:     s.AuthService,
+		ShopService:     s.ShopService,
+		AuditService:    s.AuditService,
+		AnalysisService: s.AnalysisService,
+		ForecastService: s.ForecastService,
+		ArchiveService:  s.ArchiveService,
+		ScheduleService: s.ScheduleService,
+		PivotService:    s.PivotService,
+		AnomalyService:  s.AnomalyService,
+		ImportService:   s.ImportService,
+		ExchangeService: s.ExchangeService,
+		BankService:     s.BankService,
+		Scheduler:       sc,
+		Services:        s,
+		UserLabel:       userLabel,
+		ShopName:        shopName,
 	}
 }
 
+// ctx returns context of request made by logged-in user
+func (m *AppManager) ctx() context.Context {
+	return session.WithUser(context.Background(), m.User)
+}
+
 func (m *AppManager) Run() {
 	application := app.New()
 	mainWindow := application.NewWindow("Shop Management System v.0.0.0")
@@ -60,6 +99,13 @@ func (m *AppManager) ShowLoginScreen(window fyne.Window) {
 		password := passwordEntry.Text
 
 		if m.AuthService.AuthoriseUser(context.Background(), username, password) {
+			user, err := m.AuthService.GetUser(context.Background(), username)
+			if err != nil {
+				errorLabel.SetText("Failed to load user: " + err.Error())
+				return
+			}
+			m.User = user
+
 			dialog.ShowInformation("Authorized", successfulLoginMsg, window)
 			m.ShowMainScreen(window, username)
 		} else {
@@ -125,12 +171,20 @@ func (m *AppManager) ShowMainScreen(window fyne.Window, loginEntry string) {
 	loginLabel := widget.NewLabelWithStyle("user: "+loginEntry, fyne.TextAlignTrailing, fyne.TextStyle{Monospace: true})
 
 	tabs := container.NewAppTabs(
+		container.NewTabItem("Dashboard", m.ShowDashboardScreen(window)),
 		container.NewTabItem("Handbooks", m.ShowHandbooksScreen(window)),
 		container.NewTabItem("Journals", m.ShowJournalsScreen(window)),
 		container.NewTabItem("Reports", m.ShowReportsScreen(window)),
+		container.NewTabItem("Review", m.ShowReviewScreen(window)),
 	)
+	if m.User != nil && m.User.IsAdmin {
+		tabs.Append(container.NewTabItem("Trash", m.ShowTrashScreen(window)))
+		tabs.Append(container.NewTabItem("Audit", m.ShowAuditScreen(window)))
+	}
 
 	exitButton := widget.NewButton("Logout", func() {
+		m.stopDashboardRefresh()
+		m.User = nil
 		m.ShowLoginScreen(window)
 	})
 
@@ -148,22 +202,32 @@ func (m *AppManager) ShowHandbooksScreen(window fyne.Window) fyne.CanvasObject {
 		m.ShowExpenseItemsTable(window)
 	})
 
+	importButton := widget.NewButton("Import CSV", func() {
+		m.ShowImportDialog(window, []string{dto.WarehousesTable, dto.ExpenseItemsTable})
+	})
+
+	exchangeButton := widget.NewButton("1C exchange", func() {
+		m.ShowExchangeDialog(window)
+	})
+
 	return container.NewVBox(
 		widget.NewLabelWithStyle("Please, choose Handbook:", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
 		warehousesButton,
 		expenseItemsButton,
+		importButton,
+		exchangeButton,
 	)
 }
 
 // ShowWarehousesTable outputs data from warehouses table
 func (m *AppManager) ShowWarehousesTable(window fyne.Window) {
-	data, err := m.ShopService.ShowWarehousesTable(context.Background())
+	data, err := m.ShopService.ShowWarehousesTable(m.ctx())
 	if err != nil {
 		dialog.ShowError(err, window)
 		return
 	}
 
-	headers := []string{"id", "name", "quantity", "amount"}
+	headers := []string{"id", "name", "quantity", "amount", "cost", "category", "location"}
 
 	table := widget.NewTable(
 		func() (int, int) { return len(data) + 1, len(headers) },
@@ -187,6 +251,12 @@ func (m *AppManager) ShowWarehousesTable(window fyne.Window) {
 				label.SetText(strconv.Itoa(data[row].Quantity))
 			case 3:
 				label.SetText(strconv.Itoa(data[row].Amount))
+			case 4:
+				label.SetText(formatOptionalInt(data[row].Cost))
+			case 5:
+				label.SetText(data[row].Category)
+			case 6:
+				label.SetText(data[row].Location)
 			}
 			label.TextStyle = fyne.TextStyle{Monospace: true}
 		},
@@ -196,11 +266,14 @@ func (m *AppManager) ShowWarehousesTable(window fyne.Window) {
 	table.SetColumnWidth(1, 200) // Name
 	table.SetColumnWidth(2, 100) // Quantity
 	table.SetColumnWidth(3, 100) // Amount
+	table.SetColumnWidth(4, 100) // Cost
+	table.SetColumnWidth(5, 150) // Category
+	table.SetColumnWidth(6, 150) // Location
 
 	tableContainer := container.NewMax(
 		container.NewVBox(
 			widget.NewLabelWithStyle("warehouses", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
-			container.NewGridWrap(fyne.NewSize(600, 400), table),
+			container.NewGridWrap(fyne.NewSize(900, 400), table),
 		),
 	)
 
@@ -216,6 +289,10 @@ func (m *AppManager) ShowWarehousesTable(window fyne.Window) {
 		m.ShowDeleteWarehouseDialog(window)
 	})
 
+	exportButton := widget.NewButton("Export…", func() {
+		m.showExportDialog(window, warehousesExportTable(headers, data), dto.WarehousesTable, "Warehouses")
+	})
+
 	exitButton := widget.NewButton("Back", func() {
 		m.ShowMainScreen(window, m.UserLabel.Text)
 	})
@@ -227,7 +304,7 @@ func (m *AppManager) ShowWarehousesTable(window fyne.Window) {
 
 	buttons := container.NewHBox(
 		widget.NewSeparator(),
-		container.NewGridWithColumns(3, createButton, updateButton, deleteButton),
+		container.NewGridWithColumns(4, createButton, updateButton, deleteButton, exportButton),
 	)
 
 	content := container.NewBorder(
@@ -246,12 +323,19 @@ func (m *AppManager) ShowCreateWarehouseDialog(window fyne.Window) {
 	nameEntry := widget.NewEntry()
 	quantityEntry := widget.NewEntry()
 	amountEntry := widget.NewEntry()
+	costEntry := widget.NewEntry()
+	costEntry.SetPlaceHolder("unknown")
+	categoryEntry := widget.NewEntry()
+	locationEntry := widget.NewEntry()
 
 	dialog.ShowForm("Create Warehouse's record", "Create", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("name", nameEntry),
 			widget.NewFormItem("quantity", quantityEntry),
 			widget.NewFormItem("amount", amountEntry),
+			widget.NewFormItem("cost", costEntry),
+			widget.NewFormItem("category", categoryEntry),
+			widget.NewFormItem("location", locationEntry),
 		}, func(confirmed bool) {
 			if confirmed {
 				quantity, err := strconv.Atoi(quantityEntry.Text)
@@ -262,11 +346,19 @@ func (m *AppManager) ShowCreateWarehouseDialog(window fyne.Window) {
 				if err == nil {
 					fmt.Printf("cannot convert text amount to integer")
 				}
+				cost, err := parseOptionalInt(costEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text cost to integer: %w", err), window)
+					return
+				}
 
-				err = m.ShopService.CreateWarehousesItem(context.Background(), &dto.WarehousesData{
+				err = m.ShopService.CreateWarehousesItem(m.ctx(), &dto.WarehousesData{
 					Name:     nameEntry.Text,
 					Quantity: quantity,
 					Amount:   amount,
+					Cost:     cost,
+					Category: categoryEntry.Text,
+					Location: locationEntry.Text,
 				})
 				if err != nil {
 					dialog.ShowError(err, window)
@@ -280,48 +372,100 @@ func (m *AppManager) ShowCreateWarehouseDialog(window fyne.Window) {
 // ShowUpdateWarehouseDialog shows user's form for warehouse's records update
 func (m *AppManager) ShowUpdateWarehouseDialog(window fyne.Window) {
 	idEntry := widget.NewEntry()
-	nameEntry := widget.NewEntry()
-	quantityEntry := widget.NewEntry()
-	amountEntry := widget.NewEntry()
 
 	dialog.ShowForm("Please, enter Id", "Send", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("id", idEntry),
 		}, func(confirmed bool) {
 			if confirmed {
-				dialog.ShowForm("Update Warehouse's record", "Update", "Cancel",
-					[]*widget.FormItem{
-						widget.NewFormItem("name", nameEntry),
-						widget.NewFormItem("quantity", quantityEntry),
-						widget.NewFormItem("amount", amountEntry),
-					}, func(confirmed bool) {
-						if confirmed {
-							id, err := strconv.Atoi(idEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text id to integer")
-							}
-							quantity, err := strconv.Atoi(quantityEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text quantity to integer")
-							}
-							amount, err := strconv.Atoi(amountEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text amount to integer")
-							}
-
-							err = m.ShopService.UpdateWarehousesItem(context.Background(), &dto.WarehousesData{
-								Id:       id,
-								Name:     nameEntry.Text,
-								Quantity: quantity,
-								Amount:   amount,
-							})
-							if err != nil {
-								dialog.ShowError(err, window)
-							} else {
-								m.ShowWarehousesTable(window)
-							}
-						}
-					}, window)
+				id, err := strconv.Atoi(idEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text id to integer: %w", err), window)
+					return
+				}
+
+				item, err := m.ShopService.GetWarehousesItem(m.ctx(), id)
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				m.showUpdateWarehouseForm(window, item)
+			}
+		}, window)
+}
+
+// showUpdateWarehouseForm shows user's form prefilled with current values of warehouse's record
+func (m *AppManager) showUpdateWarehouseForm(window fyne.Window, item *dto.WarehousesData) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(item.Name)
+	quantityEntry := widget.NewEntry()
+	quantityEntry.SetText(strconv.Itoa(item.Quantity))
+	amountEntry := widget.NewEntry()
+	amountEntry.SetText(strconv.Itoa(item.Amount))
+	costEntry := widget.NewEntry()
+	costEntry.SetPlaceHolder("unknown")
+	costEntry.SetText(formatOptionalInt(item.Cost))
+	categoryEntry := widget.NewEntry()
+	categoryEntry.SetText(item.Category)
+	locationEntry := widget.NewEntry()
+	locationEntry.SetText(item.Location)
+
+	dialog.ShowForm("Update Warehouse's record", "Update", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("name", nameEntry),
+			widget.NewFormItem("quantity", quantityEntry),
+			widget.NewFormItem("amount", amountEntry),
+			widget.NewFormItem("cost", costEntry),
+			widget.NewFormItem("category", categoryEntry),
+			widget.NewFormItem("location", locationEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				quantity, err := strconv.Atoi(quantityEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text quantity to integer: %w", err), window)
+					return
+				}
+				amount, err := strconv.Atoi(amountEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text amount to integer: %w", err), window)
+					return
+				}
+				cost, err := parseOptionalInt(costEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text cost to integer: %w", err), window)
+					return
+				}
+
+				err = m.ShopService.UpdateWarehousesItem(m.ctx(), &dto.WarehousesData{
+					Id:       item.Id,
+					Name:     nameEntry.Text,
+					Quantity: quantity,
+					Amount:   amount,
+					Cost:     cost,
+					Category: categoryEntry.Text,
+					Location: locationEntry.Text,
+					Version:  item.Version,
+				})
+				if errors.Is(err, customErr.ErrVersionConflict) {
+					current, err := m.ShopService.GetWarehousesItem(m.ctx(), item.Id)
+					if err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+
+					m.showVersionConflict(window,
+						fmt.Sprintf("name: %s\nquantity: %d\namount: %d\ncost: %s\ncategory: %s\nlocation: %s",
+							current.Name, current.Quantity, current.Amount, formatOptionalInt(current.Cost),
+							current.Category, current.Location),
+						func() { m.showUpdateWarehouseForm(window, current) })
+					return
+				}
+				if err != nil {
+					dialog.ShowError(err, window)
+				} else {
+					m.ShowWarehousesTable(window)
+				}
 			}
 		}, window)
 }
@@ -340,7 +484,7 @@ func (m *AppManager) ShowDeleteWarehouseDialog(window fyne.Window) {
 					fmt.Printf("cannot convert text id to integer")
 				}
 
-				err = m.ShopService.DeleteWarehousesItem(context.Background(), id)
+				err = m.ShopService.DeleteWarehousesItem(m.ctx(), id)
 				if err != nil {
 					dialog.ShowError(err, window)
 				} else {
@@ -352,7 +496,7 @@ func (m *AppManager) ShowDeleteWarehouseDialog(window fyne.Window) {
 
 // ShowExpenseItemsTable outputs data from expense items table
 func (m *AppManager) ShowExpenseItemsTable(window fyne.Window) {
-	data, err := m.ShopService.ShowExpenseItemsTable(context.Background())
+	data, err := m.ShopService.ShowExpenseItemsTable(m.ctx())
 	if err != nil {
 		dialog.ShowError(err, window)
 		return
@@ -405,6 +549,10 @@ func (m *AppManager) ShowExpenseItemsTable(window fyne.Window) {
 		m.ShowDeleteExpenseItemsDialog(window)
 	})
 
+	exportButton := widget.NewButton("Export…", func() {
+		m.showExportDialog(window, expenseItemsExportTable(headers, data), dto.ExpenseItemsTable, "ExpenseItems")
+	})
+
 	exitButton := widget.NewButton("Back", func() {
 		m.ShowMainScreen(window, m.UserLabel.Text)
 	})
@@ -416,7 +564,7 @@ func (m *AppManager) ShowExpenseItemsTable(window fyne.Window) {
 
 	buttons := container.NewHBox(
 		widget.NewSeparator(),
-		container.NewGridWithColumns(3, createButton, updateButton, deleteButton),
+		container.NewGridWithColumns(4, createButton, updateButton, deleteButton, exportButton),
 	)
 
 	content := container.NewBorder(
@@ -439,7 +587,7 @@ func (m *AppManager) ShowCreateExpenseItemsDialog(window fyne.Window) {
 			widget.NewFormItem("name", nameEntry),
 		}, func(confirmed bool) {
 			if confirmed {
-				err := m.ShopService.CreateExpenseItem(context.Background(), nameEntry.Text)
+				err := m.ShopService.CreateExpenseItem(m.ctx(), nameEntry.Text)
 				if err != nil {
 					dialog.ShowError(err, window)
 				} else {
@@ -452,33 +600,60 @@ func (m *AppManager) ShowCreateExpenseItemsDialog(window fyne.Window) {
 // ShowUpdateExpenseItemsDialog shows user's form for expense item's records update
 func (m *AppManager) ShowUpdateExpenseItemsDialog(window fyne.Window) {
 	idEntry := widget.NewEntry()
-	nameEntry := widget.NewEntry()
+
 	dialog.ShowForm("Please, enter Id", "Send", "Cancel",
 		[]*widget.FormItem{
 			widget.NewFormItem("id", idEntry),
 		}, func(confirmed bool) {
 			if confirmed {
-				dialog.ShowForm("Update Expense Item's record", "Update", "Cancel",
-					[]*widget.FormItem{
-						widget.NewFormItem("name", nameEntry),
-					}, func(confirmed bool) {
-						if confirmed {
-							id, err := strconv.Atoi(idEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text id to integer")
-							}
-
-							err = m.ShopService.UpdateExpenseItem(context.Background(), &dto.ExpenseItemsData{
-								Id:   id,
-								Name: nameEntry.Text,
-							})
-							if err != nil {
-								dialog.ShowError(err, window)
-							} else {
-								m.ShowExpenseItemsTable(window)
-							}
-						}
-					}, window)
+				id, err := strconv.Atoi(idEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text id to integer: %w", err), window)
+					return
+				}
+
+				item, err := m.ShopService.GetExpenseItem(m.ctx(), id)
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				m.showUpdateExpenseItemsForm(window, item)
+			}
+		}, window)
+}
+
+// showUpdateExpenseItemsForm shows user's form prefilled with current values of expense item's record
+func (m *AppManager) showUpdateExpenseItemsForm(window fyne.Window, item *dto.ExpenseItemsData) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(item.Name)
+
+	dialog.ShowForm("Update Expense Item's record", "Update", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("name", nameEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				err := m.ShopService.UpdateExpenseItem(m.ctx(), &dto.ExpenseItemsData{
+					Id:      item.Id,
+					Name:    nameEntry.Text,
+					Version: item.Version,
+				})
+				if errors.Is(err, customErr.ErrVersionConflict) {
+					current, err := m.ShopService.GetExpenseItem(m.ctx(), item.Id)
+					if err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+
+					m.showVersionConflict(window, fmt.Sprintf("name: %s", current.Name),
+						func() { m.showUpdateExpenseItemsForm(window, current) })
+					return
+				}
+				if err != nil {
+					dialog.ShowError(err, window)
+				} else {
+					m.ShowExpenseItemsTable(window)
+				}
 			}
 		}, window)
 }
@@ -497,7 +672,7 @@ func (m *AppManager) ShowDeleteExpenseItemsDialog(window fyne.Window) {
 					fmt.Printf("cannot convert text id to integer")
 				}
 
-				err = m.ShopService.DeleteExpenseItem(context.Background(), id)
+				err = m.ShopService.DeleteExpenseItem(m.ctx(), id)
 				if err != nil {
 					dialog.ShowError(err, window)
 				} else {
@@ -517,16 +692,31 @@ func (m *AppManager) ShowJournalsScreen(window fyne.Window) fyne.CanvasObject {
 		m.ShowSalesTable(window)
 	})
 
+	importButton := widget.NewButton("Import CSV", func() {
+		m.ShowImportDialog(window, []string{dto.ChargesTable, dto.SalesTable})
+	})
+
+	bankButton := widget.NewButton("Import bank statement", func() {
+		m.ShowBankImportDialog(window)
+	})
+
+	bankRulesButton := widget.NewButton("Bank rules", func() {
+		m.ShowBankRulesTable(window)
+	})
+
 	return container.NewVBox(
 		widget.NewLabelWithStyle("Please, choose Journal:", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
 		chargesButton,
 		salesButton,
+		importButton,
+		bankButton,
+		bankRulesButton,
 	)
 }
 
 // ShowChargesTable outputs data from charges table
 func (m *AppManager) ShowChargesTable(window fyne.Window) {
-	data, err := m.ShopService.ShowChargesTable(context.Background())
+	data, err := m.ShopService.ShowChargesTable(m.ctx())
 	if err != nil {
 		dialog.ShowError(err, window)
 		return
@@ -585,6 +775,10 @@ func (m *AppManager) ShowChargesTable(window fyne.Window) {
 		m.ShowDeleteChargesDialog(window)
 	})
 
+	exportButton := widget.NewButton("Export…", func() {
+		m.showExportDialog(window, chargesExportTable(headers, data), dto.ChargesTable, "Charges")
+	})
+
 	exitButton := widget.NewButton("Back", func() {
 		m.ShowMainScreen(window, m.UserLabel.Text)
 	})
@@ -596,7 +790,7 @@ func (m *AppManager) ShowChargesTable(window fyne.Window) {
 
 	buttons := container.NewHBox(
 		widget.NewSeparator(),
-		container.NewGridWithColumns(3, createButton, updateButton, deleteButton),
+		container.NewGridWithColumns(4, createButton, updateButton, deleteButton, exportButton),
 	)
 
 	content := container.NewBorder(
@@ -632,7 +826,7 @@ func (m *AppManager) ShowCreateChargesDialog(window fyne.Window) {
 					fmt.Printf("cannot convert text amount to integer")
 				}
 
-				err = m.ShopService.CreateChargesItem(context.Background(), &dto.ChargesData{
+				err = m.ShopService.CreateChargesItem(m.ctx(), &dto.ChargesData{
 					Amount:        amount,
 					ChargeDate:    chargeDateEntry.Text,
 					ExpenseItemId: exItemId,
@@ -649,48 +843,81 @@ func (m *AppManager) ShowCreateChargesDialog(window fyne.Window) {
 // ShowUpdateChargesDialog shows user's form for charges' records update
 func (m *AppManager) ShowUpdateChargesDialog(window fyne.Window) {
 	idEntry := widget.NewEntry()
+
+	dialog.ShowForm("Please, enter Id", "Send", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("id", idEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				id, err := strconv.Atoi(idEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text id to integer: %w", err), window)
+					return
+				}
+
+				item, err := m.ShopService.GetChargesItem(m.ctx(), id)
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				m.showUpdateChargesForm(window, item)
+			}
+		}, window)
+}
+
+// showUpdateChargesForm shows user's form prefilled with current values of charges' record
+func (m *AppManager) showUpdateChargesForm(window fyne.Window, item *dto.ChargesData) {
 	amountEntry := widget.NewEntry()
+	amountEntry.SetText(strconv.Itoa(item.Amount))
 	chargeDateEntry := widget.NewEntry()
+	chargeDateEntry.SetText(item.ChargeDate)
 	expenseItemIdEntry := widget.NewEntry()
+	expenseItemIdEntry.SetText(strconv.Itoa(item.ExpenseItemId))
 
-	dialog.ShowForm("Please, enter Id", "Send", "Cancel",
+	dialog.ShowForm("Update Charges' record", "Update", "Cancel",
 		[]*widget.FormItem{
-			widget.NewFormItem("id", idEntry),
+			widget.NewFormItem("amount", amountEntry),
+			widget.NewFormItem("charge date", chargeDateEntry),
+			widget.NewFormItem("expense item id", expenseItemIdEntry),
 		}, func(confirmed bool) {
 			if confirmed {
-				dialog.ShowForm("Update Charges' record", "Update", "Cancel",
-					[]*widget.FormItem{
-						widget.NewFormItem("amount", amountEntry),
-						widget.NewFormItem("charge date", chargeDateEntry),
-						widget.NewFormItem("expense item id", expenseItemIdEntry),
-					}, func(confirmed bool) {
-						if confirmed {
-							id, err := strconv.Atoi(idEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text id to integer")
-							}
-							exItemId, err := strconv.Atoi(expenseItemIdEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text expense item id to integer")
-							}
-							amount, err := strconv.Atoi(amountEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text amount to integer")
-							}
-
-							err = m.ShopService.UpdateChargesItem(context.Background(), &dto.ChargesData{
-								Id:            id,
-								Amount:        amount,
-								ChargeDate:    chargeDateEntry.Text,
-								ExpenseItemId: exItemId,
-							})
-							if err != nil {
-								dialog.ShowError(err, window)
-							} else {
-								m.ShowChargesTable(window)
-							}
-						}
-					}, window)
+				exItemId, err := strconv.Atoi(expenseItemIdEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text expense item id to integer: %w", err), window)
+					return
+				}
+				amount, err := strconv.Atoi(amountEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text amount to integer: %w", err), window)
+					return
+				}
+
+				err = m.ShopService.UpdateChargesItem(m.ctx(), &dto.ChargesData{
+					Id:            item.Id,
+					Amount:        amount,
+					ChargeDate:    chargeDateEntry.Text,
+					ExpenseItemId: exItemId,
+					Version:       item.Version,
+				})
+				if errors.Is(err, customErr.ErrVersionConflict) {
+					current, err := m.ShopService.GetChargesItem(m.ctx(), item.Id)
+					if err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+
+					m.showVersionConflict(window,
+						fmt.Sprintf("amount: %d\ncharge date: %s\nexpense item id: %d",
+							current.Amount, current.ChargeDate, current.ExpenseItemId),
+						func() { m.showUpdateChargesForm(window, current) })
+					return
+				}
+				if err != nil {
+					dialog.ShowError(err, window)
+				} else {
+					m.ShowChargesTable(window)
+				}
 			}
 		}, window)
 }
@@ -709,7 +936,7 @@ func (m *AppManager) ShowDeleteChargesDialog(window fyne.Window) {
 					fmt.Printf("cannot convert text id to integer")
 				}
 
-				err = m.ShopService.DeleteChargesItem(context.Background(), id)
+				err = m.ShopService.DeleteChargesItem(m.ctx(), id)
 				if err != nil {
 					dialog.ShowError(err, window)
 				} else {
@@ -721,13 +948,13 @@ func (m *AppManager) ShowDeleteChargesDialog(window fyne.Window) {
 
 // ShowSalesTable outputs data from sales table
 func (m *AppManager) ShowSalesTable(window fyne.Window) {
-	data, err := m.ShopService.ShowSalesTable(context.Background())
+	data, err := m.ShopService.ShowSalesTable(m.ctx())
 	if err != nil {
 		dialog.ShowError(err, window)
 		return
 	}
 
-	headers := []string{"id", "amount", "quantity", "sale_date", "warehouses_id"}
+	headers := []string{"id", "amount", "quantity", "sale_date", "warehouses_id", "customer"}
 
 	table := widget.NewTable(
 		func() (int, int) { return len(data) + 1, len(headers) },
@@ -753,6 +980,8 @@ func (m *AppManager) ShowSalesTable(window fyne.Window) {
 				label.SetText(data[row].SaleDate)
 			case 4:
 				label.SetText(strconv.Itoa(data[row].WarehousesId))
+			case 5:
+				label.SetText(data[row].Customer)
 			}
 			label.TextStyle = fyne.TextStyle{Monospace: true}
 		},
@@ -763,6 +992,7 @@ func (m *AppManager) ShowSalesTable(window fyne.Window) {
 	table.SetColumnWidth(2, 100) // Quantity
 	table.SetColumnWidth(3, 200) // Sale date
 	table.SetColumnWidth(4, 50)  // Warehouses id
+	table.SetColumnWidth(5, 150) // Customer
 
 	tableContainer := container.NewMax(
 		container.NewVBox(
@@ -783,6 +1013,10 @@ func (m *AppManager) ShowSalesTable(window fyne.Window) {
 		m.ShowDeleteSalesDialog(window)
 	})
 
+	exportButton := widget.NewButton("Export…", func() {
+		m.showExportDialog(window, salesExportTable(headers, data), dto.SalesTable, "Sales")
+	})
+
 	exitButton := widget.NewButton("Back", func() {
 		m.ShowMainScreen(window, m.UserLabel.Text)
 	})
@@ -794,7 +1028,7 @@ func (m *AppManager) ShowSalesTable(window fyne.Window) {
 
 	buttons := container.NewHBox(
 		widget.NewSeparator(),
-		container.NewGridWithColumns(3, createButton, updateButton, deleteButton),
+		container.NewGridWithColumns(4, createButton, updateButton, deleteButton, exportButton),
 	)
 
 	content := container.NewBorder(
@@ -813,7 +1047,9 @@ func (m *AppManager) ShowCreateSalesDialog(window fyne.Window) {
 	amountEntry := widget.NewEntry()
 	quantityEntry := widget.NewEntry()
 	saleDateEntry := widget.NewEntry()
+	saleDateEntry.SetPlaceHolder("YYYY-MM-DD HH:MM")
 	warehousesIdEntry := widget.NewEntry()
+	customerEntry := widget.NewEntry()
 
 	dialog.ShowForm("Create Sales' record", "Create", "Cancel",
 		[]*widget.FormItem{
@@ -821,6 +1057,7 @@ func (m *AppManager) ShowCreateSalesDialog(window fyne.Window) {
 			widget.NewFormItem("quantity", quantityEntry),
 			widget.NewFormItem("sale date", saleDateEntry),
 			widget.NewFormItem("warehouses id", warehousesIdEntry),
+			widget.NewFormItem("customer", customerEntry),
 		}, func(confirmed bool) {
 			if confirmed {
 				warehousesId, err := strconv.Atoi(warehousesIdEntry.Text)
@@ -836,11 +1073,12 @@ func (m *AppManager) ShowCreateSalesDialog(window fyne.Window) {
 					fmt.Printf("cannot convert text quantity to integer")
 				}
 
-				err = m.ShopService.CreateSalesItem(context.Background(), &dto.SalesData{
+				err = m.ShopService.CreateSalesItem(m.ctx(), &dto.SalesData{
 					Amount:       amount,
 					Quantity:     quantity,
 					SaleDate:     saleDateEntry.Text,
 					WarehousesId: warehousesId,
+					Customer:     customerEntry.Text,
 				})
 				if err != nil {
 					dialog.ShowError(err, window)
@@ -853,56 +1091,107 @@ func (m *AppManager) ShowCreateSalesDialog(window fyne.Window) {
 
 // ShowUpdateSalesDialog shows user's form for sales' records update
 func (m *AppManager) ShowUpdateSalesDialog(window fyne.Window) {
+	idEntry := widget.NewEntry()
+
+	dialog.ShowForm("Please, enter Id", "Send", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("id", idEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				id, err := strconv.Atoi(idEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text id to integer: %w", err), window)
+					return
+				}
+
+				item, err := m.ShopService.GetSalesItem(m.ctx(), id)
+				if err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				m.showUpdateSalesForm(window, item)
+			}
+		}, window)
+}
+
+// showUpdateSalesForm shows user's form prefilled with current values of sales' record
+func (m *AppManager) showUpdateSalesForm(window fyne.Window, item *dto.SalesData) {
 	amountEntry := widget.NewEntry()
+	amountEntry.SetText(strconv.Itoa(item.Amount))
 	quantityEntry := widget.NewEntry()
+	quantityEntry.SetText(strconv.Itoa(item.Quantity))
 	saleDateEntry := widget.NewEntry()
+	saleDateEntry.SetText(item.SaleDate)
 	warehousesIdEntry := widget.NewEntry()
-	idEntry := widget.NewEntry()
+	warehousesIdEntry.SetText(strconv.Itoa(item.WarehousesId))
+	customerEntry := widget.NewEntry()
+	customerEntry.SetText(item.Customer)
 
-	dialog.ShowForm("Please, enter Id", "Send", "Cancel",
+	dialog.ShowForm("Update Sales' record", "Update", "Cancel",
 		[]*widget.FormItem{
-			widget.NewFormItem("id", idEntry),
+			widget.NewFormItem("amount", amountEntry),
+			widget.NewFormItem("quantity", quantityEntry),
+			widget.NewFormItem("sale date", saleDateEntry),
+			widget.NewFormItem("warehouses id", warehousesIdEntry),
+			widget.NewFormItem("customer", customerEntry),
 		}, func(confirmed bool) {
 			if confirmed {
-				dialog.ShowForm("Update Sales' record", "Update", "Cancel",
-					[]*widget.FormItem{
-						widget.NewFormItem("amount", amountEntry),
-						widget.NewFormItem("quantity", quantityEntry),
-						widget.NewFormItem("sale date", saleDateEntry),
-						widget.NewFormItem("warehouses id", warehousesIdEntry),
-					}, func(confirmed bool) {
-						if confirmed {
-							id, err := strconv.Atoi(idEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text id to integer")
-							}
-							warehousesId, err := strconv.Atoi(warehousesIdEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text warehouses id to integer")
-							}
-							amount, err := strconv.Atoi(amountEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text amount to integer")
-							}
-							quantity, err := strconv.Atoi(quantityEntry.Text)
-							if err == nil {
-								fmt.Printf("cannot convert text quantity to integer")
-							}
-
-							err = m.ShopService.UpdateSalesItem(context.Background(), &dto.SalesData{
-								Id:           id,
-								Amount:       amount,
-								Quantity:     quantity,
-								SaleDate:     saleDateEntry.Text,
-								WarehousesId: warehousesId,
-							})
-							if err != nil {
-								dialog.ShowError(err, window)
-							} else {
-								m.ShowSalesTable(window)
-							}
-						}
-					}, window)
+				warehousesId, err := strconv.Atoi(warehousesIdEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text warehouses id to integer: %w", err), window)
+					return
+				}
+				amount, err := strconv.Atoi(amountEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text amount to integer: %w", err), window)
+					return
+				}
+				quantity, err := strconv.Atoi(quantityEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text quantity to integer: %w", err), window)
+					return
+				}
+
+				err = m.ShopService.UpdateSalesItem(m.ctx(), &dto.SalesData{
+					Id:           item.Id,
+					Amount:       amount,
+					Quantity:     quantity,
+					SaleDate:     saleDateEntry.Text,
+					WarehousesId: warehousesId,
+					Customer:     customerEntry.Text,
+					Version:      item.Version,
+				})
+				if errors.Is(err, customErr.ErrVersionConflict) {
+					current, err := m.ShopService.GetSalesItem(m.ctx(), item.Id)
+					if err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+
+					m.showVersionConflict(window,
+						fmt.Sprintf("amount: %d\nquantity: %d\nsale date: %s\nwarehouses id: %d\ncustomer: %s",
+							current.Amount, current.Quantity, current.SaleDate, current.WarehousesId, current.Customer),
+						func() { m.showUpdateSalesForm(window, current) })
+					return
+				}
+				if err != nil {
+					dialog.ShowError(err, window)
+				} else {
+					m.ShowSalesTable(window)
+				}
+			}
+		}, window)
+}
+
+// showVersionConflict tells user that record was changed by someone else, shows its current values
+// and lets user retry the update starting from them
+func (m *AppManager) showVersionConflict(window fyne.Window, current string, retry func()) {
+	dialog.ShowConfirm("Record was changed by another user",
+		"Current values:\n"+current+"\n\nEdit current values and retry?",
+		func(confirmed bool) {
+			if confirmed {
+				retry()
 			}
 		}, window)
 }
@@ -921,7 +1210,7 @@ func (m *AppManager) ShowDeleteSalesDialog(window fyne.Window) {
 					fmt.Printf("cannot convert text id to integer")
 				}
 
-				err = m.ShopService.DeleteSalesItem(context.Background(), id)
+				err = m.ShopService.DeleteSalesItem(m.ctx(), id)
 				if err != nil {
 					dialog.ShowError(err, window)
 				} else {
@@ -933,139 +1222,28 @@ func (m *AppManager) ShowDeleteSalesDialog(window fyne.Window) {
 
 // ShowReportsScreen shows screen with reports' features to user
 func (m *AppManager) ShowReportsScreen(window fyne.Window) fyne.CanvasObject {
-	profitButton := widget.NewButton("Count month profit", func() {
-		m.ShowMonthProfit(window)
+	archiveButton := widget.NewButton("Report archive", func() {
+		m.ShowReportArchive(window)
 	})
 
-	itemsButton := widget.NewButton("Show 5 best items", func() {
-		m.ShowBestItems(window)
-	})
-
-	return container.NewVBox(
+	screen := container.NewVBox(
 		widget.NewLabelWithStyle("Please, choose Report:", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
-		profitButton,
-		itemsButton,
 	)
-}
-
-// ShowMonthProfit counts summary month profit of shop and outputs it
-func (m *AppManager) ShowMonthProfit(window fyne.Window) {
-	profit, err := m.ShopService.CountMonthProfit(context.Background())
-	if err != nil {
-		dialog.ShowError(fmt.Errorf("failed to count monthly profit: %v", err), window)
-		return
+	for _, report := range reports.All() {
+		screen.Add(widget.NewButton(report.Info().Title, func() {
+			m.showReportForm(window, report)
+		}))
 	}
-
-	message := fmt.Sprintf("Summary month profit: %d", profit)
-
-	dialog.ShowInformation("Profit", message, window)
-}
-
-// ShowBestItems outputs additional table which contains data of five most profitable items in shop
-func (m *AppManager) ShowBestItems(window fyne.Window) {
-	fromEntry := widget.NewEntry()
-	toEntry := widget.NewEntry()
-
-	dialog.ShowForm("Please, enter date range", "Approve", "Cancel",
-		[]*widget.FormItem{
-			widget.NewFormItem("from", fromEntry),
-			widget.NewFormItem("to", toEntry),
-		}, func(confirmed bool) {
-			if confirmed {
-				data, err := m.ShopService.GetFiveBestItems(context.Background(), fromEntry.Text, toEntry.Text)
-				if err != nil {
-					dialog.ShowError(err, window)
-					return
-				}
-
-				headers := []string{"name", "total_revenue"}
-
-				table := widget.NewTable(
-					func() (int, int) { return len(data) + 1, len(headers) },
-					func() fyne.CanvasObject { return widget.NewLabel("") },
-					func(id widget.TableCellID, cell fyne.CanvasObject) {
-						label := cell.(*widget.Label)
-
-						if id.Row == 0 {
-							label.SetText(headers[id.Col])
-							label.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
-							return
-						}
-
-						row := id.Row - 1
-						switch id.Col {
-						case 0:
-							label.SetText(data[row].Name)
-						case 1:
-							label.SetText(strconv.Itoa(data[row].TotalRevenue))
-						}
-						label.TextStyle = fyne.TextStyle{Monospace: true}
-					},
-				)
-
-				table.SetColumnWidth(0, 300)
-				table.SetColumnWidth(1, 100)
-
-				tableContainer := container.NewMax(
-					container.NewVBox(
-						widget.NewLabelWithStyle("five_best_items", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
-						container.NewGridWrap(fyne.NewSize(600, 400), table),
-					),
-				)
-
-				downloadButton := widget.NewButton("Download PDF", func() {
-					m.generateBestItemsPDF(data, fromEntry.Text, toEntry.Text, window)
-				})
-
-				exitButton := widget.NewButton("Back", func() {
-					m.ShowMainScreen(window, m.UserLabel.Text)
-				})
-
-				buttons := container.NewHBox(downloadButton, exitButton)
-
-				content := container.NewBorder(nil, buttons, nil, nil, tableContainer)
-				window.SetContent(content)
-			}
-		}, window)
-}
-
-// generateBestItemsPDF creates .pdf file with FiveBestItems report in root dir
-func (m *AppManager) generateBestItemsPDF(data []*dto.BestItemsData, fromDate, toDate string, window fyne.Window) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.AddPage()
-	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(0, 10, "Report: Five Best Items")
-	pdf.Ln(12)
-
-	pdf.SetFont("Arial", "", 12)
-	pdf.Cell(0, 10, "Date Range: "+fromDate+" to "+toDate)
-	pdf.Ln(12)
-
-	pdf.SetFont("Monospace", "B", 12)
-	pdf.Cell(120, 10, "name")
-	pdf.Cell(0, 10, "total_Revenue")
-	pdf.Ln(10)
-
-	pdf.SetFont("Arial", "", 12)
-	for _, item := range data {
-		pdf.Cell(120, 10, item.Name)
-		pdf.Cell(0, 10, strconv.Itoa(item.TotalRevenue))
-		pdf.Ln(8)
-	}
-
-	reportDir := "reports"
-	err := os.MkdirAll(reportDir, os.ModePerm)
-	if err != nil {
-		dialog.ShowError(fmt.Errorf("failed to create directory: %w", err), window)
-		return
-	}
-
-	outputPath := filepath.Join(reportDir, "BestItemsReport.pdf")
-	err = pdf.OutputFileAndClose(outputPath)
-	if err != nil {
-		dialog.ShowError(err, window)
-		return
+	screen.Add(widget.NewSeparator())
+	screen.Add(widget.NewButton("Pivot builder", func() {
+		m.ShowPivotBuilder(window)
+	}))
+	screen.Add(archiveButton)
+	if m.User != nil && m.User.IsAdmin {
+		screen.Add(widget.NewButton("Report schedules", func() {
+			m.ShowSchedules(window)
+		}))
 	}
 
-	dialog.ShowInformation("Download Complete", "Report saved to: "+outputPath, window)
+	return screen
 }
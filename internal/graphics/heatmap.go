@@ -0,0 +1,53 @@
+package graphics
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"image/color"
+)
+
+const (
+	heatmapLabelWidth = 40
+	heatmapCellSize   = 28
+)
+
+var heatmapColor = color.NRGBA{R: 52, G: 101, B: 164, A: 255}
+
+// newHeatmap draws grid of cells with row labels on the left and column labels on the top
+func newHeatmap(grid *reportDoc.Heatmap) fyne.CanvasObject {
+	maxValue := grid.MaxValue()
+	size := fyne.NewSize(heatmapLabelWidth+heatmapCellSize*float32(len(grid.ColLabels)),
+		heatmapCellSize*float32(len(grid.RowLabels)+1))
+
+	var objects []fyne.CanvasObject
+	for col, label := range grid.ColLabels {
+		objects = append(objects, chartText(label, heatmapLabelWidth+heatmapCellSize*float32(col)+4, 8))
+	}
+
+	for row, label := range grid.RowLabels {
+		y := heatmapCellSize * float32(row+1)
+		objects = append(objects, chartText(label, 0, y+8))
+
+		for col, value := range grid.Values[row] {
+			cell := canvas.NewRectangle(heatmapShade(value, maxValue))
+			cell.StrokeColor = theme.ShadowColor()
+			cell.StrokeWidth = 1
+			cell.Move(fyne.NewPos(heatmapLabelWidth+heatmapCellSize*float32(col), y))
+			cell.Resize(fyne.NewSize(heatmapCellSize, heatmapCellSize))
+			objects = append(objects, cell)
+		}
+	}
+
+	return container.NewGridWrap(size, container.NewWithoutLayout(objects...))
+}
+
+// heatmapShade returns color of cell, the bigger value the darker it is
+func heatmapShade(value, maxValue int64) color.Color {
+	shade := heatmapColor
+	shade.A = uint8(255 * value / maxValue)
+
+	return shade
+}
@@ -0,0 +1,195 @@
+package graphics
+
+import (
+	"automatedShop/internal/export"
+	"automatedShop/internal/importer"
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"sort"
+	"strconv"
+)
+
+// Choices of import dialog for unmapped fields and detected settings.
+const (
+	importNone   = "(none)"
+	importDetect = "detect"
+)
+
+// ShowImportDialog asks user for table to import to and delimiter and encoding of CSV file, then lets
+// user choose the file.
+func (m *AppManager) ShowImportDialog(window fyne.Window, tables []string) {
+	delimiters := []string{importDetect}
+	for name := range export.Delimiters {
+		delimiters = append(delimiters, name)
+	}
+	sort.Strings(delimiters[1:])
+
+	tableSelect := widget.NewSelect(tables, nil)
+	tableSelect.SetSelected(tables[0])
+	delimiterSelect := widget.NewSelect(delimiters, nil)
+	delimiterSelect.SetSelected(importDetect)
+	encodingSelect := widget.NewSelect([]string{export.EncodingUTF8, export.EncodingWindows1251}, nil)
+	encodingSelect.SetSelected(export.EncodingUTF8)
+
+	dialog.ShowForm("Import CSV", "Choose file", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("table", tableSelect),
+			widget.NewFormItem("csv delimiter", delimiterSelect),
+			widget.NewFormItem("csv encoding", encodingSelect),
+		}, func(confirmed bool) {
+			if confirmed {
+				options := export.CSVOptions{
+					Delimiter: export.Delimiters[delimiterSelect.Selected],
+					Encoding:  encodingSelect.Selected,
+				}
+				m.showImportFileDialog(window, tableSelect.Selected, options)
+			}
+		}, window)
+}
+
+// showImportFileDialog lets user choose CSV file and reads it.
+func (m *AppManager) showImportFileDialog(window fyne.Window, table string, options export.CSVOptions) {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer func(reader fyne.URIReadCloser) { _ = reader.Close() }(reader)
+
+		file, err := importer.ReadCSV(reader, options)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		m.showImportMapping(window, table, reader.URI().Name(), file, importer.AutoMapping(file.Headers, dto.ImportFields[table]), "")
+	}, window)
+
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".txt"}))
+	open.Show()
+}
+
+// showImportMapping shows form mapping fields of table to columns of file. Rows are checked by dry run
+// before they are imported.
+func (m *AppManager) showImportMapping(window fyne.Window, table, fileName string, file *importer.File,
+	mapping map[string]string, dateLayout string) {
+	columns := append([]string{importNone}, file.Headers...)
+
+	fields := dto.ImportFields[table]
+	selects := make([]*widget.Select, len(fields))
+	form := widget.NewForm()
+	for i, field := range fields {
+		selects[i] = widget.NewSelect(columns, nil)
+		if header, ok := mapping[field.Key]; ok {
+			selects[i].SetSelected(header)
+		} else {
+			selects[i].SetSelected(importNone)
+		}
+
+		label := field.Key
+		if field.Required {
+			label += " *"
+		}
+		form.Append(label, selects[i])
+	}
+
+	layoutEntry := widget.NewEntry()
+	layoutEntry.SetPlaceHolder("detect, e.g. 02.01.2006 15:04")
+	layoutEntry.SetText(dateLayout)
+	form.Append("date layout", layoutEntry)
+
+	run := func(dryRun bool) {
+		current := make(map[string]string, len(fields))
+		for i, field := range fields {
+			if selects[i].Selected != importNone && selects[i].Selected != "" {
+				current[field.Key] = selects[i].Selected
+			}
+		}
+
+		res, err := m.ImportService.ImportCSV(m.ctx(), &dto.ImportParams{
+			Table:      table,
+			Headers:    file.Headers,
+			Rows:       file.Rows,
+			Mapping:    current,
+			DateLayout: layoutEntry.Text,
+			DryRun:     dryRun,
+		})
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		m.showImportResult(window, table, res, func() {
+			m.showImportMapping(window, table, fileName, file, current, layoutEntry.Text)
+		})
+	}
+
+	dryRunButton := widget.NewButton("Dry run", func() {
+		run(true)
+	})
+
+	importButton := widget.NewButton("Import", func() {
+		dialog.ShowConfirm("Import", fmt.Sprintf("Import %d rows to %s?", len(file.Rows), table), func(confirmed bool) {
+			if confirmed {
+				run(false)
+			}
+		}, window)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	buttons := container.NewHBox(dryRunButton, importButton, exitButton)
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle("import to "+table, fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+		widget.NewLabelWithStyle(fmt.Sprintf("%s: %d rows, %d columns", fileName, len(file.Rows), len(file.Headers)),
+			fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		form,
+	)
+
+	window.SetContent(container.NewBorder(nil, buttons, nil, nil, container.NewVScroll(content)))
+}
+
+// showImportResult outputs result of import or dry run with errors of rows.
+func (m *AppManager) showImportResult(window fyne.Window, table string, res *dto.ImportResultData, back func()) {
+	var summary string
+	switch {
+	case len(res.Errors) > 0:
+		summary = fmt.Sprintf("%d errors in %d rows, nothing imported", len(res.Errors), res.Rows)
+	case res.Imported == 0:
+		summary = fmt.Sprintf("%d rows are valid, dates are in %q", res.Rows, res.DateLayout)
+	default:
+		summary = fmt.Sprintf("%d rows imported to %s", res.Imported, table)
+	}
+
+	headers := []string{"line", "field", "message"}
+	rows := make([][]string, 0, len(res.Errors))
+	for _, rowErr := range res.Errors {
+		rows = append(rows, []string{strconv.Itoa(rowErr.Line), rowErr.Field, rowErr.Message})
+	}
+
+	errorsTable := newStringTable(headers, rows, []float32{60, 150, 500})
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("import result", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			widget.NewLabelWithStyle(summary, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(720, 400), errorsTable),
+		),
+	)
+
+	backButton := widget.NewButton("Back", back)
+
+	content := container.NewBorder(nil, container.NewHBox(backButton), nil, nil, tableContainer)
+	window.SetContent(content)
+}
@@ -0,0 +1,30 @@
+package graphics
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"bytes"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// savePDFReport renders report as .pdf signed by logged-in user, archives it under reportType and lets
+// user choose where to save it
+func (m *AppManager) savePDFReport(report *reportDoc.Document, reportType, fileName string, window fyne.Window) {
+	report.Shop = m.ShopName
+	report.GeneratedAt = time.Now()
+	if m.User != nil {
+		report.Author = m.User.Login
+	}
+
+	var buf bytes.Buffer
+	if err := report.WritePDF(&buf); err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	ext := filepath.Ext(fileName)
+	m.saveGeneratedReport(window, reportType, strings.TrimSuffix(fileName, ext), report.Lines, ext, buf.Bytes())
+}
@@ -0,0 +1,265 @@
+package graphics
+
+import (
+	"automatedShop/internal/reports"
+	"automatedShop/internal/services/dto"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// noDimension is choice of select leaving dimension out
+const noDimension = "(none)"
+
+// pivotRowDimensions is number of row dimensions pivot builder offers
+const pivotRowDimensions = 3
+
+// ShowPivotBuilder outputs builder of pivot reports with empty definition
+func (m *AppManager) ShowPivotBuilder(window fyne.Window) {
+	m.showPivotBuilder(window, &dto.SavedPivotData{Definition: dto.PivotDefinition{
+		Measures: []string{dto.MeasureRevenue},
+		Rows:     []string{dto.DimensionProduct},
+	}})
+}
+
+// showPivotBuilder outputs builder of pivot reports filled with definition. User picks measures,
+// dimensions of rows and columns, filters and period, then builds the report or saves definition.
+func (m *AppManager) showPivotBuilder(window fyne.Window, saved *dto.SavedPivotData) {
+	definition := saved.Definition
+	filters := append([]dto.PivotFilter(nil), definition.Filters...)
+
+	measuresCheck := widget.NewCheckGroup(dto.PivotMeasures, nil)
+	measuresCheck.Horizontal = true
+	measuresCheck.SetSelected(definition.Measures)
+
+	dimensions := append([]string{noDimension}, dto.PivotDimensions...)
+	rowSelects := make([]*widget.Select, pivotRowDimensions)
+	for i := range rowSelects {
+		rowSelects[i] = widget.NewSelect(dimensions, nil)
+		rowSelects[i].SetSelected(noDimension)
+		if i < len(definition.Rows) {
+			rowSelects[i].SetSelected(definition.Rows[i])
+		}
+	}
+	columnSelect := widget.NewSelect(dimensions, nil)
+	columnSelect.SetSelected(noDimension)
+	if definition.Column != "" {
+		columnSelect.SetSelected(definition.Column)
+	}
+
+	filtersLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	filtersLabel.Wrapping = fyne.TextWrapWord
+	showFilters := func() {
+		if len(filters) == 0 {
+			filtersLabel.SetText("no filters")
+			return
+		}
+		filtersLabel.SetText(reports.FormatPivotFilters(filters))
+	}
+	showFilters()
+
+	periodParams := []reports.Param{{Key: reports.ParamPeriod, Label: "period", Kind: reports.KindPeriod}}
+	periodItems, periodValues := reportParamItems(periodParams, false)
+
+	form := widget.NewForm(
+		widget.NewFormItem("measures", measuresCheck),
+		widget.NewFormItem("row 1", rowSelects[0]),
+		widget.NewFormItem("row 2", rowSelects[1]),
+		widget.NewFormItem("row 3", rowSelects[2]),
+		widget.NewFormItem("columns", columnSelect),
+		widget.NewFormItem("filters", filtersLabel),
+	)
+	for _, item := range periodItems {
+		form.AppendItem(item)
+	}
+
+	// read collects definition entered on the screen
+	read := func() *dto.SavedPivotData {
+		res := &dto.SavedPivotData{Id: saved.Id, Name: saved.Name, Definition: dto.PivotDefinition{
+			Measures: append([]string(nil), measuresCheck.Selected...),
+			Filters:  filters,
+		}}
+		for _, rowSelect := range rowSelects {
+			if rowSelect.Selected != noDimension {
+				res.Definition.Rows = append(res.Definition.Rows, rowSelect.Selected)
+			}
+		}
+		if columnSelect.Selected != noDimension {
+			res.Definition.Column = columnSelect.Selected
+		}
+		return res
+	}
+
+	addFilterButton := widget.NewButton("Add filter", func() {
+		dimensionSelect := widget.NewSelect(dto.PivotDimensions, nil)
+		dimensionSelect.SetSelectedIndex(0)
+		operatorSelect := widget.NewSelect(dto.FilterOperators, nil)
+		operatorSelect.SetSelected(dto.FilterEquals)
+		valueEntry := widget.NewEntry()
+
+		dialog.ShowForm("Please, enter filter", "Add", "Cancel",
+			[]*widget.FormItem{
+				widget.NewFormItem("dimension", dimensionSelect),
+				widget.NewFormItem("operator", operatorSelect),
+				widget.NewFormItem("value", valueEntry),
+			}, func(confirmed bool) {
+				if confirmed {
+					filters = append(filters, dto.PivotFilter{
+						Dimension: dimensionSelect.Selected,
+						Operator:  operatorSelect.Selected,
+						Value:     valueEntry.Text,
+					})
+					showFilters()
+				}
+			}, window)
+	})
+
+	clearFiltersButton := widget.NewButton("Clear filters", func() {
+		filters = nil
+		showFilters()
+	})
+
+	buildButton := widget.NewButton("Build", func() {
+		current := read()
+		p, err := reports.NewValues(periodParams, periodValues(), time.Now()).Period(reports.ParamPeriod)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		data, err := m.PivotService.GetPivotReport(m.ctx(), &dto.PivotParams{Period: p, Definition: current.Definition})
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		m.showReportOutput(window, "pivot", reports.PivotFile, reports.PivotOutput(current.Name, p, data), func() {
+			m.showPivotBuilder(window, current)
+		})
+	})
+
+	saveButton := widget.NewButton("Save…", func() {
+		nameEntry := widget.NewEntry()
+		nameEntry.SetText(saved.Name)
+
+		dialog.ShowForm("Save pivot definition", "Save", "Cancel",
+			[]*widget.FormItem{widget.NewFormItem("name", nameEntry)}, func(confirmed bool) {
+				if confirmed {
+					current := read()
+					current.Name = nameEntry.Text
+					if err := m.PivotService.SavePivotDefinition(m.ctx(), current); err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+					m.showPivotBuilder(window, current)
+				}
+			}, window)
+	})
+
+	savedButton := widget.NewButton("Saved definitions", func() {
+		m.showPivotDefinitions(window)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	title := "pivot_builder"
+	if saved.Name != "" {
+		title += ": " + saved.Name
+	}
+
+	builderContainer := container.NewVBox(
+		widget.NewLabelWithStyle(title, fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+		form,
+		container.NewHBox(addFilterButton, clearFiltersButton),
+	)
+
+	buttons := container.NewHBox(buildButton, saveButton, savedButton, exitButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, container.NewVScroll(builderContainer))
+	window.SetContent(content)
+}
+
+// showPivotDefinitions outputs saved pivot definitions, they can be loaded into builder or deleted.
+// Admins see definitions of all users.
+func (m *AppManager) showPivotDefinitions(window fyne.Window) {
+	data, err := m.PivotService.ShowPivotDefinitions(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"id", "name", "measures", "rows", "columns", "filters", "user", "created_at"}
+	rows := make([][]string, 0, len(data))
+	for _, saved := range data {
+		rows = append(rows, []string{
+			strconv.Itoa(saved.Id),
+			saved.Name,
+			strings.Join(saved.Definition.Measures, ", "),
+			strings.Join(saved.Definition.Rows, ", "),
+			saved.Definition.Column,
+			reports.FormatPivotFilters(saved.Definition.Filters),
+			saved.UserLogin,
+			saved.CreatedAt,
+		})
+	}
+
+	table := newStringTable(headers, rows, []float32{50, 180, 200, 200, 100, 250, 100, 170})
+
+	selected := -1
+	table.OnSelected = func(id widget.TableCellID) {
+		selected = id.Row - 1
+	}
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("pivot_definitions", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(1000, 400), table),
+		),
+	)
+
+	withSelected := func(action func(*dto.SavedPivotData)) {
+		if selected < 0 || selected >= len(data) {
+			dialog.ShowInformation("Pivot definitions", "Please, select definition first", window)
+			return
+		}
+
+		action(data[selected])
+	}
+
+	loadButton := widget.NewButton("Load", func() {
+		withSelected(func(saved *dto.SavedPivotData) {
+			m.showPivotBuilder(window, saved)
+		})
+	})
+
+	deleteButton := widget.NewButton("Delete", func() {
+		withSelected(func(saved *dto.SavedPivotData) {
+			dialog.ShowConfirm("Delete definition", "Delete pivot definition '"+saved.Name+"'?",
+				func(confirmed bool) {
+					if !confirmed {
+						return
+					}
+					if err := m.PivotService.DeletePivotDefinition(m.ctx(), saved.Id); err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+					m.showPivotDefinitions(window)
+				}, window)
+		})
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowPivotBuilder(window)
+	})
+
+	buttons := container.NewHBox(loadButton, deleteButton, exitButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, tableContainer)
+	window.SetContent(content)
+}
@@ -0,0 +1,198 @@
+package graphics
+
+import (
+	"automatedShop/internal/period"
+	"automatedShop/internal/reports"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// showReportForm asks user for parameters of registered report and outputs it
+func (m *AppManager) showReportForm(window fyne.Window, report reports.Report) {
+	info := report.Info()
+	items, values := reportParamItems(info.Params, false)
+
+	dialog.ShowForm("Please, enter "+strings.ToLower(info.Title)+" parameters", "Approve", "Cancel",
+		items, func(confirmed bool) {
+			if confirmed {
+				m.showReport(window, report, values())
+			}
+		}, window)
+}
+
+// reportParamItems creates form items of report's parameters and function reading entered values.
+// Periods are chosen among relative ones when relative is set, otherwise they're entered either as
+// calendar month or as date range.
+func reportParamItems(params []reports.Param, relative bool) ([]*widget.FormItem, func() map[string]string) {
+	var items []*widget.FormItem
+	readers := make(map[string]func() string, len(params))
+
+	for _, param := range params {
+		switch {
+		case param.Kind == reports.KindPeriod && relative:
+			relatives := make([]string, 0, len(period.Relatives))
+			for _, relative := range period.Relatives {
+				relatives = append(relatives, string(relative))
+			}
+			periodSelect := widget.NewSelect(relatives, nil)
+			periodSelect.SetSelected(string(period.PreviousMonth))
+
+			items = append(items, widget.NewFormItem(param.Label, periodSelect))
+			readers[param.Key] = func() string { return periodSelect.Selected }
+		case param.Kind == reports.KindPeriod:
+			monthEntry := widget.NewEntry()
+			monthEntry.SetPlaceHolder("YYYY-MM")
+			fromEntry := widget.NewEntry()
+			fromEntry.SetPlaceHolder("YYYY-MM-DD")
+			toEntry := widget.NewEntry()
+			toEntry.SetPlaceHolder("YYYY-MM-DD")
+
+			items = append(items,
+				widget.NewFormItem("month", monthEntry),
+				widget.NewFormItem("or from", fromEntry),
+				widget.NewFormItem("to", toEntry),
+			)
+			readers[param.Key] = func() string {
+				if monthEntry.Text != "" {
+					return monthEntry.Text
+				}
+				return fromEntry.Text + reports.RangeSeparator + toEntry.Text
+			}
+		case param.Kind == reports.KindChoice:
+			choiceSelect := widget.NewSelect(param.Choices, nil)
+			choiceSelect.SetSelected(param.Default)
+
+			items = append(items, widget.NewFormItem(param.Label, choiceSelect))
+			readers[param.Key] = func() string { return choiceSelect.Selected }
+		case param.Kind == reports.KindBool:
+			check := widget.NewCheck("", nil)
+			check.SetChecked(param.Default == "true")
+
+			items = append(items, widget.NewFormItem(param.Label, check))
+			readers[param.Key] = func() string { return strconv.FormatBool(check.Checked) }
+		default:
+			entry := widget.NewEntry()
+			entry.SetText(param.Default)
+
+			items = append(items, widget.NewFormItem(param.Label, entry))
+			readers[param.Key] = func() string { return entry.Text }
+		}
+	}
+
+	return items, func() map[string]string {
+		values := make(map[string]string, len(readers))
+		for key, read := range readers {
+			values[key] = read()
+		}
+		return values
+	}
+}
+
+// showReport generates registered report and outputs it
+func (m *AppManager) showReport(window fyne.Window, report reports.Report, params map[string]string) {
+	info := report.Info()
+	out, err := report.Generate(m.ctx(), m.Services, reports.NewValues(info.Params, params, time.Now()))
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	m.showReportOutput(window, info.Name, info.File, out, func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+}
+
+// showReportOutput outputs lines, chart or heatmap and table of generated report with export buttons.
+// Saved files are archived under name and named with file prefix, back is called by Back button.
+func (m *AppManager) showReportOutput(window fyne.Window, name, file string, out *reports.Output, back func()) {
+	doc := out.Document
+
+	reportContainer := container.NewVBox(
+		widget.NewLabelWithStyle(name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+	)
+	for _, line := range doc.Lines {
+		reportContainer.Add(widget.NewLabelWithStyle(line, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}))
+	}
+	if doc.Chart != nil {
+		reportContainer.Add(newChart(doc.Chart, fyne.NewSize(700, 250)))
+	}
+	if doc.Heatmap != nil {
+		reportContainer.Add(container.NewHScroll(newHeatmap(doc.Heatmap)))
+	}
+
+	var width float32
+	for _, column := range out.Columns {
+		width += column
+	}
+	table := newStringTable(doc.Headers, doc.Rows, out.Columns)
+	reportContainer.Add(container.NewGridWrap(fyne.NewSize(width+20, 400), table))
+
+	pdfButton := widget.NewButton("Download PDF", func() {
+		m.savePDFReport(doc, name, file+".pdf", window)
+	})
+
+	exportButton := widget.NewButton("Export…", func() {
+		m.showExportDialog(window, out.ExportTable(), name, file)
+	})
+
+	exitButton := widget.NewButton("Back", back)
+
+	buttons := container.NewHBox(pdfButton, exportButton, exitButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, container.NewVScroll(reportContainer))
+	window.SetContent(content)
+}
+
+// newStringTable creates table with header row and given rows of text
+func newStringTable(headers []string, rows [][]string, widths []float32) *widget.Table {
+	table := widget.NewTable(
+		func() (int, int) { return len(rows) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			label := cell.(*widget.Label)
+
+			if id.Row == 0 {
+				label.SetText(headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true, Monospace: true}
+				return
+			}
+
+			label.SetText(rows[id.Row-1][id.Col])
+			label.TextStyle = fyne.TextStyle{Monospace: true}
+		},
+	)
+
+	for i, width := range widths {
+		table.SetColumnWidth(i, width)
+	}
+
+	return table
+}
+
+// formatOptionalInt formats value which may be unknown
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*value)
+}
+
+// parseOptionalInt parses value which may be left empty
+func parseOptionalInt(text string) (*int, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
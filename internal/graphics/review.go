@@ -0,0 +1,120 @@
+package graphics
+
+import (
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"strconv"
+	"time"
+)
+
+// ShowReviewScreen shows screen with list of anomalies to review
+func (m *AppManager) ShowReviewScreen(window fyne.Window) fyne.CanvasObject {
+	anomaliesButton := widget.NewButton("Anomalies", func() {
+		m.ShowAnomalies(window, dto.DefaultAnomalyThreshold, false)
+	})
+
+	return container.NewVBox(
+		widget.NewLabelWithStyle("Review unusual charges, sales and days:", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		anomaliesButton,
+	)
+}
+
+// ShowAnomalies outputs anomalies found with given threshold, newest first. Reviewed anomalies are
+// acknowledged and hidden unless acknowledged is set.
+func (m *AppManager) ShowAnomalies(window fyne.Window, threshold float64, acknowledged bool) {
+	data, err := m.AnomalyService.DetectAnomalies(m.ctx(), &dto.AnomalyParams{
+		Threshold:    threshold,
+		Now:          time.Now(),
+		Acknowledged: acknowledged,
+	})
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"day", "kind", "subject", "record", "value", "expected", "score", "description", "acknowledged_by"}
+	rows := make([][]string, 0, len(data))
+	for _, anomaly := range data {
+		record := ""
+		if anomaly.Table != "" {
+			record = anomaly.Table + " #" + strconv.Itoa(anomaly.RecordId)
+		}
+		score := ""
+		if anomaly.Score != 0 {
+			score = strconv.FormatFloat(anomaly.Score, 'f', 1, 64)
+		}
+		rows = append(rows, []string{
+			anomaly.Day,
+			anomaly.Kind,
+			anomaly.Subject,
+			record,
+			strconv.FormatInt(anomaly.Value, 10),
+			strconv.FormatInt(anomaly.Expected, 10),
+			score,
+			anomaly.Description,
+			anomaly.AcknowledgedBy,
+		})
+	}
+
+	table := newStringTable(headers, rows, []float32{110, 150, 150, 120, 90, 90, 60, 330, 130})
+
+	selected := -1
+	table.OnSelected = func(id widget.TableCellID) {
+		selected = id.Row - 1
+	}
+
+	thresholdEntry := widget.NewEntry()
+	thresholdEntry.SetText(strconv.FormatFloat(threshold, 'f', -1, 64))
+	acknowledgedCheck := widget.NewCheck("show acknowledged", nil)
+	acknowledgedCheck.SetChecked(acknowledged)
+
+	refreshButton := widget.NewButton("Refresh", func() {
+		value, err := strconv.ParseFloat(thresholdEntry.Text, 64)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("cannot convert text threshold to number: %w", err), window)
+			return
+		}
+		m.ShowAnomalies(window, value, acknowledgedCheck.Checked)
+	})
+
+	settings := container.NewHBox(
+		widget.NewLabelWithStyle("threshold", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		container.NewGridWrap(fyne.NewSize(80, thresholdEntry.MinSize().Height), thresholdEntry),
+		acknowledgedCheck,
+		refreshButton,
+	)
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("anomalies", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			settings,
+			container.NewGridWrap(fyne.NewSize(1000, 400), table),
+		),
+	)
+
+	acknowledgeButton := widget.NewButton("Acknowledge", func() {
+		if selected < 0 || selected >= len(data) {
+			dialog.ShowInformation("Anomalies", "Please, select anomaly first", window)
+			return
+		}
+
+		if err := m.AnomalyService.AcknowledgeAnomaly(m.ctx(), data[selected]); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		m.ShowAnomalies(window, threshold, acknowledged)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	buttons := container.NewHBox(acknowledgeButton, exitButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, tableContainer)
+	window.SetContent(content)
+}
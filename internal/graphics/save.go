@@ -0,0 +1,58 @@
+package graphics
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"os"
+	"path/filepath"
+)
+
+// reportDir is the directory save dialog opens in
+const reportDir = "reports"
+
+// showSaveDialog lets user choose file to save report's content to. Dialog opens in reports dir.
+func (m *AppManager) showSaveDialog(window fyne.Window, fileName string, content []byte) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+
+		_, err = writer.Write(content)
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save report: %w", err), window)
+			return
+		}
+
+		dialog.ShowInformation("Download Complete", "Report saved to: "+writer.URI().Path(), window)
+	}, window)
+
+	save.SetFileName(fileName)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{filepath.Ext(fileName)}))
+	if location, err := reportLocation(); err == nil {
+		save.SetLocation(location)
+	}
+
+	save.Show()
+}
+
+// reportLocation returns reports dir creating it when needed
+func reportLocation() (fyne.ListableURI, error) {
+	if err := os.MkdirAll(reportDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	path, err := filepath.Abs(reportDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.ListerForURI(storage.NewFileURI(path))
+}
@@ -0,0 +1,256 @@
+package graphics
+
+import (
+	"automatedShop/internal/export"
+	"automatedShop/internal/reports"
+	"automatedShop/internal/services/dto"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ShowSchedules outputs schedules of reports to admin, they can be created, run at once or deleted
+func (m *AppManager) ShowSchedules(window fyne.Window) {
+	data, err := m.ScheduleService.ShowSchedules(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"id", "name", "report", "format", "frequency", "day", "hour", "next_run", "destination", "parameters"}
+	rows := make([][]string, 0, len(data))
+	for _, schedule := range data {
+		rows = append(rows, []string{
+			strconv.Itoa(schedule.Id),
+			schedule.Name,
+			schedule.ReportType,
+			schedule.Format,
+			schedule.Frequency,
+			strconv.Itoa(schedule.Day),
+			strconv.Itoa(schedule.Hour),
+			schedule.NextRunAt.Format("2006-01-02 15:04"),
+			schedule.Destination,
+			formatScheduleParams(schedule.Params),
+		})
+	}
+
+	table := newStringTable(headers, rows, []float32{50, 180, 110, 60, 90, 50, 50, 150, 200, 300})
+
+	selected := -1
+	table.OnSelected = func(id widget.TableCellID) {
+		selected = id.Row - 1
+	}
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("report_schedules", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			widget.NewLabelWithStyle("Reports are generated by the app or by cmd/scheduler", fyne.TextAlignLeading,
+				fyne.TextStyle{Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(1000, 400), table),
+		),
+	)
+
+	withSelected := func(action func(*dto.ScheduleData)) {
+		if selected < 0 || selected >= len(data) {
+			dialog.ShowInformation("Report schedules", "Please, select schedule first", window)
+			return
+		}
+
+		action(data[selected])
+	}
+
+	createButton := widget.NewButton("Create", func() {
+		m.showScheduleForm(window)
+	})
+
+	runButton := widget.NewButton("Run now", func() {
+		withSelected(func(schedule *dto.ScheduleData) {
+			if err := m.Scheduler.Run(m.ctx(), schedule); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			dialog.ShowInformation("Report schedules", "Report '"+schedule.Name+"' generated", window)
+		})
+	})
+
+	runsButton := widget.NewButton("Run log", func() {
+		if selected < 0 || selected >= len(data) {
+			m.showScheduleRuns(window, 0)
+			return
+		}
+		m.showScheduleRuns(window, data[selected].Id)
+	})
+
+	deleteButton := widget.NewButton("Delete", func() {
+		withSelected(func(schedule *dto.ScheduleData) {
+			dialog.ShowConfirm("Delete schedule", "Delete schedule '"+schedule.Name+"' and its run log?",
+				func(confirmed bool) {
+					if !confirmed {
+						return
+					}
+					if err := m.ScheduleService.DeleteSchedule(m.ctx(), schedule.Id); err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+					m.ShowSchedules(window)
+				}, window)
+		})
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	buttons := container.NewHBox(createButton, runButton, runsButton, deleteButton, exitButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, tableContainer)
+	window.SetContent(content)
+}
+
+// showScheduleForm asks admin for new schedule, then for parameters of its report. Periods of
+// scheduled reports are relative to the moment they're generated at.
+func (m *AppManager) showScheduleForm(window fyne.Window) {
+	titles := make([]string, 0, len(reports.All()))
+	byTitle := make(map[string]reports.Report)
+	for _, report := range reports.All() {
+		titles = append(titles, report.Info().Title)
+		byTitle[report.Info().Title] = report
+	}
+
+	nameEntry := widget.NewEntry()
+	reportSelect := widget.NewSelect(titles, nil)
+	reportSelect.SetSelectedIndex(0)
+	formatSelect := widget.NewSelect(append([]string{dto.FormatPDF}, export.Formats...), nil)
+	formatSelect.SetSelected(dto.FormatPDF)
+	destinationEntry := widget.NewEntry()
+	destinationEntry.SetText(reportDir)
+	frequencySelect := widget.NewSelect(dto.Frequencies, nil)
+	frequencySelect.SetSelected(dto.FrequencyMonthly)
+	dayEntry := widget.NewEntry()
+	dayEntry.SetText("1")
+	hourEntry := widget.NewEntry()
+	hourEntry.SetText("6")
+
+	dialog.ShowForm("Please, enter schedule", "Next", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("name", nameEntry),
+			widget.NewFormItem("report", reportSelect),
+			widget.NewFormItem("format", formatSelect),
+			widget.NewFormItem("destination dir", destinationEntry),
+			widget.NewFormItem("frequency", frequencySelect),
+			widget.NewFormItem("weekday (1-7) or day", dayEntry),
+			widget.NewFormItem("hour", hourEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				day, err := strconv.Atoi(dayEntry.Text)
+				if err != nil && frequencySelect.Selected != dto.FrequencyDaily {
+					dialog.ShowError(fmt.Errorf("cannot convert text day to integer: %w", err), window)
+					return
+				}
+				hour, err := strconv.Atoi(hourEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text hour to integer: %w", err), window)
+					return
+				}
+
+				report := byTitle[reportSelect.Selected]
+				m.showScheduleParamsForm(window, &dto.ScheduleData{
+					Name:        nameEntry.Text,
+					ReportType:  report.Info().Name,
+					Format:      formatSelect.Selected,
+					Destination: destinationEntry.Text,
+					Frequency:   frequencySelect.Selected,
+					Day:         day,
+					Hour:        hour,
+				}, report)
+			}
+		}, window)
+}
+
+// showScheduleParamsForm asks admin for parameters of scheduled report and, for CSV files, their
+// delimiter and encoding, then creates schedule
+func (m *AppManager) showScheduleParamsForm(window fyne.Window, schedule *dto.ScheduleData, report reports.Report) {
+	items, values := reportParamItems(report.Info().Params, true)
+
+	delimiters := make([]string, 0, len(export.Delimiters))
+	for name := range export.Delimiters {
+		delimiters = append(delimiters, name)
+	}
+	sort.Strings(delimiters)
+
+	delimiterSelect := widget.NewSelect(delimiters, nil)
+	delimiterSelect.SetSelected("comma")
+	encodingSelect := widget.NewSelect(export.Encodings, nil)
+	encodingSelect.SetSelected(export.EncodingUTF8)
+	if schedule.Format == export.FormatCSV {
+		items = append(items,
+			widget.NewFormItem("csv delimiter", delimiterSelect),
+			widget.NewFormItem("csv encoding", encodingSelect),
+		)
+	}
+
+	dialog.ShowForm("Please, enter "+strings.ToLower(report.Info().Title)+" parameters", "Create", "Cancel",
+		items, func(confirmed bool) {
+			if confirmed {
+				schedule.Params = values()
+				if schedule.Format == export.FormatCSV {
+					schedule.Params[dto.ParamDelimiter] = delimiterSelect.Selected
+					schedule.Params[dto.ParamEncoding] = encodingSelect.Selected
+				}
+
+				if err := m.ScheduleService.CreateSchedule(m.ctx(), schedule); err != nil {
+					dialog.ShowError(err, window)
+					return
+				}
+
+				m.ShowSchedules(window)
+			}
+		}, window)
+}
+
+// showScheduleRuns outputs run log of schedule or of all schedules when id is 0
+func (m *AppManager) showScheduleRuns(window fyne.Window, id int) {
+	data, err := m.ScheduleService.ShowScheduleRuns(m.ctx(), id)
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"schedule", "started_at", "finished_at", "status", "file", "error"}
+	rows := make([][]string, 0, len(data))
+	for _, run := range data {
+		rows = append(rows, []string{run.ScheduleName, run.StartedAt, run.FinishedAt, run.Status, run.FilePath, run.Error})
+	}
+
+	table := newStringTable(headers, rows, []float32{180, 170, 170, 70, 300, 400})
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("schedule_runs", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(1000, 400), table),
+		),
+	)
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowSchedules(window)
+	})
+
+	content := container.NewBorder(nil, exitButton, nil, nil, tableContainer)
+	window.SetContent(content)
+}
+
+// formatScheduleParams formats parameters as key=value pairs sorted by key
+func formatScheduleParams(params map[string]string) string {
+	pairs := make([]string, 0, len(params))
+	for key, value := range params {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "; ")
+}
@@ -0,0 +1,143 @@
+package graphics
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"context"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"strconv"
+)
+
+var editableTables = []string{dto.WarehousesTable, dto.ExpenseItemsTable, dto.ChargesTable, dto.SalesTable}
+
+// ShowTrashScreen shows screen with soft deleted records to admin
+func (m *AppManager) ShowTrashScreen(window fyne.Window) fyne.CanvasObject {
+	trashButton := widget.NewButton("Deleted records", func() {
+		m.ShowTrashTable(window)
+	})
+
+	return container.NewVBox(
+		widget.NewLabelWithStyle("Restore or purge deleted records:", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true}),
+		trashButton,
+	)
+}
+
+// ShowTrashTable outputs soft deleted records of all handbooks and journals
+func (m *AppManager) ShowTrashTable(window fyne.Window) {
+	data, err := m.ShopService.ShowTrash(m.ctx())
+	if err != nil {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	headers := []string{"table", "id", "description", "deleted_at"}
+
+	table := widget.NewTable(
+		func() (int, int) { return len(data) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			label := cell.(*widget.Label)
+
+			if id.Row == 0 {
+				label.SetText(headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Monospace: true}
+				return
+			}
+
+			row := id.Row - 1
+			switch id.Col {
+			case 0:
+				label.SetText(data[row].Table)
+			case 1:
+				label.SetText(strconv.Itoa(data[row].Id))
+			case 2:
+				label.SetText(data[row].Description)
+			case 3:
+				label.SetText(data[row].DeletedAt)
+			}
+			label.TextStyle = fyne.TextStyle{Monospace: true}
+		},
+	)
+
+	table.SetColumnWidth(0, 120) // Table
+	table.SetColumnWidth(1, 50)  // ID
+	table.SetColumnWidth(2, 300) // Description
+	table.SetColumnWidth(3, 180) // Deleted at
+
+	tableContainer := container.NewMax(
+		container.NewVBox(
+			widget.NewLabelWithStyle("trash", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true}),
+			container.NewGridWrap(fyne.NewSize(700, 400), table),
+		),
+	)
+
+	restoreButton := widget.NewButton("Restore", func() {
+		m.ShowTrashActionDialog(window, "Restore", m.ShopService.RestoreTrashItem)
+	})
+
+	purgeButton := widget.NewButton("Purge", func() {
+		m.ShowTrashActionDialog(window, "Purge", m.ShopService.PurgeTrashItem)
+	})
+
+	exitButton := widget.NewButton("Back", func() {
+		m.ShowMainScreen(window, m.UserLabel.Text)
+	})
+
+	topButtons := container.NewHBox(
+		widget.NewSeparator(),
+		container.NewGridWithColumns(1, exitButton),
+	)
+
+	buttons := container.NewHBox(
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2, restoreButton, purgeButton),
+	)
+
+	content := container.NewBorder(
+		topButtons,
+		buttons,
+		nil,
+		nil,
+		tableContainer,
+	)
+
+	window.SetContent(content)
+}
+
+// ShowTrashActionDialog shows user's form for restoring or purging deleted record
+func (m *AppManager) ShowTrashActionDialog(window fyne.Window, action string,
+	apply func(context.Context, string, int) error) {
+	tableSelect := widget.NewSelect(editableTables, nil)
+	idEntry := widget.NewEntry()
+
+	dialog.ShowForm(action+" deleted record", action, "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("table", tableSelect),
+			widget.NewFormItem("id", idEntry),
+		}, func(confirmed bool) {
+			if confirmed {
+				id, err := strconv.Atoi(idEntry.Text)
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("cannot convert text id to integer: %w", err), window)
+					return
+				}
+
+				err = apply(m.ctx(), tableSelect.Selected, id)
+				if errors.Is(err, customErr.ErrRecordInUse) {
+					dialog.ShowError(errors.New("record is still referenced by other records "+
+						"(e.g. product with sales) and can't be purged"), window)
+					return
+				}
+				if err != nil {
+					dialog.ShowError(err, window)
+				} else {
+					m.ShowTrashTable(window)
+				}
+			}
+		}, window)
+}
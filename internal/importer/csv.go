@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"automatedShop/internal/export"
+	"automatedShop/internal/services/dto"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"golang.org/x/text/encoding/charmap"
+	"io"
+	"strings"
+)
+
+// ErrEmptyFile is returned for files without header row.
+var ErrEmptyFile = errors.New("csv file is empty")
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// File is CSV file read as text. Rows may be shorter than Headers.
+type File struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ReadCSV reads CSV file with header row. Files are read in options.Encoding, UTF-8 byte order mark
+// is skipped in any case. Delimiter is detected from header row when options.Delimiter is zero.
+func ReadCSV(r io.Reader, options export.CSVOptions) (*File, error) {
+	switch options.Encoding {
+	case "", export.EncodingUTF8, export.EncodingUTF8BOM:
+	case export.EncodingWindows1251:
+		r = charmap.Windows1251.NewDecoder().Reader(r)
+	default:
+		return nil, fmt.Errorf("unknown csv encoding %q", options.Encoding)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	delimiter := options.Delimiter
+	if delimiter == 0 {
+		delimiter = detectDelimiter(string(data))
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	headers := make([]string, len(records[0]))
+	for i, header := range records[0] {
+		headers[i] = strings.TrimSpace(header)
+	}
+
+	return &File{Headers: headers, Rows: records[1:]}, nil
+}
+
+// detectDelimiter returns the delimiter met most often in the first line of text, comma wins ties.
+func detectDelimiter(text string) rune {
+	line, _, _ := strings.Cut(text, "\n")
+
+	delimiter, count := ',', strings.Count(line, ",")
+	for _, candidate := range []rune{';', '\t'} {
+		if n := strings.Count(line, string(candidate)); n > count {
+			delimiter, count = candidate, n
+		}
+	}
+
+	return delimiter
+}
+
+// AutoMapping maps fields to headers equal to their keys, ignoring case.
+func AutoMapping(headers []string, fields []dto.ImportField) map[string]string {
+	mapping := make(map[string]string, len(fields))
+	for _, field := range fields {
+		for _, header := range headers {
+			if strings.EqualFold(header, field.Key) {
+				mapping[field.Key] = header
+				break
+			}
+		}
+	}
+
+	return mapping
+}
@@ -0,0 +1,191 @@
+package period
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DateLayout is the format of dates entered by users and shown in reports.
+	DateLayout = "2006-01-02"
+	// MonthLayout is the format of calendar months entered by users.
+	MonthLayout = "2006-01"
+)
+
+var ErrInvalidPeriod = errors.New("period end is before its start")
+
+// SetTimeZone makes location local time zone of the process. Sale and charge dates are stored
+// without time zone, so periods and all dates are treated as local time of the shop. It's called
+// once by main before anything else runs.
+func SetTimeZone(location *time.Location) {
+	time.Local = location
+}
+
+// Period is a range of whole days. From is the first day of period and To is the day
+// after the last one, so queries can use `date >= From AND date < To`.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Parse returns period between from and to dates inclusive.
+func Parse(from, to string) (Period, error) {
+	f, err := time.ParseInLocation(DateLayout, from, time.Local)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD: %w", from, err)
+	}
+	t, err := time.ParseInLocation(DateLayout, to, time.Local)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD: %w", to, err)
+	}
+	if t.Before(f) {
+		return Period{}, ErrInvalidPeriod
+	}
+
+	return Period{From: f, To: t.AddDate(0, 0, 1)}, nil
+}
+
+// Month returns period of calendar month given as YYYY-MM.
+func Month(month string) (Period, error) {
+	m, err := time.ParseInLocation(MonthLayout, month, time.Local)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid month '%s', expected YYYY-MM: %w", month, err)
+	}
+
+	return Period{From: m, To: m.AddDate(0, 1, 0)}, nil
+}
+
+// CurrentMonth returns period from the first day of month containing now till the end of that month.
+func CurrentMonth(now time.Time) Period {
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return Period{From: from, To: from.AddDate(0, 1, 0)}
+}
+
+// LastDays returns period of n days ending with the day containing now.
+func LastDays(now time.Time, n int) Period {
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	return Period{From: to.AddDate(0, 0, -n), To: to}
+}
+
+// Relative is a period named relatively to the current moment, e.g. for reports generated on schedule.
+type Relative string
+
+const (
+	Yesterday     Relative = "yesterday"
+	Last7Days     Relative = "last_7_days"
+	Last30Days    Relative = "last_30_days"
+	PreviousWeek  Relative = "previous_week"
+	ThisMonth     Relative = "current_month"
+	PreviousMonth Relative = "previous_month"
+)
+
+// Relatives lists supported relative periods.
+var Relatives = []Relative{Yesterday, Last7Days, Last30Days, PreviousWeek, ThisMonth, PreviousMonth}
+
+var ErrUnknownRelative = errors.New("unknown relative period")
+
+// Period returns period r denotes at moment now.
+func (r Relative) Period(now time.Time) (Period, error) {
+	today := LastDays(now, 1)
+
+	switch r {
+	case Yesterday:
+		return today.Previous(), nil
+	case Last7Days:
+		return LastDays(now, 7), nil
+	case Last30Days:
+		return LastDays(now, 30), nil
+	case PreviousWeek:
+		week := BucketStart(now, WeekBucket)
+		return Period{From: week.AddDate(0, 0, -7), To: week}, nil
+	case ThisMonth:
+		return CurrentMonth(now), nil
+	case PreviousMonth:
+		return CurrentMonth(now).Previous(), nil
+	default:
+		return Period{}, fmt.Errorf("%w: '%s'", ErrUnknownRelative, r)
+	}
+}
+
+// FirstDay returns the first day of period as YYYY-MM-DD.
+func (p Period) FirstDay() string {
+	return p.From.Format(DateLayout)
+}
+
+// LastDay returns the last day of period as YYYY-MM-DD.
+func (p Period) LastDay() string {
+	return p.To.AddDate(0, 0, -1).Format(DateLayout)
+}
+
+// Days returns number of days in period.
+func (p Period) Days() int {
+	return int(p.To.Sub(p.From).Round(24*time.Hour) / (24 * time.Hour))
+}
+
+// Previous returns period of the same length right before p. Previous period of calendar month
+// is the previous calendar month.
+func (p Period) Previous() Period {
+	if p.From.Day() == 1 && p.To.Equal(p.From.AddDate(0, 1, 0)) {
+		return Period{From: p.From.AddDate(0, -1, 0), To: p.From}
+	}
+
+	return Period{From: p.From.AddDate(0, 0, -p.Days()), To: p.From}
+}
+
+// YearAgo returns the same period of the previous year.
+func (p Period) YearAgo() Period {
+	return Period{From: p.From.AddDate(-1, 0, 0), To: p.To.AddDate(-1, 0, 0)}
+}
+
+func (p Period) String() string {
+	return p.FirstDay() + " - " + p.LastDay()
+}
+
+// Bucket is the length of time series' step.
+type Bucket string
+
+const (
+	DayBucket   Bucket = "day"
+	WeekBucket  Bucket = "week"
+	MonthBucket Bucket = "month"
+)
+
+// Buckets lists supported buckets.
+var Buckets = []Bucket{DayBucket, WeekBucket, MonthBucket}
+
+// BucketStart returns the first day of bucket containing t. Weeks start on Monday.
+func BucketStart(t time.Time, bucket Bucket) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch bucket {
+	case WeekBucket:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case MonthBucket:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// NextBucket returns the first day of bucket following the one which starts at start.
+func NextBucket(start time.Time, bucket Bucket) time.Time {
+	switch bucket {
+	case WeekBucket:
+		return start.AddDate(0, 0, 7)
+	case MonthBucket:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// BucketStarts returns starts of all buckets intersecting period in chronological order.
+func (p Period) BucketStarts(bucket Bucket) []time.Time {
+	var starts []time.Time
+	for start := BucketStart(p.From, bucket); start.Before(p.To); start = NextBucket(start, bucket) {
+		starts = append(starts, start)
+	}
+
+	return starts
+}
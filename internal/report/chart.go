@@ -0,0 +1,80 @@
+package report
+
+import (
+	"github.com/jung-kurt/gofpdf"
+	"strconv"
+)
+
+const chartMaxLabels = 10
+
+// Chart is a series of values drawn as line or bar chart
+type Chart struct {
+	Title  string
+	Labels []string
+	Values []float64
+	Bars   bool
+}
+
+// MaxValue returns the upper bound of chart's value axis
+func (c *Chart) MaxValue() float64 {
+	maxValue := 1.0
+	for _, value := range c.Values {
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+
+	return maxValue
+}
+
+// LabelStep returns how many points are skipped between labels of category axis
+func (c *Chart) LabelStep() int {
+	return (len(c.Labels)-1)/chartMaxLabels + 1
+}
+
+// draw draws chart in rectangle with top left corner (x, y) of given width and height in mm
+func (c *Chart) draw(pdf *gofpdf.Fpdf, x, y, width, height float64) {
+	const leftMargin, bottomMargin = 20.0, 8.0
+
+	plotX, plotWidth := x+leftMargin, width-leftMargin
+	plotHeight := height - bottomMargin
+	baseline := y + plotHeight
+	maxValue := c.MaxValue()
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.3)
+	pdf.Line(plotX, y, plotX, baseline)
+	pdf.Line(plotX, baseline, plotX+plotWidth, baseline)
+
+	pdf.SetFont(fontFamily, "", 7)
+	pdf.Text(x, y+3, strconv.FormatFloat(maxValue, 'f', 0, 64))
+	pdf.Text(x, baseline, "0")
+
+	if len(c.Values) == 0 {
+		return
+	}
+
+	step := plotWidth / float64(len(c.Values))
+	valueY := func(value float64) float64 {
+		return baseline - value/maxValue*plotHeight
+	}
+
+	pdf.SetFillColor(seriesColor.R, seriesColor.G, seriesColor.B)
+	pdf.SetDrawColor(seriesColor.R, seriesColor.G, seriesColor.B)
+	pdf.SetLineWidth(0.6)
+	for i, value := range c.Values {
+		pointX := plotX + step*float64(i)
+		if c.Bars {
+			pdf.Rect(pointX+step*0.1, valueY(value), step*0.8, baseline-valueY(value), "F")
+		} else if i > 0 {
+			pdf.Line(pointX-step/2, valueY(c.Values[i-1]), pointX+step/2, valueY(value))
+		}
+
+		if i%c.LabelStep() == 0 && i < len(c.Labels) {
+			pdf.Text(pointX, baseline+5, c.Labels[i])
+		}
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.2)
+}
@@ -0,0 +1,14 @@
+package report
+
+import _ "embed"
+
+// Noto Sans covers Latin and Cyrillic, so names of products are rendered as entered.
+// Fonts are distributed under SIL Open Font License, see fonts/LICENSE.txt.
+const fontFamily = "NotoSans"
+
+var (
+	//go:embed fonts/NotoSans-Regular.ttf
+	regularFont []byte
+	//go:embed fonts/NotoSans-Bold.ttf
+	boldFont []byte
+)
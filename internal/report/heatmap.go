@@ -0,0 +1,82 @@
+package report
+
+import (
+	"github.com/jung-kurt/gofpdf"
+	"strconv"
+)
+
+const heatmapLabelWidth = 12.0
+
+// Heatmap is a matrix of values drawn as cells shaded by value
+type Heatmap struct {
+	Title     string
+	RowLabels []string
+	ColLabels []string
+	Values    [][]int64
+}
+
+// MaxValue returns the value of the darkest cell
+func (h *Heatmap) MaxValue() int64 {
+	var maxValue int64 = 1
+	for _, row := range h.Values {
+		for _, value := range row {
+			if value > maxValue {
+				maxValue = value
+			}
+		}
+	}
+
+	return maxValue
+}
+
+// cellSize returns side of square cell in mm when heatmap is drawn of given width
+func (h *Heatmap) cellSize(width float64) float64 {
+	return (width - heatmapLabelWidth) / float64(max(len(h.ColLabels), 1))
+}
+
+// height returns height of heatmap in mm when it's drawn of given width
+func (h *Heatmap) height(width float64) float64 {
+	return h.cellSize(width) * float64(len(h.RowLabels)+1)
+}
+
+// draw draws heatmap in rectangle with top left corner (x, y) of given width in mm
+func (h *Heatmap) draw(pdf *gofpdf.Fpdf, x, y, width float64) {
+	cell := h.cellSize(width)
+	maxValue := h.MaxValue()
+
+	pdf.SetFont(fontFamily, "", 6)
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetLineWidth(0.1)
+	for col, label := range h.ColLabels {
+		pdf.Text(x+heatmapLabelWidth+cell*float64(col)+1, y+4, label)
+	}
+
+	for row, label := range h.RowLabels {
+		rowY := y + cell*float64(row+1)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.Text(x, rowY+cell/2+1, label)
+
+		for col, value := range h.Values[row] {
+			// Shade is mixed with white background, text turns white on dark cells
+			share := float64(value) / float64(maxValue)
+			pdf.SetFillColor(255-int(share*float64(255-seriesColor.R)), 255-int(share*float64(255-seriesColor.G)),
+				255-int(share*float64(255-seriesColor.B)))
+			cellX := x + heatmapLabelWidth + cell*float64(col)
+			pdf.Rect(cellX, rowY, cell, cell, "FD")
+
+			text := strconv.FormatInt(value, 10)
+			if value > 0 && pdf.GetStringWidth(text) < cell-1 {
+				if share > 0.5 {
+					pdf.SetTextColor(255, 255, 255)
+				} else {
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Text(cellX+0.5, rowY+cell/2+1, text)
+			}
+		}
+	}
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.2)
+}
@@ -0,0 +1,197 @@
+package report
+
+import (
+	"fmt"
+	"github.com/jung-kurt/gofpdf"
+	"io"
+	"time"
+)
+
+// Layout of A4 page in mm.
+const (
+	pageMargin   = 15.0
+	footerHeight = 10.0
+	rowHeight    = 7.0
+	chartHeight  = 70.0
+	cellPadding  = 1.0
+)
+
+// seriesColor is the color of chart series and the darkest heatmap cell
+var seriesColor = struct{ R, G, B int }{R: 52, G: 101, B: 164}
+
+// Document describes report rendered as .pdf: title, lines of parameters, optional chart or
+// heatmap and table. Author, Shop and GeneratedAt are printed in the footer of every page.
+type Document struct {
+	Title       string
+	Author      string
+	Shop        string
+	GeneratedAt time.Time
+	Lines       []string
+	// Chart is drawn between lines and table when set
+	Chart *Chart
+	// Heatmap is drawn between lines and table when set
+	Heatmap *Heatmap
+	Headers []string
+	// Widths of table's columns in mm, zero width stretches column to the right margin
+	Widths []float64
+	Rows   [][]string
+}
+
+// WritePDF renders document as .pdf to w. Table continues on as many pages as needed, its
+// header is repeated on each of them.
+func (d *Document) WritePDF(w io.Writer) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() { d.footer(pdf) })
+
+	pdf.AddPage()
+	pdf.SetFont(fontFamily, "B", 16)
+	pdf.Cell(0, 10, d.Title)
+	pdf.Ln(12)
+
+	pdf.SetFont(fontFamily, "", 11)
+	for _, line := range d.Lines {
+		pdf.Cell(0, 8, line)
+		pdf.Ln(7)
+	}
+	pdf.Ln(4)
+
+	width := contentWidth(pdf)
+	if d.Chart != nil {
+		ensureSpace(pdf, chartHeight)
+		d.Chart.draw(pdf, pdf.GetX(), pdf.GetY(), width, chartHeight)
+		pdf.Ln(chartHeight + 8)
+	}
+	if d.Heatmap != nil {
+		height := d.Heatmap.height(width)
+		ensureSpace(pdf, height)
+		d.Heatmap.draw(pdf, pdf.GetX(), pdf.GetY(), width)
+		pdf.Ln(height + 8)
+	}
+
+	if len(d.Headers) > 0 {
+		d.table(pdf, d.columnWidths(width))
+	}
+
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render pdf: %w", err)
+	}
+
+	return pdf.Output(w)
+}
+
+// table draws table row by row starting new page with header whenever the page is filled
+func (d *Document) table(pdf *gofpdf.Fpdf, widths []float64) {
+	ensureSpace(pdf, 2*rowHeight)
+	d.header(pdf, widths)
+
+	pdf.SetFont(fontFamily, "", 10)
+	for _, row := range d.Rows {
+		if !hasSpace(pdf, rowHeight) {
+			pdf.AddPage()
+			d.header(pdf, widths)
+			pdf.SetFont(fontFamily, "", 10)
+		}
+
+		for i, value := range row {
+			if i < len(widths) {
+				pdf.CellFormat(widths[i], rowHeight, fitText(pdf, value, widths[i]), "B", 0, "L", false, 0, "")
+			}
+		}
+		pdf.Ln(rowHeight)
+	}
+}
+
+func (d *Document) header(pdf *gofpdf.Fpdf, widths []float64) {
+	pdf.SetFont(fontFamily, "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, header := range d.Headers {
+		pdf.CellFormat(widths[i], rowHeight, fitText(pdf, header, widths[i]), "B", 0, "L", true, 0, "")
+	}
+	pdf.Ln(rowHeight)
+}
+
+func (d *Document) footer(pdf *gofpdf.Fpdf) {
+	pdf.SetY(-pageMargin)
+	pdf.SetFont(fontFamily, "", 8)
+	pdf.SetTextColor(100, 100, 100)
+
+	info := "Generated " + d.GeneratedAt.Format("2006-01-02 15:04")
+	if d.Author != "" {
+		info += " by " + d.Author
+	}
+	if d.Shop != "" {
+		info = d.Shop + ". " + info
+	}
+
+	pdf.CellFormat(0, footerHeight/2, info, "T", 0, "L", false, 0, "")
+	pdf.SetX(pageMargin)
+	pdf.CellFormat(0, footerHeight/2, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "T", 0, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// columnWidths returns widths of table's columns. Columns without width share the rest of the page.
+func (d *Document) columnWidths(width float64) []float64 {
+	widths := make([]float64, len(d.Headers))
+	var fixed float64
+	var stretched int
+	for i := range widths {
+		if i < len(d.Widths) && d.Widths[i] > 0 {
+			widths[i] = d.Widths[i]
+			fixed += widths[i]
+		} else {
+			stretched++
+		}
+	}
+
+	if stretched > 0 {
+		rest := max((width-fixed)/float64(stretched), 10)
+		for i := range widths {
+			if widths[i] == 0 {
+				widths[i] = rest
+			}
+		}
+	}
+
+	return widths
+}
+
+func contentWidth(pdf *gofpdf.Fpdf) float64 {
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+
+	return pageWidth - left - right
+}
+
+// hasSpace reports whether block of given height fits on the current page above footer
+func hasSpace(pdf *gofpdf.Fpdf, height float64) bool {
+	_, pageHeight := pdf.GetPageSize()
+
+	return pdf.GetY()+height <= pageHeight-pageMargin-footerHeight
+}
+
+// ensureSpace starts new page unless block of given height fits on the current one
+func ensureSpace(pdf *gofpdf.Fpdf, height float64) {
+	if !hasSpace(pdf, height) {
+		pdf.AddPage()
+	}
+}
+
+// fitText cuts text which is wider than cell of given width
+func fitText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	available := width - 2*cellPadding
+	if pdf.GetStringWidth(text) <= available {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > available {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "…"
+}
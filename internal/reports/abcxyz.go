@@ -0,0 +1,69 @@
+package reports
+
+import (
+	"automatedShop/internal/period"
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// abcXyzReport classifies items by share of revenue and by variation of demand. Counts of items
+// in each class pair are listed in lines.
+type abcXyzReport struct{}
+
+func (abcXyzReport) Info() Info {
+	return Info{Name: "abc_xyz", Title: "ABC/XYZ classification", File: "AbcXyzReport", Params: []Param{
+		{Key: "bucket", Label: "demand by", Kind: KindChoice, Default: string(period.WeekBucket),
+			Choices: []string{string(period.WeekBucket), string(period.MonthBucket)}},
+		periodParam,
+	}}
+}
+
+func (abcXyzReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := period.Bucket(values.String("bucket"))
+	report, err := s.AnalysisService.GetAbcXyzReport(ctx, &dto.AbcXyzParams{Period: p, Bucket: bucket})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{"Period: " + p.String(), "Demand by: " + string(bucket)}
+	for i, abc := range dto.AbcClasses {
+		line := abc + ":"
+		for j, xyz := range dto.XyzClasses {
+			line += fmt.Sprintf(" %s %d", xyz, report.Matrix[i][j])
+		}
+		lines = append(lines, line)
+	}
+
+	rows := make([][]string, 0, len(report.Items))
+	for _, item := range report.Items {
+		rows = append(rows, []string{
+			item.Name,
+			strconv.FormatInt(item.Revenue, 10),
+			fmt.Sprintf("%.1f", item.Share*100),
+			fmt.Sprintf("%.1f", item.CumulativeShare*100),
+			strconv.FormatInt(item.Units, 10),
+			formatRatio(item.Variation),
+			item.AbcClass + item.XyzClass,
+		})
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title:   "Report: ABC/XYZ Classification",
+			Lines:   lines,
+			Headers: []string{"name", "revenue", "share_%", "cumulative_%", "units", "variation", "class"},
+			Widths:  []float64{50, 25, 20, 28, 20, 22, 0},
+			Rows:    rows,
+		},
+		Columns: []float32{200, 100, 80, 110, 80, 90, 60},
+	}, nil
+}
@@ -0,0 +1,101 @@
+package reports
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// comparisonReport compares period with the previous one and with the same period a year ago
+type comparisonReport struct{}
+
+func (comparisonReport) Info() Info {
+	return Info{Name: "comparison", Title: "Period comparison", File: "ComparisonReport", Params: []Param{
+		periodParam,
+		{Key: "mover_percent", Label: "big mover, %", Kind: KindFloat, Default: "20"},
+	}}
+}
+
+func (comparisonReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+	moverPercent, err := values.Float("mover_percent")
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.ShopService.GetComparisonReport(ctx, &dto.ComparisonParams{
+		Period:       p,
+		MoverPercent: moverPercent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		var movers []string
+		if mover := moverDirection(row.Previous); mover != "" {
+			movers = append(movers, mover+" vs previous")
+		}
+		if mover := moverDirection(row.YearAgo); mover != "" {
+			movers = append(movers, mover+" vs year ago")
+		}
+
+		rows = append(rows, []string{
+			row.Section,
+			row.Name,
+			strconv.FormatInt(row.Current, 10),
+			strconv.FormatInt(row.Previous.Base, 10),
+			fmt.Sprintf("%+d", row.Previous.Delta),
+			formatPercent(row.Previous.Percent),
+			strconv.FormatInt(row.YearAgo.Base, 10),
+			fmt.Sprintf("%+d", row.YearAgo.Delta),
+			formatPercent(row.YearAgo.Percent),
+			strings.Join(movers, ", "),
+		})
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title: "Report: Period Comparison",
+			Lines: []string{
+				"Period: " + report.Current,
+				"Previous period: " + report.Previous,
+				"Year ago: " + report.YearAgo,
+				fmt.Sprintf("Big movers changed by %.0f%% or more", moverPercent),
+			},
+			Headers: []string{"section", "name", "current", "previous", "delta_previous", "delta_previous_%",
+				"year_ago", "delta_year_ago", "delta_year_ago_%", "big_mover"},
+			Widths: []float64{16, 32, 18, 18, 17, 14, 18, 17, 14, 0},
+			Rows:   rows,
+		},
+		Columns: []float32{90, 200, 100, 100, 100, 80, 100, 100, 80, 180},
+	}, nil
+}
+
+// moverDirection returns up or down for big movers and empty string for other lines.
+func moverDirection(delta dto.ComparisonDeltaData) string {
+	switch {
+	case !delta.BigMover:
+		return ""
+	case delta.Delta > 0:
+		return "up"
+	default:
+		return "down"
+	}
+}
+
+func formatPercent(percent *float64) string {
+	if percent == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%+.1f", *percent)
+}
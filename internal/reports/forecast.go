@@ -0,0 +1,78 @@
+package reports
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// forecastReport is weekly demand forecast with replenishment suggestions. Its parameters are in weeks.
+type forecastReport struct{}
+
+func (forecastReport) Info() Info {
+	return Info{Name: "demand_forecast", Title: "Demand forecast", File: "DemandForecastReport", Params: []Param{
+		{Key: "method", Label: "method", Kind: KindChoice, Choices: dto.ForecastMethods,
+			Default: dto.ForecastExponentialSmoothing},
+		{Key: "horizon", Label: "horizon, weeks", Kind: KindInt, Default: "8"},
+		{Key: "history", Label: "history, weeks", Kind: KindInt, Default: "104"},
+		{Key: "lead_time", Label: "lead time, weeks", Kind: KindInt, Default: "1"},
+		{Key: "safety_weeks", Label: "safety stock, weeks", Kind: KindInt, Default: "1"},
+	}}
+}
+
+func (forecastReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	params := &dto.ForecastParams{Method: values.String("method")}
+	for key, value := range map[string]*int{
+		"horizon":      &params.Horizon,
+		"history":      &params.History,
+		"lead_time":    &params.LeadTime,
+		"safety_weeks": &params.SafetyWeeks,
+	} {
+		n, err := values.Int(key)
+		if err != nil {
+			return nil, err
+		}
+		*value = n
+	}
+
+	report, err := s.ForecastService.GetDemandForecast(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(report.Items))
+	chart := &reportDoc.Chart{Title: "forecast units by week", Bars: true}
+	weekly := make([]float64, len(report.WeekStarts))
+	for _, item := range report.Items {
+		rows = append(rows, []string{
+			item.Name,
+			strconv.Itoa(item.Stock),
+			fmt.Sprintf("%.1f", item.TotalDemand),
+			strconv.Itoa(item.ReorderQuantity),
+			item.StockOutDate,
+			item.OrderByDate,
+			strconv.FormatBool(item.Seasonal),
+		})
+		for i, units := range item.Demand {
+			weekly[i] += units
+		}
+	}
+	chart.Labels = report.WeekStarts
+	chart.Values = weekly
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title: "Report: Demand Forecast",
+			Lines: []string{fmt.Sprintf("Method: %s, horizon %d weeks, lead time %d weeks", params.Method,
+				params.Horizon, params.LeadTime)},
+			Headers: []string{"name", "stock", "demand", "reorder", "stock_out", "order_by", "seasonal"},
+			Widths:  []float64{50, 20, 22, 20, 27, 27, 0},
+			Rows:    rows,
+			Chart:   chart,
+		},
+		Columns: []float32{200, 80, 90, 80, 110, 110, 80},
+	}, nil
+}
@@ -0,0 +1,99 @@
+package reports
+
+import (
+	"automatedShop/internal/export"
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+var weekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// heatmapReport is sales by weekday and hour of sale. Whole heatmap is exported, while PDF and
+// screen show it drawn with totals of weekdays.
+type heatmapReport struct{}
+
+func (heatmapReport) Info() Info {
+	return Info{Name: "sales_heatmap", Title: "Sales heatmap", File: "SalesHeatmapReport", Params: []Param{
+		periodParam,
+		{Key: "measure", Label: "measure", Kind: KindChoice, Choices: []string{"sales", dto.MetricRevenue}, Default: "sales"},
+	}}
+}
+
+func (heatmapReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap, err := s.ShopService.GetSalesHeatmap(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	measure := values.String("measure")
+	counts := heatmap.SalesCount
+	if measure == dto.MetricRevenue {
+		counts = heatmap.Revenue
+	}
+
+	grid := &reportDoc.Heatmap{Title: measure + " by weekday and hour", RowLabels: weekdays}
+	for hour := 0; hour < 24; hour++ {
+		grid.ColLabels = append(grid.ColLabels, strconv.Itoa(hour))
+	}
+
+	rows := make([][]string, 0, len(weekdays))
+	for day, name := range weekdays {
+		grid.Values = append(grid.Values, counts[day][:])
+
+		var sales, revenue int64
+		busiest := 0
+		for hour := range counts[day] {
+			sales += heatmap.SalesCount[day][hour]
+			revenue += heatmap.Revenue[day][hour]
+			if counts[day][hour] > counts[day][busiest] {
+				busiest = hour
+			}
+		}
+
+		busiestHour := ""
+		if counts[day][busiest] > 0 {
+			busiestHour = fmt.Sprintf("%02d:00", busiest)
+		}
+		rows = append(rows, []string{name, strconv.FormatInt(sales, 10), strconv.FormatInt(revenue, 10), busiestHour})
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title:   "Report: Sales Heatmap",
+			Lines:   []string{"Period: " + p.String(), "Heatmap: " + grid.Title},
+			Headers: []string{"weekday", "sales", "revenue", "busiest_hour"},
+			Widths:  []float64{40, 40, 40, 0},
+			Rows:    rows,
+			Heatmap: grid,
+		},
+		Columns: []float32{100, 100, 120, 120},
+		Export:  heatmapExportTable(grid, p.String()),
+	}, nil
+}
+
+// heatmapExportTable lays whole heatmap out as table with a row per weekday and a column per hour
+func heatmapExportTable(grid *reportDoc.Heatmap, period string) *export.Table {
+	table := &export.Table{
+		Title:   grid.Title,
+		Lines:   []string{"Period: " + period},
+		Headers: append([]string{"weekday"}, grid.ColLabels...),
+	}
+	for i, label := range grid.RowLabels {
+		row := []string{label}
+		for _, value := range grid.Values[i] {
+			row = append(row, strconv.FormatInt(value, 10))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
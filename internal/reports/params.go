@@ -0,0 +1,101 @@
+package reports
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/period"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind of parameter defines how it's entered and parsed.
+type Kind string
+
+const (
+	// KindPeriod is period entered as month (YYYY-MM), range (YYYY-MM-DD..YYYY-MM-DD) or relative
+	// period, the latter is used by schedules.
+	KindPeriod Kind = "period"
+	KindInt    Kind = "int"
+	KindFloat  Kind = "float"
+	// KindChoice is one of Param.Choices.
+	KindChoice Kind = "choice"
+	// KindBool is "true" or "false".
+	KindBool Kind = "bool"
+)
+
+// Param describes parameter of report.
+type Param struct {
+	Key     string
+	Label   string
+	Kind    Kind
+	Choices []string
+	Default string
+}
+
+// ParamPeriod is key of period parameter, reports for a period share it.
+const ParamPeriod = "period"
+
+var periodParam = Param{Key: ParamPeriod, Label: "period", Kind: KindPeriod}
+
+// RangeSeparator separates first and last days of period entered as range.
+const RangeSeparator = ".."
+
+// Values are parameters of report as text keyed by Param.Key. Empty ones are replaced by defaults.
+type Values struct {
+	params map[string]string
+	now    time.Time
+}
+
+// NewValues returns values of params at moment now, relative periods are counted from it.
+func NewValues(params []Param, values map[string]string, now time.Time) *Values {
+	v := &Values{params: make(map[string]string, len(params)), now: now}
+	for _, param := range params {
+		v.params[param.Key] = param.Default
+		if value := strings.TrimSpace(values[param.Key]); value != "" {
+			v.params[param.Key] = value
+		}
+	}
+
+	return v
+}
+
+func (v *Values) String(key string) string {
+	return v.params[key]
+}
+
+func (v *Values) Bool(key string) bool {
+	return v.params[key] == "true"
+}
+
+func (v *Values) Int(key string) (int, error) {
+	value, err := strconv.Atoi(v.params[key])
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be integer", customErr.ErrInvalidReportParams, key)
+	}
+
+	return value, nil
+}
+
+func (v *Values) Float(key string) (float64, error) {
+	value, err := strconv.ParseFloat(v.params[key], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be number", customErr.ErrInvalidReportParams, key)
+	}
+
+	return value, nil
+}
+
+// Period parses period entered as month, range or relative period.
+func (v *Values) Period(key string) (period.Period, error) {
+	value := v.params[key]
+
+	if from, to, ok := strings.Cut(value, RangeSeparator); ok {
+		return period.Parse(from, to)
+	}
+	if p, err := period.Month(value); err == nil {
+		return p, nil
+	}
+
+	return period.Relative(value).Period(v.now)
+}
@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"automatedShop/internal/period"
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services/dto"
+	"strconv"
+	"strings"
+)
+
+// PivotFile is the prefix of names of generated pivot reports.
+const PivotFile = "PivotReport"
+
+const pivotTotal = "total"
+
+// PivotOutput lays out pivot table built by user. It isn't a registered report as its definition is
+// built on a screen of its own. Name is name of saved definition or empty.
+func PivotOutput(name string, p period.Period, data *dto.PivotReportData) *Output {
+	definition := data.Definition
+
+	lines := []string{"Period: " + p.String()}
+	if name != "" {
+		lines = append([]string{"Definition: " + name}, lines...)
+	}
+	if len(definition.Rows) > 0 {
+		lines = append(lines, "Rows: "+strings.Join(definition.Rows, ", "))
+	}
+	if definition.Column != "" {
+		lines = append(lines, "Columns: "+definition.Column)
+	}
+	if len(definition.Filters) > 0 {
+		lines = append(lines, "Filters: "+FormatPivotFilters(definition.Filters))
+	}
+
+	headers := append([]string(nil), definition.Rows...)
+	columns := make([]float32, 0, len(headers))
+	for range headers {
+		columns = append(columns, 150)
+	}
+	for _, column := range append(append([]string(nil), data.Columns...), pivotTotal) {
+		for _, measure := range definition.Measures {
+			switch {
+			case definition.Column == "":
+				headers = append(headers, measure)
+			case len(definition.Measures) == 1:
+				headers = append(headers, column)
+			default:
+				headers = append(headers, column+" "+measure)
+			}
+			columns = append(columns, 110)
+		}
+	}
+
+	rows := make([][]string, 0, len(data.Rows))
+	for _, row := range data.Rows {
+		cells := make([]string, len(definition.Rows), len(headers))
+		copy(cells, row.Keys)
+		if row.Level < len(definition.Rows) {
+			cells[row.Level] = pivotTotal
+		}
+		for _, values := range row.Values {
+			for _, value := range values {
+				cells = append(cells, strconv.FormatInt(value, 10))
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title:   "Report: Pivot",
+			Lines:   lines,
+			Headers: headers,
+			Rows:    rows,
+		},
+		Columns: columns,
+	}
+}
+
+// FormatPivotFilters formats filters as "dimension operator value" separated by semicolons.
+func FormatPivotFilters(filters []dto.PivotFilter) string {
+	parts := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		parts = append(parts, filter.Dimension+" "+filter.Operator+" "+strconv.Quote(filter.Value))
+	}
+
+	return strings.Join(parts, "; ")
+}
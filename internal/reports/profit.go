@@ -0,0 +1,60 @@
+package reports
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"context"
+	"strconv"
+)
+
+// profitReport is profit and loss statement for period
+type profitReport struct{}
+
+func (profitReport) Info() Info {
+	return Info{Name: "profit", Title: "Profit and loss", File: "ProfitReport", Params: []Param{periodParam}}
+}
+
+func (profitReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.ShopService.GetProfitReport(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	format := func(value int64) string { return strconv.FormatInt(value, 10) }
+	rows := [][]string{
+		{"Revenue", format(report.Revenue)},
+		{"Cost of goods sold", format(report.Cogs)},
+		{"Gross profit (known cost)", format(report.GrossProfit)},
+	}
+	for _, expense := range report.Expenses {
+		rows = append(rows, []string{"  " + expense.Name, format(expense.Total)})
+	}
+	rows = append(rows,
+		[]string{"Total expenses", format(report.TotalExpenses)},
+		[]string{"Net profit (known cost)", format(report.NetProfit)},
+	)
+
+	lines := []string{
+		"Period: " + report.From + " to " + report.To,
+		"COGS is at current costs of items, changed costs change past periods too",
+	}
+	if report.RevenueWithoutCost > 0 {
+		lines = append(lines, "Profit excludes revenue "+format(report.RevenueWithoutCost)+" of items with unknown cost")
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title:   "Report: Profit and Loss",
+			Lines:   lines,
+			Headers: []string{"item", "amount"},
+			Widths:  []float64{120, 0},
+			Rows:    rows,
+		},
+		Columns: []float32{300, 150},
+	}, nil
+}
@@ -0,0 +1,97 @@
+package reports
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const customRanking = "Custom"
+
+// rankingReport is top or bottom N items by metric. Preset other than custom one overrides
+// metric, grouping, N and order.
+type rankingReport struct {
+	presets []string
+}
+
+func newRankingReport() rankingReport {
+	presets := []string{customRanking}
+	for _, preset := range dto.RankingPresets {
+		presets = append(presets, preset.Name)
+	}
+
+	return rankingReport{presets: presets}
+}
+
+func (r rankingReport) Info() Info {
+	return Info{Name: "ranking", Title: "Top-N ranking", File: "RankingReport", Params: []Param{
+		{Key: "preset", Label: "preset", Kind: KindChoice, Choices: r.presets, Default: customRanking},
+		{Key: "limit", Label: "N", Kind: KindInt, Default: "5"},
+		{Key: "metric", Label: "metric", Kind: KindChoice, Default: dto.MetricRevenue,
+			Choices: []string{dto.MetricRevenue, dto.MetricUnits, dto.MetricMargin, dto.MetricSales}},
+		{Key: "group_by", Label: "group by", Kind: KindChoice, Default: dto.GroupByProduct,
+			Choices: []string{dto.GroupByProduct, dto.GroupByCategory, dto.GroupByLocation}},
+		{Key: "worst", Label: "worst first", Kind: KindBool, Default: "false"},
+		periodParam,
+	}}
+}
+
+func (rankingReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := values.Int("limit")
+	if err != nil {
+		return nil, err
+	}
+
+	params := &dto.RankingParams{
+		Metric:  values.String("metric"),
+		GroupBy: values.String("group_by"),
+		Limit:   limit,
+		Worst:   values.Bool("worst"),
+	}
+	for _, preset := range dto.RankingPresets {
+		if preset.Name == values.String("preset") {
+			*params = preset.Params
+		}
+	}
+	params.Period = p
+
+	data, err := s.ShopService.GetRankingReport(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(data))
+	for i, item := range data {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			item.Name,
+			strconv.FormatInt(item.Revenue, 10),
+			strconv.FormatInt(item.Units, 10),
+			strconv.FormatInt(item.Margin, 10),
+			strconv.FormatInt(item.SalesCount, 10),
+		})
+	}
+
+	order := "best"
+	if params.Worst {
+		order = "worst"
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title:   fmt.Sprintf("Report: Ranking, %s %d by %s", order, params.Limit, params.Metric),
+			Lines:   []string{"Period: " + p.String(), "Group by: " + params.GroupBy},
+			Headers: []string{"#", params.GroupBy, "revenue", "units", "margin", "sales"},
+			Widths:  []float64{10, 70, 30, 25, 30, 0},
+			Rows:    rows,
+		},
+		Columns: []float32{40, 250, 100, 80, 100, 80},
+	}, nil
+}
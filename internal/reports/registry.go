@@ -0,0 +1,90 @@
+package reports
+
+import (
+	"automatedShop/internal/export"
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Report is a plugin of report registry. Adding a report takes a type implementing Report and
+// its registration, parameter forms and views of the app and the scheduler are built from Info.
+type Report interface {
+	Info() Info
+	// Generate fetches report's data through services and lays it out as output.
+	Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error)
+}
+
+// Info describes report. Name identifies report in schedules, Title is shown to user and File is
+// the prefix of names of generated files.
+type Info struct {
+	Name   string
+	Title  string
+	File   string
+	Params []Param
+}
+
+// Output is report laid out for the screen, PDF and export. Document holds its title, lines of
+// parameters, chart or heatmap and table, Columns are widths of the table on the screen.
+type Output struct {
+	Document *reportDoc.Document
+	Columns  []float32
+	// Export is exported instead of document's table when set
+	Export *export.Table
+}
+
+// ExportTable returns table exported to CSV, XLSX and other formats.
+func (o *Output) ExportTable() *export.Table {
+	if o.Export != nil {
+		return o.Export
+	}
+
+	return &export.Table{
+		Title:   strings.TrimPrefix(o.Document.Title, "Report: "),
+		Lines:   o.Document.Lines,
+		Headers: o.Document.Headers,
+		Rows:    o.Document.Rows,
+	}
+}
+
+var (
+	registry = make(map[string]Report)
+	// order keeps reports in order of registration, it's the order they're listed to user in
+	order []Report
+)
+
+// init registers built-in reports in the order they're listed to user.
+func init() {
+	Register(profitReport{})
+	Register(newRankingReport())
+	Register(comparisonReport{})
+	Register(newTrendReport())
+	Register(heatmapReport{})
+	Register(abcXyzReport{})
+	Register(turnoverReport{})
+	Register(forecastReport{})
+}
+
+// Register adds report to registry. It panics if report with the same name is already registered.
+func Register(report Report) {
+	name := report.Info().Name
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("report %q is already registered", name))
+	}
+
+	registry[name] = report
+	order = append(order, report)
+}
+
+// All returns registered reports in order of registration.
+func All() []Report {
+	return order
+}
+
+// Get returns registered report by its name.
+func Get(name string) (Report, bool) {
+	report, ok := registry[name]
+	return report, ok
+}
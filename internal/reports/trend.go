@@ -0,0 +1,78 @@
+package reports
+
+import (
+	"automatedShop/internal/period"
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"strconv"
+)
+
+// trendReport is time series of sales by day, week or month drawn as line or bar chart
+type trendReport struct {
+	buckets []string
+}
+
+func newTrendReport() trendReport {
+	buckets := make([]string, 0, len(period.Buckets))
+	for _, bucket := range period.Buckets {
+		buckets = append(buckets, string(bucket))
+	}
+
+	return trendReport{buckets: buckets}
+}
+
+func (r trendReport) Info() Info {
+	return Info{Name: "sales_trend", Title: "Sales trend", File: "SalesTrendReport", Params: []Param{
+		{Key: "bucket", Label: "bucket", Kind: KindChoice, Choices: r.buckets, Default: string(period.DayBucket)},
+		{Key: "measure", Label: "measure", Kind: KindChoice, Choices: []string{dto.MetricRevenue, dto.MetricUnits},
+			Default: dto.MetricRevenue},
+		{Key: "chart", Label: "chart", Kind: KindChoice, Choices: []string{"line", "bar"}, Default: "line"},
+		periodParam,
+	}}
+}
+
+func (trendReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := period.Bucket(values.String("bucket"))
+	points, err := s.ShopService.GetSalesTrend(ctx, &dto.TrendParams{Period: p, Bucket: bucket})
+	if err != nil {
+		return nil, err
+	}
+
+	measure := values.String("measure")
+	chart := &reportDoc.Chart{Title: measure + " by " + string(bucket), Bars: values.String("chart") == "bar"}
+	rows := make([][]string, 0, len(points))
+	for _, point := range points {
+		value := point.Revenue
+		if measure == dto.MetricUnits {
+			value = point.Units
+		}
+		chart.Labels = append(chart.Labels, point.Start)
+		chart.Values = append(chart.Values, float64(value))
+
+		rows = append(rows, []string{
+			point.Start,
+			strconv.FormatInt(point.Revenue, 10),
+			strconv.FormatInt(point.Units, 10),
+			strconv.FormatInt(point.SalesCount, 10),
+		})
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title:   "Report: Sales Trend",
+			Lines:   []string{"Period: " + p.String(), "Chart: " + chart.Title},
+			Headers: []string{string(bucket), "revenue", "units", "sales"},
+			Widths:  []float64{40, 40, 30, 0},
+			Rows:    rows,
+			Chart:   chart,
+		},
+		Columns: []float32{120, 100, 80, 80},
+	}, nil
+}
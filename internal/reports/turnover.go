@@ -0,0 +1,84 @@
+package reports
+
+import (
+	reportDoc "automatedShop/internal/report"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// turnoverReport is turnover and days of supply of items with dead stock first
+type turnoverReport struct{}
+
+func (turnoverReport) Info() Info {
+	return Info{Name: "turnover", Title: "Turnover and dead stock", File: "TurnoverReport", Params: []Param{
+		periodParam,
+		{Key: "dead_days", Label: "dead after, days", Kind: KindInt, Default: "90"},
+	}}
+}
+
+func (turnoverReport) Generate(ctx context.Context, s *services.Service, values *Values) (*Output, error) {
+	p, err := values.Period(ParamPeriod)
+	if err != nil {
+		return nil, err
+	}
+	deadDays, err := values.Int("dead_days")
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.AnalysisService.GetTurnoverReport(ctx, &dto.TurnoverParams{Period: p, DeadDays: deadDays})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(report.Items))
+	for _, item := range report.Items {
+		cost, tiedUp := "", ""
+		if item.Cost != nil {
+			cost = strconv.Itoa(*item.Cost)
+		}
+		if item.TiedUpValue != nil {
+			tiedUp = strconv.FormatInt(*item.TiedUpValue, 10)
+		}
+
+		rows = append(rows, []string{
+			item.Name,
+			strconv.Itoa(item.Quantity),
+			cost,
+			tiedUp,
+			strconv.FormatInt(item.Units, 10),
+			formatRatio(item.Turnover),
+			formatRatio(item.DaysOfSupply),
+			item.LastSale,
+			strconv.FormatBool(item.Dead),
+		})
+	}
+
+	return &Output{
+		Document: &reportDoc.Document{
+			Title: "Report: Inventory Turnover",
+			Lines: []string{
+				"Period: " + p.String(),
+				fmt.Sprintf("Dead stock: no sales for %d days", deadDays),
+				fmt.Sprintf("Tied-up value: %d, in dead stock: %d", report.TiedUpValue, report.DeadValue),
+			},
+			Headers: []string{"name", "quantity", "cost", "tied_up", "units_sold", "turnover", "days_of_supply", "last_sale", "dead"},
+			Widths:  []float64{40, 18, 15, 20, 20, 18, 25, 22, 0},
+			Rows:    rows,
+		},
+		Columns: []float32{200, 80, 70, 90, 90, 80, 120, 110, 60},
+	}, nil
+}
+
+// formatRatio formats computed ratio which is infinite when there was nothing to divide by
+func formatRatio(value float64) string {
+	if math.IsInf(value, 0) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f", value)
+}
@@ -4,36 +4,135 @@ import (
 	"automatedShop/internal/repository/dto"
 	logicDto "automatedShop/internal/services/dto"
 	"context"
+	"time"
 )
 
 type IShopRepository interface {
 	// Handbook's methods
 	ShowWarehousesTable(context.Context) ([]*logicDto.WarehousesData, error)
-	CreateWarehousesItem(context.Context, string, int, int) error
-	UpdateWarehousesItem(context.Context, string, int, int, int) error
+	GetWarehousesItem(context.Context, int) (*logicDto.WarehousesData, error)
+	CreateWarehousesItem(context.Context, *logicDto.WarehousesData) (int, error)
+	UpdateWarehousesItem(context.Context, *logicDto.WarehousesData) error
 	DeleteWarehousesItem(context.Context, int) error
 	ShowExpenseItemsTable(context.Context) ([]*logicDto.ExpenseItemsData, error)
-	CreateExpenseItem(context.Context, string) error
-	UpdateExpenseItem(context.Context, string, int) error
+	GetExpenseItem(context.Context, int) (*logicDto.ExpenseItemsData, error)
+	CreateExpenseItem(context.Context, string) (int, error)
+	UpdateExpenseItem(context.Context, *logicDto.ExpenseItemsData) error
 	DeleteExpenseItem(context.Context, int) error
 
 	// Journal's methods
 	ShowChargesTable(context.Context) ([]*logicDto.ChargesData, error)
-	CreateChargesItem(context.Context, *logicDto.ChargesData) error
+	GetChargesItem(context.Context, int) (*logicDto.ChargesData, error)
+	CreateChargesItem(context.Context, *logicDto.ChargesData) (int, error)
 	UpdateChargesItem(context.Context, *logicDto.ChargesData) error
 	DeleteChargesItem(context.Context, int) error
 	ShowSalesTable(context.Context) ([]*logicDto.SalesData, error)
-	CreateSalesItem(context.Context, *logicDto.SalesData) error
+	GetSalesItem(context.Context, int) (*logicDto.SalesData, error)
+	CreateSalesItem(context.Context, *logicDto.SalesData) (int, error)
 	UpdateSalesItem(context.Context, *logicDto.SalesData) error
 	DeleteSalesItem(context.Context, int) error
 
 	// Report's methods
-	CountMonthProfit(context.Context) (int64, error)
-	GetFiveBestItems(context.Context, string, string) ([]*logicDto.BestItemsData, error)
+	GetProfitReport(context.Context, time.Time, time.Time) (*logicDto.ProfitReportData, error)
+	GetRankingReport(context.Context, *logicDto.RankingParams) ([]*logicDto.RankingItemData, error)
+	GetProductSales(context.Context, time.Time, time.Time) ([]*logicDto.ProductSalesData, error)
+	GetDailySales(context.Context, time.Time, time.Time) ([]*logicDto.DailySalesData, error)
+	GetHourlySales(context.Context, time.Time, time.Time) ([]*logicDto.HourlySalesData, error)
+	GetItemDailySales(context.Context, time.Time, time.Time) ([]*logicDto.ItemDailySalesData, error)
+	GetItemStockSales(context.Context, time.Time, time.Time) ([]*logicDto.ItemStockSalesData, error)
+
+	// Trash's methods
+	ShowTrash(context.Context) ([]*logicDto.TrashItemData, error)
+	RestoreTrashItem(context.Context, string, int) error
+	PurgeTrashItem(context.Context, string, int) error
+}
+
+type IAuditRepository interface {
+	SaveAuditRecord(context.Context, *logicDto.AuditRecordData) error
+	ShowAuditLog(context.Context, *logicDto.AuditFilterData) ([]*logicDto.AuditRecordData, error)
+}
+
+type IArchiveRepository interface {
+	SaveReport(context.Context, *logicDto.ArchivedReportData) (int64, error)
+	ShowReports(context.Context, string) ([]*logicDto.ArchivedReportData, error)
+	GetReport(context.Context, int64) (*logicDto.ArchivedReportData, error)
+}
+
+type IScheduleRepository interface {
+	CreateSchedule(context.Context, *logicDto.ScheduleData) (int, error)
+	ShowSchedules(context.Context) ([]*logicDto.ScheduleData, error)
+	GetDueSchedules(context.Context, time.Time) ([]*logicDto.ScheduleData, error)
+	DeleteSchedule(context.Context, int) error
+	ClaimSchedule(context.Context, int, time.Time, time.Time) (bool, error)
+	SaveScheduleRun(context.Context, *logicDto.ScheduleRunData) error
+	ShowScheduleRuns(context.Context, int) ([]*logicDto.ScheduleRunData, error)
+}
+
+type IPivotRepository interface {
+	GetPivotData(context.Context, *logicDto.PivotParams) ([]*logicDto.PivotGroupData, error)
+	SavePivotDefinition(context.Context, *logicDto.SavedPivotData) (int, error)
+	ShowPivotDefinitions(context.Context, string) ([]*logicDto.SavedPivotData, error)
+	DeletePivotDefinition(context.Context, int, string) error
+}
+
+type IAnomalyRepository interface {
+	GetChargeHistory(context.Context) ([]*logicDto.ChargeHistoryData, error)
+	GetSaleHistory(context.Context) ([]*logicDto.SaleHistoryData, error)
+	SaveAnomalyAck(context.Context, *logicDto.AnomalyAckData) error
+	ShowAnomalyAcks(context.Context) ([]*logicDto.AnomalyAckData, error)
+}
+
+type IAggregateRepository interface {
+	RebuildSalesDaily(context.Context) (int64, error)
+	RebuildChargesDaily(context.Context) (int64, error)
+}
+
+// IImportRepository loads large imports at once recording loaded records in audit log on behalf
+// of given user, smaller ones are created record by record.
+type IImportRepository interface {
+	CopyWarehouses(context.Context, []*logicDto.WarehousesData, string) (int64, error)
+	CopyExpenseItems(context.Context, []string, string) (int64, error)
+	CopyCharges(context.Context, []*logicDto.ChargesData, string) (int64, error)
+	CopySales(context.Context, []*logicDto.SalesData, string) (int64, error)
+}
+
+type IBackupRepository interface {
+	SetSnapshot(context.Context) error
+	SchemaVersion(context.Context) (int, error)
+	TableColumns(context.Context, string) ([]string, error)
+	DumpTable(context.Context, string, []string, func([]byte) error) error
+	HasRows(context.Context, string) (bool, error)
+	ClearTables(context.Context, []string) error
+	RestoreRows(context.Context, string, []string, []byte) (int64, error)
+	RestoreUsers(context.Context, []byte) (int64, error)
+	ResetSequence(context.Context, string) error
+}
+
+// IExchangeRepository keeps ids of 1C objects exchanged in CommerceML files.
+type IExchangeRepository interface {
+	ShowExchangeIds(context.Context, string) ([]*logicDto.ExchangeIdData, error)
+	SaveExchangeId(context.Context, *logicDto.ExchangeIdData) error
+	ShowSales(context.Context, time.Time, time.Time) ([]*logicDto.SalesData, error)
+}
+
+// IBankRepository keeps rules matching bank payments to expense items and keys of imported payments.
+type IBankRepository interface {
+	ShowBankRules(context.Context) ([]*logicDto.BankRuleData, error)
+	CreateBankRule(context.Context, *logicDto.BankRuleData) (int, error)
+	DeleteBankRule(context.Context, int) error
+	ShowImportedPayments(context.Context, []string) ([]string, error)
+	SaveImportedPayment(context.Context, string, int) error
+}
+
+// ITransactor runs several repository calls in one transaction. Repositories use the
+// transaction when they are called with context passed to fn.
+type ITransactor interface {
+	RunInTx(ctx context.Context, fn func(context.Context) error) error
 }
 
 type IAuthRepository interface {
 	SaveUser(context.Context, string, []byte) error
 	FindUser(context.Context, string) (*dto.User, error)
 	IsRoot(context.Context, int64) (bool, error)
+	SetAdmin(context.Context, string, bool) error
 }
@@ -4,4 +4,5 @@ type User struct {
 	ID       uint64 `db:"id"`
 	Login    string `db:"login"`
 	PassHash []byte `db:"pass_hash"`
+	IsAdmin  bool   `db:"is_admin"`
 }
@@ -0,0 +1,80 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"context"
+	"fmt"
+)
+
+// Daily aggregates are rebuilt while sales and charges are locked against writes, so that triggers
+// don't change them meanwhile.
+const (
+	_lockSales       = `LOCK TABLE "sales" IN SHARE MODE`
+	_truncSalesDaily = `TRUNCATE "sales_daily"`
+	_fillSalesDaily  = `INSERT INTO "sales_daily" (day, warehouses_id, revenue, units, sales_count)
+						SELECT sale_date::date, warehouses_id,
+							   COALESCE(SUM(quantity::bigint * amount), 0), COALESCE(SUM(quantity), 0), COUNT(*)
+						FROM "sales"
+						WHERE deleted_at IS NULL AND sale_date IS NOT NULL AND warehouses_id IS NOT NULL
+						GROUP BY sale_date::date, warehouses_id
+					   `
+	_lockCharges       = `LOCK TABLE "charges" IN SHARE MODE`
+	_truncChargesDaily = `TRUNCATE "charges_daily"`
+	_fillChargesDaily  = `INSERT INTO "charges_daily" (day, expense_item_id, amount, charges_count)
+						  SELECT charge_date::date, expense_item_id, COALESCE(SUM(amount), 0), COUNT(*)
+						  FROM "charges"
+						  WHERE deleted_at IS NULL AND charge_date IS NOT NULL AND expense_item_id IS NOT NULL
+						  GROUP BY charge_date::date, expense_item_id
+						 `
+)
+
+type AggregateProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewAggregateProvider(db *dataprovider.Provider) *AggregateProvider {
+	return &AggregateProvider{db: db}
+}
+
+// RebuildSalesDaily fills sales_daily from sales anew and returns number of its rows. It must be
+// called in transaction.
+func (p *AggregateProvider) RebuildSalesDaily(ctx context.Context) (int64, error) {
+	const op = "AggregateRepo.RebuildSalesDaily"
+
+	rows, err := p.rebuild(ctx, _lockSales, _truncSalesDaily, _fillSalesDaily)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rows, nil
+}
+
+// RebuildChargesDaily fills charges_daily from charges anew and returns number of its rows. It must
+// be called in transaction.
+func (p *AggregateProvider) RebuildChargesDaily(ctx context.Context) (int64, error) {
+	const op = "AggregateRepo.RebuildChargesDaily"
+
+	rows, err := p.rebuild(ctx, _lockCharges, _truncChargesDaily, _fillChargesDaily)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rows, nil
+}
+
+// rebuild runs lock and truncate statements, then fill statement whose number of inserted rows
+// is returned.
+func (p *AggregateProvider) rebuild(ctx context.Context, lock, truncate, fill string) (int64, error) {
+	for _, query := range []string{lock, truncate} {
+		if _, err := p.db.Executor(ctx).ExecContext(ctx, query); err != nil {
+			return 0, err
+		}
+	}
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, fill)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,121 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+)
+
+const (
+	// Charges and sales without amount, quantity or date aren't checked, items without price have zero price
+	_getChargeHistory = `SELECT c.id, c.expense_item_id, COALESCE(e.name, ''), c.amount, to_char(c.charge_date, 'YYYY-MM-DD')
+						 FROM charges c
+						 JOIN expense_items e ON c.expense_item_id = e.id
+						 WHERE c.deleted_at IS NULL AND c.amount IS NOT NULL AND c.charge_date IS NOT NULL
+						 ORDER BY c.charge_date, c.id
+						`
+	_getSaleHistory = `SELECT s.id, s.warehouses_id, COALESCE(w.name, ''), s.amount, s.quantity, COALESCE(w.amount, 0),
+							  w.cost, to_char(s.sale_date, 'YYYY-MM-DD')
+					   FROM sales s
+					   JOIN warehouses w ON s.warehouses_id = w.id
+					   WHERE s.deleted_at IS NULL AND s.amount IS NOT NULL AND s.quantity IS NOT NULL
+						 AND s.sale_date IS NOT NULL
+					   ORDER BY s.sale_date, s.id
+					  `
+	_saveAnomalyAck = `INSERT INTO "anomaly_acknowledgements" (kind, record_key, value, user_login)
+					   VALUES ($1, $2, $3, $4)
+					   ON CONFLICT (kind, record_key) DO UPDATE
+						   SET value = EXCLUDED.value, user_login = EXCLUDED.user_login, acknowledged_at = now()`
+	_showAnomalyAcks = `SELECT kind, record_key, value, user_login, to_char(acknowledged_at, 'YYYY-MM-DD HH24:MI:SS')
+						FROM "anomaly_acknowledgements"
+					   `
+)
+
+type AnomalyProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewAnomalyProvider(db *dataprovider.Provider) *AnomalyProvider {
+	return &AnomalyProvider{db: db}
+}
+
+// GetChargeHistory returns all charges with amount and date in order of their dates.
+func (p *AnomalyProvider) GetChargeHistory(ctx context.Context) ([]*dto.ChargeHistoryData, error) {
+	const op = "AnomalyRepo.GetChargeHistory"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _getChargeHistory)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var charges []*dto.ChargeHistoryData
+	for rows.Next() {
+		var charge dto.ChargeHistoryData
+		if err = rows.Scan(&charge.Id, &charge.ExpenseItemId, &charge.ExpenseItem, &charge.Amount, &charge.Day); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		charges = append(charges, &charge)
+	}
+
+	return charges, nil
+}
+
+// GetSaleHistory returns all sales with amount, quantity and date in order of their dates with
+// current price and cost of items.
+func (p *AnomalyProvider) GetSaleHistory(ctx context.Context) ([]*dto.SaleHistoryData, error) {
+	const op = "AnomalyRepo.GetSaleHistory"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _getSaleHistory)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var sales []*dto.SaleHistoryData
+	for rows.Next() {
+		var sale dto.SaleHistoryData
+		if err = rows.Scan(&sale.Id, &sale.WarehousesId, &sale.Product, &sale.Amount, &sale.Quantity, &sale.Price, &sale.Cost,
+			&sale.Day); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sales = append(sales, &sale)
+	}
+
+	return sales, nil
+}
+
+// SaveAnomalyAck stores acknowledgement of anomaly, the previous one of the same anomaly is replaced.
+func (p *AnomalyProvider) SaveAnomalyAck(ctx context.Context, ack *dto.AnomalyAckData) error {
+	const op = "AnomalyRepo.SaveAnomalyAck"
+
+	_, err := p.db.Executor(ctx).ExecContext(ctx, _saveAnomalyAck, ack.Kind, ack.Key, ack.Value, ack.UserLogin)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShowAnomalyAcks returns all acknowledgements of anomalies.
+func (p *AnomalyProvider) ShowAnomalyAcks(ctx context.Context) ([]*dto.AnomalyAckData, error) {
+	const op = "AnomalyRepo.ShowAnomalyAcks"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showAnomalyAcks)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var acks []*dto.AnomalyAckData
+	for rows.Next() {
+		var ack dto.AnomalyAckData
+		if err = rows.Scan(&ack.Kind, &ack.Key, &ack.Value, &ack.UserLogin, &ack.AcknowledgedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		acks = append(acks, &ack)
+	}
+
+	return acks, nil
+}
@@ -0,0 +1,95 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const (
+	_saveReport = `INSERT INTO "report_archive" (report_type, params, format, file_name, content, user_login)
+				   VALUES ($1, $2, $3, $4, $5, $6)
+				   RETURNING id`
+	_showReports = `SELECT id, report_type, params, format, file_name, user_login,
+						   to_char(created_at, 'YYYY-MM-DD HH24:MI:SS')
+					FROM "report_archive"
+					WHERE NULLIF($1, '') IS NULL OR user_login = $1
+					ORDER BY created_at DESC, id DESC
+					LIMIT 1000
+				   `
+	_getReport = `SELECT id, report_type, params, format, file_name, user_login,
+						 to_char(created_at, 'YYYY-MM-DD HH24:MI:SS'), content
+				  FROM "report_archive"
+				  WHERE id = $1
+				 `
+)
+
+type ArchiveProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewArchiveProvider(db *dataprovider.Provider) *ArchiveProvider {
+	return &ArchiveProvider{db: db}
+}
+
+// SaveReport stores generated report with its file. Time of generation is set by database.
+func (p *ArchiveProvider) SaveReport(ctx context.Context, report *dto.ArchivedReportData) (int64, error) {
+	const op = "ArchiveRepo.SaveReport"
+
+	var id int64
+	err := p.db.Executor(ctx).GetContext(ctx, &id, _saveReport, report.ReportType, report.Params, report.Format,
+		report.FileName, report.Content, report.UserLogin)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ShowReports returns last 1000 archived reports without files, newest first. Reports are
+// filtered by author unless login is empty.
+func (p *ArchiveProvider) ShowReports(ctx context.Context, login string) ([]*dto.ArchivedReportData, error) {
+	const op = "ArchiveRepo.ShowReports"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showReports, login)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reports []*dto.ArchivedReportData
+	for rows.Next() {
+		var report dto.ArchivedReportData
+		if err = rows.Scan(&report.Id, &report.ReportType, &report.Params, &report.Format, &report.FileName,
+			&report.UserLogin, &report.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		reports = append(reports, &report)
+	}
+
+	return reports, nil
+}
+
+// GetReport returns archived report with its file.
+func (p *ArchiveProvider) GetReport(ctx context.Context, id int64) (*dto.ArchivedReportData, error) {
+	const op = "ArchiveRepo.GetReport"
+
+	var report dto.ArchivedReportData
+
+	row := p.db.Executor(ctx).QueryRowxContext(ctx, _getReport, id)
+	err := row.Scan(&report.Id, &report.ReportType, &report.Params, &report.Format, &report.FileName,
+		&report.UserLogin, &report.CreatedAt, &report.Content)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,69 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+)
+
+const (
+	_saveAuditRecord = `INSERT INTO "audit_log" (user_login, table_name, record_id, action, before_data, after_data)
+						VALUES ($1, $2, $3, $4, $5, $6)`
+	_showAuditLog = `SELECT id, user_login, to_char(changed_at, 'YYYY-MM-DD HH24:MI:SS'), table_name, record_id, action,
+						    before_data, after_data
+					 FROM "audit_log"
+					 WHERE (NULLIF($1, '') IS NULL OR user_login = $1)
+					   AND (NULLIF($2, '') IS NULL OR table_name = $2)
+					   AND ($3 = 0 OR record_id = $3)
+					   AND changed_at >= COALESCE(NULLIF($4, '')::timestamp, '-infinity')
+					   AND changed_at < COALESCE(NULLIF($5, '')::timestamp + INTERVAL '1 day', 'infinity')
+					 ORDER BY changed_at DESC, id DESC
+					 LIMIT 1000
+					`
+)
+
+type AuditProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewAuditProvider(db *dataprovider.Provider) *AuditProvider {
+	return &AuditProvider{db: db}
+}
+
+// SaveAuditRecord appends record to audit log. Time of change is set by database.
+func (p *AuditProvider) SaveAuditRecord(ctx context.Context, record *dto.AuditRecordData) error {
+	const op = "AuditRepo.SaveAuditRecord"
+
+	_, err := p.db.Executor(ctx).ExecContext(ctx, _saveAuditRecord, record.UserLogin, record.Table, record.RecordId,
+		record.Action, record.Before, record.After)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShowAuditLog returns last 1000 audit records matching filter, newest first.
+func (p *AuditProvider) ShowAuditLog(ctx context.Context, filter *dto.AuditFilterData) ([]*dto.AuditRecordData, error) {
+	const op = "AuditRepo.ShowAuditLog"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showAuditLog, filter.UserLogin, filter.Table, filter.RecordId,
+		filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var records []*dto.AuditRecordData
+	for rows.Next() {
+		var record dto.AuditRecordData
+		if err = rows.Scan(&record.Id, &record.UserLogin, &record.ChangedAt, &record.Table, &record.RecordId,
+			&record.Action, &record.Before, &record.After); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+
+	return records, nil
+}
@@ -12,8 +12,9 @@ import (
 
 const (
 	_saveUserQuery   = `INSERT INTO "users"(login, pass_hash) VALUES ($1, $2) RETURNING id`
-	_findUserQuery   = `SELECT id, login, pass_hash FROM "users" WHERE login = $1`
-	_isRootUserQuery = `SELECT is_admin FROM "users" WHERE id = $1`
+	_findUserQuery   = `SELECT id, login, pass_hash, COALESCE(is_admin, false) AS is_admin FROM "users" WHERE login = $1`
+	_isRootUserQuery = `SELECT COALESCE(is_admin, false) FROM "users" WHERE id = $1`
+	_setAdminQuery   = `UPDATE "users" SET is_admin = $2 WHERE login = $1`
 )
 
 type AuthProvider struct {
@@ -28,7 +29,7 @@ func NewAuthProvider(db *dataprovider.Provider) *AuthProvider {
 func (p *AuthProvider) SaveUser(ctx context.Context, login string, pwdHash []byte) error {
 	const op = "AuthRepo.SaveUser"
 
-	_, err := p.db.ExecContext(ctx, _saveUserQuery, login, pwdHash)
+	_, err := p.db.Executor(ctx).ExecContext(ctx, _saveUserQuery, login, pwdHash)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -42,7 +43,7 @@ func (p *AuthProvider) FindUser(ctx context.Context, login string) (*dto.User, e
 
 	var user dto.User
 
-	err := p.db.GetContext(ctx, &user, _findUserQuery, login)
+	err := p.db.Executor(ctx).GetContext(ctx, &user, _findUserQuery, login)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return &dto.User{}, fmt.Errorf("%s: %w", op, customErr.ErrUserNotFound)
@@ -54,21 +55,13 @@ func (p *AuthProvider) FindUser(ctx context.Context, login string) (*dto.User, e
 	return &user, nil
 }
 
-// TODO: исправить mysql на psql нотацию
-
 // IsRoot checks if user is admin.
 func (p *AuthProvider) IsRoot(ctx context.Context, uid int64) (bool, error) {
 	const op = "AuthRepo.IsRoot"
 
-	stmt, err := p.db.Prepare("SELECT is_admin FROM user WHERE id = ?")
-	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
-	}
-
 	var isRoot bool
-	row := stmt.QueryRowContext(ctx, uid)
 
-	err = row.Scan(&isRoot)
+	err := p.db.Executor(ctx).GetContext(ctx, &isRoot, _isRootUserQuery, uid)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, fmt.Errorf("%s: %w", op, customErr.ErrUserNotFound)
@@ -79,3 +72,23 @@ func (p *AuthProvider) IsRoot(ctx context.Context, uid int64) (bool, error) {
 
 	return isRoot, nil
 }
+
+// SetAdmin grants admin rights to user with given login or takes them away.
+func (p *AuthProvider) SetAdmin(ctx context.Context, login string, isAdmin bool) error {
+	const op = "AuthRepo.SetAdmin"
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _setAdminQuery, login, isAdmin)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, customErr.ErrUserNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strings"
+)
+
+// Rows are dumped as JSON by row_to_json and restored by json_populate_recordset, which convert
+// values of any column type to JSON and back the way postgres formats them as text. Names of tables
+// and columns are checked by the service and quoted here.
+const (
+	_snapshotQuery      = `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY`
+	_schemaVersionQuery = `SELECT COALESCE(MAX(version), 0) FROM "schema_version"`
+	_tableColumnsQuery  = `SELECT column_name FROM information_schema.columns
+						   WHERE table_schema = current_schema() AND table_name = $1
+						   ORDER BY ordinal_position`
+	_restoreUsersQuery = `INSERT INTO "users" (login, pass_hash, is_admin)
+						  SELECT r.login, r.pass_hash, r.is_admin
+						  FROM json_populate_recordset(NULL::"users", $1::json) r
+						  WHERE NOT EXISTS (SELECT 1 FROM "users" u WHERE u.login = r.login)`
+)
+
+type BackupProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewBackupProvider(db *dataprovider.Provider) *BackupProvider {
+	return &BackupProvider{db: db}
+}
+
+// SetSnapshot makes transaction read-only and see data as of its start, so tables dumped in it are
+// consistent with each other. It must be the first query of transaction.
+func (p *BackupProvider) SetSnapshot(ctx context.Context) error {
+	const op = "BackupRepo.SetSnapshot"
+
+	if _, err := p.db.Executor(ctx).ExecContext(ctx, _snapshotQuery); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SchemaVersion returns number of the last migration applied to database.
+func (p *BackupProvider) SchemaVersion(ctx context.Context) (int, error) {
+	const op = "BackupRepo.SchemaVersion"
+
+	var version int
+	if err := p.db.Executor(ctx).GetContext(ctx, &version, _schemaVersionQuery); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
+
+// TableColumns returns names of columns of table, it's empty when there's no such table.
+func (p *BackupProvider) TableColumns(ctx context.Context, table string) ([]string, error) {
+	const op = "BackupRepo.TableColumns"
+
+	var columns []string
+	if err := p.db.Executor(ctx).SelectContext(ctx, &columns, _tableColumnsQuery, table); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return columns, nil
+}
+
+// DumpTable passes given columns of rows of table as JSON objects to fn, ordered by the first
+// column. All columns are dumped when columns is empty.
+func (p *BackupProvider) DumpTable(ctx context.Context, table string, columns []string, fn func([]byte) error) error {
+	const op = "BackupRepo.DumpTable"
+
+	list := "*"
+	if len(columns) > 0 {
+		list = quoteIdentifiers(columns)
+	}
+	query := fmt.Sprintf(`SELECT row_to_json(t)::text FROM (SELECT %s FROM %s ORDER BY 1) t`,
+		list, pgx.Identifier{table}.Sanitize())
+
+	rows, err := p.db.Executor(ctx).QueryxContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err = rows.Scan(&row); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err = fn(row); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// HasRows tells whether table has any rows, soft deleted included.
+func (p *BackupProvider) HasRows(ctx context.Context, table string) (bool, error) {
+	const op = "BackupRepo.HasRows"
+
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s)`, pgx.Identifier{table}.Sanitize())
+	if err := p.db.Executor(ctx).GetContext(ctx, &exists, query); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+// ClearTables deletes all rows of tables and resets their sequences.
+func (p *BackupProvider) ClearTables(ctx context.Context, tables []string) error {
+	const op = "BackupRepo.ClearTables"
+
+	query := `TRUNCATE ` + quoteIdentifiers(tables) + ` RESTART IDENTITY`
+	if _, err := p.db.Executor(ctx).ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RestoreRows inserts rows given as JSON array of objects into table. Only given columns are
+// inserted, the rest get their default values.
+func (p *BackupProvider) RestoreRows(ctx context.Context, table string, columns []string, rows []byte) (int64, error) {
+	const op = "BackupRepo.RestoreRows"
+
+	list := quoteIdentifiers(columns)
+	query := fmt.Sprintf(`INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, $1::json)`,
+		pgx.Identifier{table}.Sanitize(), list)
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, query, string(rows))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// RestoreUsers adds users given as JSON array of objects with login, pass_hash and is_admin. Users
+// with logins which already exist are skipped.
+func (p *BackupProvider) RestoreUsers(ctx context.Context, rows []byte) (int64, error) {
+	const op = "BackupRepo.RestoreUsers"
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _restoreUsersQuery, string(rows))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// ResetSequence moves sequence of id column of table past the greatest restored id.
+func (p *BackupProvider) ResetSequence(ctx context.Context, table string) error {
+	const op = "BackupRepo.ResetSequence"
+
+	name := pgx.Identifier{table}.Sanitize()
+	query := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence($1, 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s`, name)
+	if _, err := p.db.Executor(ctx).ExecContext(ctx, query, name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = pgx.Identifier{name}.Sanitize()
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,111 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+)
+
+const (
+	_showBankRules = `SELECT r.id, r.counterparty, r.description, r.expense_item_id, e.name
+					  FROM "bank_rules" r
+					  JOIN "expense_items" e ON r.expense_item_id = e.id
+					  WHERE e.deleted_at IS NULL
+					  ORDER BY r.id
+					 `
+	_insertBankRule = `INSERT INTO "bank_rules" (counterparty, description, expense_item_id)
+					   VALUES ($1, $2, $3) RETURNING id`
+	_deleteBankRule        = `DELETE FROM "bank_rules" WHERE id = $1`
+	_showImportedPayments  = `SELECT key FROM "bank_payments" WHERE key = ANY($1)`
+	_insertImportedPayment = `INSERT INTO "bank_payments" (key, charge_id) VALUES ($1, $2)`
+)
+
+type BankProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewBankProvider(db *dataprovider.Provider) *BankProvider {
+	return &BankProvider{db: db}
+}
+
+// ShowBankRules returns rules in order they're applied in. Rules of deleted expense items are left
+// out.
+func (p *BankProvider) ShowBankRules(ctx context.Context) ([]*dto.BankRuleData, error) {
+	const op = "BankRepo.ShowBankRules"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showBankRules)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rules []*dto.BankRuleData
+	for rows.Next() {
+		var rule dto.BankRuleData
+		if err = rows.Scan(&rule.Id, &rule.Counterparty, &rule.Description, &rule.ExpenseItemId,
+			&rule.ExpenseItem); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
+
+func (p *BankProvider) CreateBankRule(ctx context.Context, rule *dto.BankRuleData) (int, error) {
+	const op = "BankRepo.CreateBankRule"
+
+	var id int
+	err := p.db.Executor(ctx).GetContext(ctx, &id, _insertBankRule, rule.Counterparty, rule.Description,
+		rule.ExpenseItemId)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (p *BankProvider) DeleteBankRule(ctx context.Context, id int) error {
+	const op = "BankRepo.DeleteBankRule"
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deleteBankRule, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checkAffected(op, res)
+}
+
+// ShowImportedPayments returns those of keys which payments were imported with.
+func (p *BankProvider) ShowImportedPayments(ctx context.Context, keys []string) ([]string, error) {
+	const op = "BankRepo.ShowImportedPayments"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showImportedPayments, keys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var imported []string
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		imported = append(imported, key)
+	}
+
+	return imported, nil
+}
+
+// SaveImportedPayment records that payment with key was imported as charge.
+func (p *BankProvider) SaveImportedPayment(ctx context.Context, key string, chargeId int) error {
+	const op = "BankRepo.SaveImportedPayment"
+
+	if _, err := p.db.Executor(ctx).ExecContext(ctx, _insertImportedPayment, key, chargeId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	_showExchangeIds = `SELECT table_name, external_id, record_id
+						FROM "exchange_ids"
+						WHERE table_name = $1
+					   `
+	_deleteExchangeId = `DELETE FROM "exchange_ids"
+						 WHERE table_name = $1 AND (external_id = $2 OR record_id = $3)`
+	_saveExchangeId = `INSERT INTO "exchange_ids" (table_name, external_id, record_id)
+					   VALUES ($1, $2, $3)`
+	_showExchangeSales = `SELECT id, amount, quantity, to_char(sale_date, 'YYYY-MM-DD HH24:MI:SS'), warehouses_id,
+							  COALESCE(customer, ''), version
+						  FROM sales
+						  WHERE deleted_at IS NULL AND sale_date >= $1 AND sale_date < $2
+						  ORDER BY sale_date, id
+						 `
+)
+
+type ExchangeProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewExchangeProvider(db *dataprovider.Provider) *ExchangeProvider {
+	return &ExchangeProvider{db: db}
+}
+
+// ShowExchangeIds returns ids of 1C objects mapped to records of table.
+func (p *ExchangeProvider) ShowExchangeIds(ctx context.Context, table string) ([]*dto.ExchangeIdData, error) {
+	const op = "ExchangeRepo.ShowExchangeIds"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showExchangeIds, table)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []*dto.ExchangeIdData
+	for rows.Next() {
+		var id dto.ExchangeIdData
+		if err = rows.Scan(&id.Table, &id.ExternalId, &id.RecordId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, &id)
+	}
+
+	return ids, nil
+}
+
+// SaveExchangeId maps id of 1C object to record. Previous mappings of the id and of the record are
+// replaced, so it must be called in transaction.
+func (p *ExchangeProvider) SaveExchangeId(ctx context.Context, id *dto.ExchangeIdData) error {
+	const op = "ExchangeRepo.SaveExchangeId"
+
+	executor := p.db.Executor(ctx)
+	if _, err := executor.ExecContext(ctx, _deleteExchangeId, id.Table, id.ExternalId, id.RecordId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := executor.ExecContext(ctx, _saveExchangeId, id.Table, id.ExternalId, id.RecordId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShowSales returns sales made from from till to in order of their dates. Dates are formatted as
+// YYYY-MM-DD HH:MM:SS.
+func (p *ExchangeProvider) ShowSales(ctx context.Context, from, to time.Time) ([]*dto.SalesData, error) {
+	const op = "ExchangeRepo.ShowSales"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showExchangeSales, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var sales []*dto.SalesData
+	for rows.Next() {
+		var sale dto.SalesData
+		if err = rows.Scan(&sale.Id, &sale.Amount, &sale.Quantity, &sale.SaleDate, &sale.WarehousesId,
+			&sale.Customer, &sale.Version); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sales = append(sales, &sale)
+	}
+
+	return sales, nil
+}
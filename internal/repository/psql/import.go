@@ -0,0 +1,178 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	"automatedShop/internal/services/dto"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"strings"
+	"time"
+)
+
+const (
+	// Temporary table rows are copied to, it has columns of the table rows are loaded to
+	_createImportRows = `CREATE TEMP TABLE import_rows ON COMMIT DROP AS SELECT %[2]s FROM %[1]s WITH NO DATA`
+	// Rows are moved from temporary table and each of them is recorded in audit log as created
+	_moveImportRows = `WITH inserted AS (
+						   INSERT INTO %[1]s AS r (%[2]s)
+						   SELECT %[2]s FROM import_rows
+						   RETURNING r.id, to_jsonb(r) - 'deleted_at' AS after_data
+					   )
+					   INSERT INTO "audit_log" (user_login, table_name, record_id, action, after_data)
+					   SELECT $1, $2, id, $3, after_data FROM inserted
+					  `
+)
+
+// _jsonTime formats timestamp like database/sql does when it's scanned into string, in RFC 3339
+// with fractional seconds only when there are any.
+const _jsonTime = `to_char(%[1]s, 'YYYY-MM-DD"T"HH24:MI:SS') || rtrim(rtrim(to_char(%[1]s, '.US'), '0'), '.') || 'Z'`
+
+// _importAuditStates are audit states of imported records, they have the same fields and formats as
+// JSON of dto read by ShopProvider, which ShopService records in audit log.
+var _importAuditStates = map[string]string{
+	dto.WarehousesTable: `jsonb_build_object('id', r.id, 'name', r.name, 'quantity', r.quantity, 'amount', r.amount,
+						  'cost', r.cost, 'category', COALESCE(r.category, ''), 'location', COALESCE(r.location, ''),
+						  'version', r.version)`,
+	dto.ExpenseItemsTable: `jsonb_build_object('id', r.id, 'name', r.name, 'version', r.version)`,
+	dto.ChargesTable: `jsonb_build_object('id', r.id, 'amount', r.amount,
+					   'charge_date', ` + fmt.Sprintf(_jsonTime, "r.charge_date") + `,
+					   'expense_item_id', r.expense_item_id, 'version', r.version)`,
+	dto.SalesTable: `jsonb_build_object('id', r.id, 'amount', r.amount, 'quantity', r.quantity,
+					 'sale_date', ` + fmt.Sprintf(_jsonTime, "r.sale_date") + `,
+					 'warehouses_id', r.warehouses_id, 'customer', r.customer, 'version', r.version)`,
+}
+
+// ImportProvider loads large imports with COPY. Rows are copied to temporary table, then moved to
+// the table with audit record of each of them in one transaction, so load is done entirely or not
+// at all. COPY runs on a connection of its own and can't join transaction of
+// dataprovider.Provider.RunInTx.
+type ImportProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewImportProvider(db *dataprovider.Provider) *ImportProvider {
+	return &ImportProvider{db: db}
+}
+
+func (p *ImportProvider) CopyWarehouses(ctx context.Context, items []*dto.WarehousesData, userLogin string) (int64, error) {
+	const op = "ImportRepo.CopyWarehouses"
+
+	rows := make([][]any, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []any{item.Name, item.Quantity, item.Amount, item.Cost, nullIfEmpty(item.Category),
+			nullIfEmpty(item.Location)})
+	}
+
+	n, err := p.copyFrom(ctx, userLogin, dto.WarehousesTable, []string{"name", "quantity", "amount", "cost", "category", "location"}, rows)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+func (p *ImportProvider) CopyExpenseItems(ctx context.Context, names []string, userLogin string) (int64, error) {
+	const op = "ImportRepo.CopyExpenseItems"
+
+	rows := make([][]any, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []any{name})
+	}
+
+	n, err := p.copyFrom(ctx, userLogin, dto.ExpenseItemsTable, []string{"name"}, rows)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// CopyCharges loads charges, their dates must be in dto.TimeLayout.
+func (p *ImportProvider) CopyCharges(ctx context.Context, charges []*dto.ChargesData, userLogin string) (int64, error) {
+	const op = "ImportRepo.CopyCharges"
+
+	rows := make([][]any, 0, len(charges))
+	for _, charge := range charges {
+		date, err := time.ParseInLocation(dto.TimeLayout, charge.ChargeDate, time.Local)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		rows = append(rows, []any{charge.Amount, date, charge.ExpenseItemId})
+	}
+
+	n, err := p.copyFrom(ctx, userLogin, dto.ChargesTable, []string{"amount", "charge_date", "expense_item_id"}, rows)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// CopySales loads sales, their dates must be in dto.TimeLayout.
+func (p *ImportProvider) CopySales(ctx context.Context, sales []*dto.SalesData, userLogin string) (int64, error) {
+	const op = "ImportRepo.CopySales"
+
+	rows := make([][]any, 0, len(sales))
+	for _, sale := range sales {
+		date, err := time.ParseInLocation(dto.TimeLayout, sale.SaleDate, time.Local)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		rows = append(rows, []any{sale.Amount, sale.Quantity, date, sale.WarehousesId, sale.Customer})
+	}
+
+	n, err := p.copyFrom(ctx, userLogin, dto.SalesTable, []string{"amount", "quantity", "sale_date", "warehouses_id", "customer"}, rows)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+// copyFrom copies rows to columns of table through connection of pgx driver. Created records are
+// recorded in audit log on behalf of userLogin.
+func (p *ImportProvider) copyFrom(ctx context.Context, userLogin, table string, columns []string,
+	rows [][]any) (int64, error) {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func(conn *sql.Conn) { _ = conn.Close() }(conn)
+
+	identifier := pgx.Identifier{table}.Sanitize()
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, pgx.Identifier{column}.Sanitize())
+	}
+	list := strings.Join(names, ", ")
+
+	var n int64
+	err = conn.Raw(func(driverConn any) error {
+		return pgx.BeginFunc(ctx, driverConn.(*stdlib.Conn).Conn(), func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, fmt.Sprintf(_createImportRows, identifier, list)); err != nil {
+				return err
+			}
+			if _, err := tx.CopyFrom(ctx, pgx.Identifier{"import_rows"}, columns, pgx.CopyFromRows(rows)); err != nil {
+				return err
+			}
+
+			tag, err := tx.Exec(ctx, fmt.Sprintf(_moveImportRows, identifier, list, _importAuditStates[table]),
+				userLogin, table, dto.AuditCreate)
+			n = tag.RowsAffected()
+			return err
+		})
+	})
+
+	return n, err
+}
+
+func nullIfEmpty(text string) any {
+	if text == "" {
+		return nil
+	}
+
+	return text
+}
@@ -0,0 +1,46 @@
+package psql
+
+import (
+	"automatedShop/internal/services/dto"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+var auditStateKey = regexp.MustCompile(`'([a-z_]+)', `)
+
+func TestImportAuditStatesHaveFieldsOfDto(t *testing.T) {
+	records := map[string]any{
+		dto.WarehousesTable:   dto.WarehousesData{},
+		dto.ExpenseItemsTable: dto.ExpenseItemsData{},
+		dto.ChargesTable:      dto.ChargesData{},
+		dto.SalesTable:        dto.SalesData{},
+	}
+
+	for table, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]any
+		if err = json.Unmarshal(data, &fields); err != nil {
+			t.Fatal(err)
+		}
+		var want []string
+		for field := range fields {
+			want = append(want, field)
+		}
+		slices.Sort(want)
+
+		var got []string
+		for _, match := range auditStateKey.FindAllStringSubmatch(_importAuditStates[table], -1) {
+			got = append(got, match[1])
+		}
+		slices.Sort(got)
+
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: audit state has fields %v, dto has %v", table, got, want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Sales and charges made in [$1, $2) as facts of pivot reports. Dimensions a record doesn't have
+	// are NULL. Dimension, measure and filter expressions are substituted from _pivotDimensions,
+	// _pivotMeasures and _pivotOperators only, values of filters are passed as parameters.
+	_pivotFacts = `WITH facts AS (
+						SELECT s.sale_date            AS fact_date,
+							   w.name                 AS product,
+							   w.category             AS category,
+							   w.location             AS location,
+							   s.customer             AS customer,
+							   NULL::varchar          AS expense_item,
+							   s.quantity * s.amount  AS revenue,
+							   s.quantity             AS quantity,
+							   0                      AS charge_amount
+						FROM sales s
+						JOIN warehouses w ON s.warehouses_id = w.id
+						WHERE s.sale_date >= $1 AND s.sale_date < $2
+						  AND s.deleted_at IS NULL
+						UNION ALL
+						SELECT c.charge_date, NULL, NULL, NULL, NULL, e.name, 0, 0, c.amount
+						FROM charges c
+						JOIN expense_items e ON c.expense_item_id = e.id
+						WHERE c.charge_date >= $1 AND c.charge_date < $2
+						  AND c.deleted_at IS NULL
+				   )
+				   `
+
+	_savePivotDefinition = `INSERT INTO "pivot_definitions" (name, definition, user_login)
+							VALUES ($1, $2, $3)
+							RETURNING id`
+	_showPivotDefinitions = `SELECT id, name, definition, user_login, to_char(created_at, 'YYYY-MM-DD HH24:MI:SS')
+							 FROM "pivot_definitions"
+							 WHERE NULLIF($1, '') IS NULL OR user_login = $1
+							 ORDER BY name, id
+							`
+	_deletePivotDefinition = `DELETE FROM "pivot_definitions"
+							  WHERE id = $1 AND (NULLIF($2, '') IS NULL OR user_login = $2)`
+)
+
+var (
+	_pivotDimensions = map[string]string{
+		dto.DimensionProduct:     `COALESCE(NULLIF(product, ''), '(no product)')`,
+		dto.DimensionCategory:    `COALESCE(NULLIF(category, ''), '(no category)')`,
+		dto.DimensionLocation:    `COALESCE(NULLIF(location, ''), '(no location)')`,
+		dto.DimensionCustomer:    `COALESCE(NULLIF(customer, ''), '(no customer)')`,
+		dto.DimensionExpenseItem: `COALESCE(expense_item, '(no expense item)')`,
+		dto.DimensionDay:         `to_char(fact_date, 'YYYY-MM-DD')`,
+		dto.DimensionWeek:        `to_char(date_trunc('week', fact_date), 'YYYY-MM-DD')`,
+		dto.DimensionMonth:       `to_char(fact_date, 'YYYY-MM')`,
+	}
+	_pivotMeasures = map[string]string{
+		dto.MeasureRevenue:      `COALESCE(SUM(revenue), 0)`,
+		dto.MeasureQuantity:     `COALESCE(SUM(quantity), 0)`,
+		dto.MeasureChargeAmount: `COALESCE(SUM(charge_amount), 0)`,
+		dto.MeasureCount:        `COUNT(*)`,
+	}
+	// %[1]s is dimension expression, %[2]s is placeholder of value
+	_pivotOperators = map[string]string{
+		dto.FilterEquals:    `%[1]s = %[2]s`,
+		dto.FilterNotEquals: `%[1]s <> %[2]s`,
+		dto.FilterContains:  `strpos(lower(%[1]s), lower(%[2]s)) > 0`,
+	}
+)
+
+type PivotProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewPivotProvider(db *dataprovider.Provider) *PivotProvider {
+	return &PivotProvider{db: db}
+}
+
+// GetPivotData returns measures of sales and charges made in period grouped by row dimensions and
+// column dimension of definition. Groups are ordered by their keys.
+func (p *PivotProvider) GetPivotData(ctx context.Context, params *dto.PivotParams) ([]*dto.PivotGroupData, error) {
+	const op = "PivotRepo.GetPivotData"
+
+	query, args, err := buildPivotQuery(&params.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	args = append([]any{params.Period.From, params.Period.To}, args...)
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	keyCount := len(params.Definition.Rows)
+	if params.Definition.Column != "" {
+		keyCount++
+	}
+
+	var groups []*dto.PivotGroupData
+	for rows.Next() {
+		group := &dto.PivotGroupData{
+			Keys:   make([]string, keyCount),
+			Values: make([]int64, len(params.Definition.Measures)),
+		}
+		dest := make([]any, 0, keyCount+len(group.Values))
+		for i := range group.Keys {
+			dest = append(dest, &group.Keys[i])
+		}
+		for i := range group.Values {
+			dest = append(dest, &group.Values[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// buildPivotQuery builds query of pivot definition and returns it with values of filters, they're
+// numbered from $3 as $1 and $2 are bounds of period.
+func buildPivotQuery(definition *dto.PivotDefinition) (string, []any, error) {
+	dimensions := definition.Rows
+	if definition.Column != "" {
+		dimensions = append(dimensions[:len(dimensions):len(dimensions)], definition.Column)
+	}
+
+	selects := make([]string, 0, len(dimensions)+len(definition.Measures))
+	positions := make([]string, 0, len(dimensions))
+	for i, dimension := range dimensions {
+		expr, ok := _pivotDimensions[dimension]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: dimension %s", customErr.ErrInvalidReportParams, dimension)
+		}
+		selects = append(selects, expr)
+		positions = append(positions, strconv.Itoa(i+1))
+	}
+	for _, measure := range definition.Measures {
+		expr, ok := _pivotMeasures[measure]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: measure %s", customErr.ErrInvalidReportParams, measure)
+		}
+		selects = append(selects, expr)
+	}
+
+	conditions := make([]string, 0, len(definition.Filters))
+	args := make([]any, 0, len(definition.Filters))
+	for _, filter := range definition.Filters {
+		expr, ok := _pivotDimensions[filter.Dimension]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: filter by %s", customErr.ErrInvalidReportParams, filter.Dimension)
+		}
+		operator, ok := _pivotOperators[filter.Operator]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: filter operator %s", customErr.ErrInvalidReportParams, filter.Operator)
+		}
+		args = append(args, filter.Value)
+		conditions = append(conditions, fmt.Sprintf(operator, expr, "$"+strconv.Itoa(len(args)+2)))
+	}
+
+	var query strings.Builder
+	query.WriteString(_pivotFacts)
+	query.WriteString("SELECT " + strings.Join(selects, ", ") + " FROM facts")
+	if len(conditions) > 0 {
+		query.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+	if len(positions) > 0 {
+		query.WriteString(" GROUP BY " + strings.Join(positions, ", "))
+		query.WriteString(" ORDER BY " + strings.Join(positions, ", "))
+	}
+
+	return query.String(), args, nil
+}
+
+// SavePivotDefinition stores named pivot definition and returns its id.
+func (p *PivotProvider) SavePivotDefinition(ctx context.Context, saved *dto.SavedPivotData) (int, error) {
+	const op = "PivotRepo.SavePivotDefinition"
+
+	definition, err := json.Marshal(saved.Definition)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = p.db.Executor(ctx).GetContext(ctx, &id, _savePivotDefinition, saved.Name, definition, saved.UserLogin)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ShowPivotDefinitions returns saved pivot definitions ordered by name. Definitions are filtered by
+// author unless login is empty.
+func (p *PivotProvider) ShowPivotDefinitions(ctx context.Context, login string) ([]*dto.SavedPivotData, error) {
+	const op = "PivotRepo.ShowPivotDefinitions"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showPivotDefinitions, login)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var definitions []*dto.SavedPivotData
+	for rows.Next() {
+		var (
+			saved      dto.SavedPivotData
+			definition []byte
+		)
+		if err = rows.Scan(&saved.Id, &saved.Name, &definition, &saved.UserLogin, &saved.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err = json.Unmarshal(definition, &saved.Definition); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		definitions = append(definitions, &saved)
+	}
+
+	return definitions, nil
+}
+
+// DeletePivotDefinition removes saved pivot definition. Definitions of other users are not removed
+// unless login is empty.
+func (p *PivotProvider) DeletePivotDefinition(ctx context.Context, id int, login string) error {
+	const op = "PivotRepo.DeletePivotDefinition"
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deletePivotDefinition, id, login)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+	}
+
+	return nil
+}
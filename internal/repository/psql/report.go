@@ -0,0 +1,290 @@
+package psql
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Report queries read daily aggregates sales_daily and charges_daily kept by triggers instead of
+// scanning sales and charges, so bounds of their periods must be starts of days. Only the heatmap
+// needs hours of sales and reads sales.
+const (
+	// Profit
+	_countPeriodSales = `SELECT COALESCE(SUM(sd.revenue), 0)                                 AS revenue,
+							   COALESCE(SUM(sd.units * w.cost), 0)                          AS cogs,
+							   COALESCE(SUM(sd.revenue) FILTER (WHERE w.cost IS NULL), 0) AS revenue_without_cost
+						FROM sales_daily sd
+						JOIN warehouses w ON sd.warehouses_id = w.id
+						WHERE sd.day >= $1 AND sd.day < $2
+						`
+	_countPeriodCharges = `SELECT e.id, e.name, SUM(cd.amount) AS total
+						   FROM charges_daily cd
+						   JOIN expense_items e ON cd.expense_item_id = e.id
+						   WHERE cd.day >= $1 AND cd.day < $2
+						   GROUP BY e.id, e.name
+						   ORDER BY total DESC
+						  `
+
+	// Ranking of items. Name, group and metric expressions are substituted from _rankingGroups and
+	// _rankingMetrics only. Items without sales in period take part in ranking with zero metrics.
+	_rankItems = `SELECT %[1]s AS name,
+						 COALESCE(SUM(sd.revenue), 0)                         AS revenue,
+						 COALESCE(SUM(sd.units), 0)                           AS units,
+						 COALESCE(SUM(sd.revenue - sd.units * w.cost), 0)     AS margin,
+						 COALESCE(SUM(sd.sales_count), 0)                     AS sales_count,
+						 %[2]s                                                AS value
+				  FROM warehouses w
+				  LEFT JOIN sales_daily sd ON sd.warehouses_id = w.id
+					   AND sd.day >= $1 AND sd.day < $2
+				  WHERE w.deleted_at IS NULL OR sd.day IS NOT NULL
+				  GROUP BY %[4]s
+				  ORDER BY value %[3]s, name
+				  LIMIT $3
+				 `
+
+	// Sales of each item sold in period
+	_countProductSales = `SELECT w.id, w.name, SUM(sd.revenue) AS revenue, SUM(sd.units) AS units
+						  FROM sales_daily sd
+						  JOIN warehouses w ON sd.warehouses_id = w.id
+						  WHERE sd.day >= $1 AND sd.day < $2
+						  GROUP BY w.id, w.name
+						  ORDER BY revenue DESC, w.name, w.id
+						 `
+
+	// Daily sales. Days are returned as text, so wall-clock dates of sale_date are kept as is.
+	_countDailySales = `SELECT to_char(day, 'YYYY-MM-DD') AS day,
+							   SUM(revenue)               AS revenue,
+							   SUM(units)                 AS units,
+							   SUM(sales_count)           AS sales_count
+						FROM sales_daily
+						WHERE sales_daily.day >= $1 AND sales_daily.day < $2
+						GROUP BY sales_daily.day
+						ORDER BY sales_daily.day
+					   `
+
+	// Sales by ISO weekday (1 is Monday) and hour of sale_date
+	_countHourlySales = `SELECT EXTRACT(ISODOW FROM sale_date)::int AS weekday,
+								EXTRACT(HOUR FROM sale_date)::int   AS hour,
+								SUM(quantity * amount)              AS revenue,
+								COUNT(*)                            AS sales_count
+						 FROM sales
+						 WHERE sale_date >= $1 AND sale_date < $2
+						   AND deleted_at IS NULL
+						 GROUP BY weekday, hour
+						`
+
+	// Daily sales of each item
+	_countItemDailySales = `SELECT warehouses_id, to_char(day, 'YYYY-MM-DD'), revenue, units
+							FROM sales_daily
+							WHERE day >= $1 AND day < $2
+							ORDER BY warehouses_id, day
+						   `
+
+	// Stock of each item with its sales in period and the last sale ever
+	_countItemStockSales = `SELECT w.id, w.name, w.quantity, w.cost,
+								   COALESCE(SUM(sd.units) FILTER (WHERE sd.day >= $1 AND sd.day < $2), 0) AS units,
+								   COALESCE(to_char(MAX(sd.day), 'YYYY-MM-DD'), '')                      AS last_sale
+							FROM warehouses w
+							LEFT JOIN sales_daily sd ON sd.warehouses_id = w.id
+							WHERE w.deleted_at IS NULL
+							GROUP BY w.id, w.name, w.quantity, w.cost
+							ORDER BY w.id
+						   `
+)
+
+var (
+	// Groups are named by name expression and grouped by group expression, items which have the same
+	// name are different products
+	_rankingGroups = map[string]struct{ name, group string }{
+		dto.GroupByProduct:  {`w.name`, `w.id, w.name`},
+		dto.GroupByCategory: {`COALESCE(w.category, '(no category)')`, `COALESCE(w.category, '(no category)')`},
+		dto.GroupByLocation: {`COALESCE(w.location, '(no location)')`, `COALESCE(w.location, '(no location)')`},
+	}
+	_rankingMetrics = map[string]string{
+		dto.MetricRevenue: `COALESCE(SUM(sd.revenue), 0)`,
+		dto.MetricUnits:   `COALESCE(SUM(sd.units), 0)`,
+		dto.MetricMargin:  `COALESCE(SUM(sd.revenue - sd.units * w.cost), 0)`,
+		dto.MetricSales:   `COALESCE(SUM(sd.sales_count), 0)`,
+	}
+)
+
+// GetProfitReport counts revenue, cost of goods sold and charges by expense items for sales and charges
+// made in [from, to). Periods without sales or charges give zero sums.
+func (p *ShopProvider) GetProfitReport(ctx context.Context, from, to time.Time) (*dto.ProfitReportData, error) {
+	const op = "ShopRepo.GetProfitReport"
+
+	var report dto.ProfitReportData
+
+	row := p.db.Executor(ctx).QueryRowxContext(ctx, _countPeriodSales, from, to)
+	if err := row.Scan(&report.Revenue, &report.Cogs, &report.RevenueWithoutCost); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _countPeriodCharges, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expense dto.ExpenseTotalData
+		if err = rows.Scan(&expense.Id, &expense.Name, &expense.Total); err != nil {
+			return nil, err
+		}
+		report.Expenses = append(report.Expenses, &expense)
+	}
+
+	return &report, nil
+}
+
+// GetRankingReport returns items grouped and ranked by chosen metric for sales made in given period.
+// Margin counts only sales of items with known cost.
+func (p *ShopProvider) GetRankingReport(ctx context.Context, params *dto.RankingParams) ([]*dto.RankingItemData, error) {
+	const op = "ShopRepo.GetRankingReport"
+
+	group, ok := _rankingGroups[params.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: group by %s", op, customErr.ErrInvalidReportParams, params.GroupBy)
+	}
+	metric, ok := _rankingMetrics[params.Metric]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: metric %s", op, customErr.ErrInvalidReportParams, params.Metric)
+	}
+	order := "DESC"
+	if params.Worst {
+		order = "ASC"
+	}
+
+	query := fmt.Sprintf(_rankItems, group.name, metric, order, group.group)
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, query, params.Period.From, params.Period.To, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var items []*dto.RankingItemData
+	for rows.Next() {
+		var item dto.RankingItemData
+		if err = rows.Scan(&item.Name, &item.Revenue, &item.Units, &item.Margin, &item.SalesCount,
+			&item.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// GetProductSales returns sales totals of every item sold in [from, to), deleted items included.
+func (p *ShopProvider) GetProductSales(ctx context.Context, from, to time.Time) ([]*dto.ProductSalesData, error) {
+	const op = "ShopRepo.GetProductSales"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _countProductSales, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var products []*dto.ProductSalesData
+	for rows.Next() {
+		var product dto.ProductSalesData
+		if err = rows.Scan(&product.Id, &product.Name, &product.Revenue, &product.Units); err != nil {
+			return nil, err
+		}
+		products = append(products, &product)
+	}
+
+	return products, nil
+}
+
+// GetDailySales returns sales totals of each day of [from, to) which has sales.
+func (p *ShopProvider) GetDailySales(ctx context.Context, from, to time.Time) ([]*dto.DailySalesData, error) {
+	const op = "ShopRepo.GetDailySales"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _countDailySales, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var days []*dto.DailySalesData
+	for rows.Next() {
+		var day dto.DailySalesData
+		if err = rows.Scan(&day.Day, &day.Revenue, &day.Units, &day.SalesCount); err != nil {
+			return nil, err
+		}
+		days = append(days, &day)
+	}
+
+	return days, nil
+}
+
+// GetItemDailySales returns sales totals of each item for each day of [from, to) when it was sold.
+func (p *ShopProvider) GetItemDailySales(ctx context.Context, from, to time.Time) ([]*dto.ItemDailySalesData, error) {
+	const op = "ShopRepo.GetItemDailySales"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _countItemDailySales, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var days []*dto.ItemDailySalesData
+	for rows.Next() {
+		var day dto.ItemDailySalesData
+		if err = rows.Scan(&day.WarehousesId, &day.Day, &day.Revenue, &day.Units); err != nil {
+			return nil, err
+		}
+		days = append(days, &day)
+	}
+
+	return days, nil
+}
+
+// GetItemStockSales returns stock of each warehouses item with units sold in [from, to) and the day of its last sale.
+func (p *ShopProvider) GetItemStockSales(ctx context.Context, from, to time.Time) ([]*dto.ItemStockSalesData, error) {
+	const op = "ShopRepo.GetItemStockSales"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _countItemStockSales, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var items []*dto.ItemStockSalesData
+	for rows.Next() {
+		var item dto.ItemStockSalesData
+		if err = rows.Scan(&item.Id, &item.Name, &item.Quantity, &item.Cost, &item.Units, &item.LastSale); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// GetHourlySales returns sales totals of [from, to) grouped by weekday and hour of sale.
+func (p *ShopProvider) GetHourlySales(ctx context.Context, from, to time.Time) ([]*dto.HourlySalesData, error) {
+	const op = "ShopRepo.GetHourlySales"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _countHourlySales, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var hours []*dto.HourlySalesData
+	for rows.Next() {
+		var hour dto.HourlySalesData
+		if err = rows.Scan(&hour.Weekday, &hour.Hour, &hour.Revenue, &hour.SalesCount); err != nil {
+			return nil, err
+		}
+		hours = append(hours, &hour)
+	}
+
+	return hours, nil
+}
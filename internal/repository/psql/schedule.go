@@ -0,0 +1,196 @@
+package psql
+
+import (
+	"automatedShop/internal/dataprovider"
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	_createSchedule = `INSERT INTO "report_schedules" (name, report_type, params, format, destination, frequency,
+														day, hour, next_run_at, created_by)
+					   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+					   RETURNING id`
+	_showSchedules = `SELECT id, name, report_type, params, format, destination, frequency, day, hour,
+							 to_char(next_run_at, 'YYYY-MM-DD HH24:MI:SS'), created_by
+					  FROM "report_schedules"
+					  WHERE next_run_at <= COALESCE($1::timestamp, 'infinity')
+					  ORDER BY next_run_at, id
+					 `
+	_deleteSchedule = `DELETE FROM "report_schedules" WHERE id = $1`
+	_claimSchedule  = `UPDATE "report_schedules"
+					   SET next_run_at = $3
+					   WHERE id = $1 AND next_run_at = $2`
+	_saveScheduleRun = `INSERT INTO "report_schedule_runs" (schedule_id, started_at, finished_at, status, file_path, error)
+						VALUES ($1, $2::timestamp, $3::timestamp, $4, $5, $6)`
+	_showScheduleRuns = `SELECT r.id, r.schedule_id, s.name,
+								to_char(r.started_at, 'YYYY-MM-DD HH24:MI:SS'),
+								to_char(r.finished_at, 'YYYY-MM-DD HH24:MI:SS'),
+								r.status, r.file_path, r.error
+						 FROM "report_schedule_runs" r
+								  JOIN "report_schedules" s ON s.id = r.schedule_id
+						 WHERE $1 = 0 OR r.schedule_id = $1
+						 ORDER BY r.started_at DESC, r.id DESC
+						 LIMIT 1000
+						`
+)
+
+// scheduleTimeLayout is format of to_char(..., 'YYYY-MM-DD HH24:MI:SS').
+const scheduleTimeLayout = "2006-01-02 15:04:05"
+
+type ScheduleProvider struct {
+	db *dataprovider.Provider
+}
+
+func NewScheduleProvider(db *dataprovider.Provider) *ScheduleProvider {
+	return &ScheduleProvider{db: db}
+}
+
+// CreateSchedule stores schedule and returns its id.
+func (p *ScheduleProvider) CreateSchedule(ctx context.Context, schedule *dto.ScheduleData) (int, error) {
+	const op = "ScheduleRepo.CreateSchedule"
+
+	params, err := json.Marshal(schedule.Params)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = p.db.Executor(ctx).GetContext(ctx, &id, _createSchedule, schedule.Name, schedule.ReportType, params,
+		schedule.Format, schedule.Destination, schedule.Frequency, schedule.Day, schedule.Hour,
+		schedule.NextRunAt, schedule.CreatedBy)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ShowSchedules returns all schedules ordered by time of their next run.
+func (p *ScheduleProvider) ShowSchedules(ctx context.Context) ([]*dto.ScheduleData, error) {
+	const op = "ScheduleRepo.ShowSchedules"
+
+	res, err := p.selectSchedules(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// GetDueSchedules returns schedules whose next run is not later than now.
+func (p *ScheduleProvider) GetDueSchedules(ctx context.Context, now time.Time) ([]*dto.ScheduleData, error) {
+	const op = "ScheduleRepo.GetDueSchedules"
+
+	res, err := p.selectSchedules(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (p *ScheduleProvider) selectSchedules(ctx context.Context, until any) ([]*dto.ScheduleData, error) {
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showSchedules, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*dto.ScheduleData
+	for rows.Next() {
+		var (
+			schedule  dto.ScheduleData
+			params    []byte
+			nextRunAt string
+		)
+		if err = rows.Scan(&schedule.Id, &schedule.Name, &schedule.ReportType, &params, &schedule.Format,
+			&schedule.Destination, &schedule.Frequency, &schedule.Day, &schedule.Hour, &nextRunAt,
+			&schedule.CreatedBy); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(params, &schedule.Params); err != nil {
+			return nil, err
+		}
+		// next_run_at is stored without time zone in shop's local time
+		if schedule.NextRunAt, err = time.ParseInLocation(scheduleTimeLayout, nextRunAt, time.Local); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, &schedule)
+	}
+
+	return schedules, rows.Err()
+}
+
+// DeleteSchedule removes schedule together with its run log.
+func (p *ScheduleProvider) DeleteSchedule(ctx context.Context, id int) error {
+	const op = "ScheduleRepo.DeleteSchedule"
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deleteSchedule, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// ClaimSchedule moves next run of schedule from due to next. It reports false when another
+// scheduler has already claimed the run, so every run is performed only once.
+func (p *ScheduleProvider) ClaimSchedule(ctx context.Context, id int, due, next time.Time) (bool, error) {
+	const op = "ScheduleRepo.ClaimSchedule"
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _claimSchedule, id, due, next)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n == 1, nil
+}
+
+// SaveScheduleRun adds record to run log of schedule.
+func (p *ScheduleProvider) SaveScheduleRun(ctx context.Context, run *dto.ScheduleRunData) error {
+	const op = "ScheduleRepo.SaveScheduleRun"
+
+	_, err := p.db.Executor(ctx).ExecContext(ctx, _saveScheduleRun, run.ScheduleId, run.StartedAt, run.FinishedAt,
+		run.Status, run.FilePath, run.Error)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShowScheduleRuns returns last 1000 runs, newest first. Runs of all schedules are returned when
+// id is 0.
+func (p *ScheduleProvider) ShowScheduleRuns(ctx context.Context, id int) ([]*dto.ScheduleRunData, error) {
+	const op = "ScheduleRepo.ShowScheduleRuns"
+
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showScheduleRuns, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var runs []*dto.ScheduleRunData
+	for rows.Next() {
+		var run dto.ScheduleRunData
+		if err = rows.Scan(&run.Id, &run.ScheduleId, &run.ScheduleName, &run.StartedAt, &run.FinishedAt,
+			&run.Status, &run.FilePath, &run.Error); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		runs = append(runs, &run)
+	}
+
+	return runs, nil
+}
@@ -2,71 +2,108 @@ package psql
 
 import (
 	"automatedShop/internal/dataprovider"
+	customErr "automatedShop/internal/errors"
 	"automatedShop/internal/services/dto"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// _foreignKeyViolation is the postgres error code raised when a row is still referenced.
+const _foreignKeyViolation = "23503"
+
 const (
 	// Warehouses
-	_showWarehousesTable  = `SELECT id, name, quantity, amount FROM "warehouses"`
-	_insertWarehousesItem = `INSERT INTO "warehouses" (name, quantity, amount) VALUES ($1, $2, $3)`
+	_showWarehousesTable = `SELECT id, name, quantity, amount, cost, COALESCE(category, ''), COALESCE(location, ''), version
+							FROM "warehouses" WHERE deleted_at IS NULL`
+	_getWarehousesItem = `SELECT id, name, quantity, amount, cost, COALESCE(category, ''), COALESCE(location, ''), version
+						  FROM "warehouses" WHERE id = $1`
+	_insertWarehousesItem = `INSERT INTO "warehouses" (name, quantity, amount, cost, category, location)
+							 VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, '')) RETURNING id`
 	_updateWarehousesItem = `UPDATE "warehouses"
-							 SET name = $1, quantity = $2, amount = $3
-							 WHERE id = $4`
-	_deleteWarehousesItem = `DELETE FROM "warehouses" WHERE id = $1`
+							 SET name = $1, quantity = $2, amount = $3, cost = $4,
+							     category = NULLIF($5, ''), location = NULLIF($6, ''), version = version + 1
+							 WHERE id = $7 AND version = $8 AND deleted_at IS NULL`
+	_deleteWarehousesItem = `UPDATE "warehouses" SET deleted_at = now(), version = version + 1
+							 WHERE id = $1 AND deleted_at IS NULL`
 
 	// Expense Items
-	_showExpenseItemsTable = `SELECT id, name FROM "expense_items"`
-	_insertExpenseItem     = `INSERT INTO "expense_items" (name) VALUES ($1)`
+	_showExpenseItemsTable = `SELECT id, name, version FROM "expense_items" WHERE deleted_at IS NULL`
+	_getExpenseItem        = `SELECT id, name, version FROM "expense_items" WHERE id = $1`
+	_insertExpenseItem     = `INSERT INTO "expense_items" (name) VALUES ($1) RETURNING id`
 	_updateExpenseItem     = `UPDATE "expense_items"
-							  SET name = $1
-							  WHERE id = $2
+							  SET name = $1, version = version + 1
+							  WHERE id = $2 AND version = $3 AND deleted_at IS NULL
                              `
-	_deleteExpenseItem = `DELETE FROM "expense_items" WHERE id = $1`
+	_deleteExpenseItem = `UPDATE "expense_items" SET deleted_at = now(), version = version + 1
+						  WHERE id = $1 AND deleted_at IS NULL`
 
 	// Charges
-	_showChargesTable  = `SELECT id, amount, charge_date, expense_item_id FROM "charges"`
-	_insertChargesItem = `INSERT INTO "charges" (amount, charge_date, expense_item_id) VALUES ($1, $2, $3)`
+	_showChargesTable  = `SELECT id, amount, charge_date, expense_item_id, version FROM "charges" WHERE deleted_at IS NULL`
+	_getChargesItem    = `SELECT id, amount, charge_date, expense_item_id, version FROM "charges" WHERE id = $1`
+	_insertChargesItem = `INSERT INTO "charges" (amount, charge_date, expense_item_id) VALUES ($1, $2, $3) RETURNING id`
 	_updateChargesItem = `UPDATE "charges"
-                              SET amount = $1, charge_date = $2, expense_item_id = $3
-							  WHERE id = $4
+                              SET amount = $1, charge_date = $2, expense_item_id = $3, version = version + 1
+							  WHERE id = $4 AND version = $5 AND deleted_at IS NULL
                              `
-	_deleteChargesItem = `DELETE FROM "charges" WHERE id = $1`
+	_deleteChargesItem = `UPDATE "charges" SET deleted_at = now(), version = version + 1
+						  WHERE id = $1 AND deleted_at IS NULL`
 
 	// Sales
-	_showSalesTable  = `SELECT id, amount, quantity, sale_date, warehouses_id FROM "sales"`
-	_insertSalesItem = `INSERT INTO "sales" (amount, quantity, sale_date, warehouses_id) VALUES ($1, $2, $3, $4)`
+	_showSalesTable  = `SELECT id, amount, quantity, sale_date, warehouses_id, customer, version FROM "sales" WHERE deleted_at IS NULL`
+	_getSalesItem    = `SELECT id, amount, quantity, sale_date, warehouses_id, customer, version FROM "sales" WHERE id = $1`
+	_insertSalesItem = `INSERT INTO "sales" (amount, quantity, sale_date, warehouses_id, customer) VALUES ($1, $2, $3, $4, $5) RETURNING id`
 	_updateSalesItem = `UPDATE "sales"
-                              SET amount = $1, quantity = $2, sale_date = $3, warehouses_id = $4
-							  WHERE id = $5
+                              SET amount = $1, quantity = $2, sale_date = $3, warehouses_id = $4, customer = $5,
+                                  version = version + 1
+							  WHERE id = $6 AND version = $7 AND deleted_at IS NULL
                              `
-	_deleteSalesItem = `DELETE FROM "sales" WHERE id = $1`
-
-	// Profit
-	_countMonthProfit = `WITH sales_last_month AS (
-							SELECT SUM(quantity * amount) AS total_sales
-							FROM sales
-							WHERE sale_date >= CURRENT_DATE - INTERVAL '1 month'
-						),
-						charges_last_month AS (
- 							SELECT SUM(amount) AS total_charges
-							FROM charges
-							WHERE charge_date >= CURRENT_DATE - INTERVAL '1 month'
-						)
-						SELECT slm.total_sales - clm.total_charges AS profit
-						FROM sales_last_month slm, charges_last_month clm
-						`
-	// 5 best items
-	_showBestItems = `SELECT w.name AS warehouse_name, 
-       				 	 	SUM(s.quantity * s.amount) AS total_revenue
-					  	 	FROM sales s
-						 	JOIN warehouses w ON s.warehouses_id = w.id
-						 WHERE s.sale_date BETWEEN $1 AND $2 
-						 GROUP BY w.name
-						 ORDER BY total_revenue DESC
-						 LIMIT 5;
-                     `
+	_deleteSalesItem = `UPDATE "sales" SET deleted_at = now(), version = version + 1
+						WHERE id = $1 AND deleted_at IS NULL`
+
+	// _isRecordActive reports whether record exists and isn't deleted. Table name is substituted
+	// from the fixed set of table constants only.
+	_isRecordActive = `SELECT EXISTS (SELECT 1 FROM %q WHERE id = $1 AND deleted_at IS NULL)`
+
+	// Trash
+	_showTrash = `SELECT 'warehouses', id, name, to_char(deleted_at, 'YYYY-MM-DD HH24:MI:SS')
+				  FROM "warehouses" WHERE deleted_at IS NOT NULL
+				  UNION ALL
+				  SELECT 'expense_items', id, name, to_char(deleted_at, 'YYYY-MM-DD HH24:MI:SS')
+				  FROM "expense_items" WHERE deleted_at IS NOT NULL
+				  UNION ALL
+				  SELECT 'charges', id, 'amount ' || amount || ', expense item ' || expense_item_id,
+				         to_char(deleted_at, 'YYYY-MM-DD HH24:MI:SS')
+				  FROM "charges" WHERE deleted_at IS NOT NULL
+				  UNION ALL
+				  SELECT 'sales', id, 'amount ' || amount || ' x ' || quantity || ', warehouses item ' || warehouses_id,
+				         to_char(deleted_at, 'YYYY-MM-DD HH24:MI:SS')
+				  FROM "sales" WHERE deleted_at IS NOT NULL
+				  ORDER BY 4 DESC
+				 `
+)
+
+// _restoreTrashItem and _purgeTrashItem are keyed by table name, so only known tables
+// can ever reach the database.
+var (
+	_restoreTrashItem = map[string]string{
+		dto.WarehousesTable: `UPDATE "warehouses" SET deleted_at = NULL, version = version + 1
+							  WHERE id = $1 AND deleted_at IS NOT NULL`,
+		dto.ExpenseItemsTable: `UPDATE "expense_items" SET deleted_at = NULL, version = version + 1
+								WHERE id = $1 AND deleted_at IS NOT NULL`,
+		dto.ChargesTable: `UPDATE "charges" SET deleted_at = NULL, version = version + 1
+						   WHERE id = $1 AND deleted_at IS NOT NULL`,
+		dto.SalesTable: `UPDATE "sales" SET deleted_at = NULL, version = version + 1
+						 WHERE id = $1 AND deleted_at IS NOT NULL`,
+	}
+	_purgeTrashItem = map[string]string{
+		dto.WarehousesTable:   `DELETE FROM "warehouses" WHERE id = $1 AND deleted_at IS NOT NULL`,
+		dto.ExpenseItemsTable: `DELETE FROM "expense_items" WHERE id = $1 AND deleted_at IS NOT NULL`,
+		dto.ChargesTable:      `DELETE FROM "charges" WHERE id = $1 AND deleted_at IS NOT NULL`,
+		dto.SalesTable:        `DELETE FROM "sales" WHERE id = $1 AND deleted_at IS NOT NULL`,
+	}
 )
 
 type ShopProvider struct {
@@ -82,7 +119,7 @@ func NewShopProvider(db *dataprovider.Provider) *ShopProvider {
 func (p *ShopProvider) ShowWarehousesTable(ctx context.Context) ([]*dto.WarehousesData, error) {
 	const op = "ShopRepo.ShowWarehousesTable"
 
-	rows, err := p.db.QueryContext(ctx, _showWarehousesTable)
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showWarehousesTable)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -91,7 +128,8 @@ func (p *ShopProvider) ShowWarehousesTable(ctx context.Context) ([]*dto.Warehous
 	var warehouses []*dto.WarehousesData
 	for rows.Next() {
 		var warehouse dto.WarehousesData
-		if err = rows.Scan(&warehouse.Id, &warehouse.Name, &warehouse.Quantity, &warehouse.Amount); err != nil {
+		if err = rows.Scan(&warehouse.Id, &warehouse.Name, &warehouse.Quantity, &warehouse.Amount,
+			&warehouse.Cost, &warehouse.Category, &warehouse.Location, &warehouse.Version); err != nil {
 			return nil, err
 		}
 		warehouses = append(warehouses, &warehouse)
@@ -100,43 +138,67 @@ func (p *ShopProvider) ShowWarehousesTable(ctx context.Context) ([]*dto.Warehous
 	return warehouses, nil
 }
 
-func (p *ShopProvider) CreateWarehousesItem(ctx context.Context, name string, quantity, amount int) error {
+func (p *ShopProvider) GetWarehousesItem(ctx context.Context, id int) (*dto.WarehousesData, error) {
+	const op = "ShopRepo.GetWarehousesItem"
+
+	var item dto.WarehousesData
+
+	row := p.db.Executor(ctx).QueryRowxContext(ctx, _getWarehousesItem, id)
+	err := row.Scan(&item.Id, &item.Name, &item.Quantity, &item.Amount, &item.Cost, &item.Category,
+		&item.Location, &item.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &item, nil
+}
+
+func (p *ShopProvider) CreateWarehousesItem(ctx context.Context, data *dto.WarehousesData) (int, error) {
 	const op = "ShopRepo.CreateWarehousesItem"
 
-	_, err := p.db.ExecContext(ctx, _insertWarehousesItem, name, quantity, amount)
+	var id int
+
+	err := p.db.Executor(ctx).GetContext(ctx, &id, _insertWarehousesItem, data.Name, data.Quantity, data.Amount,
+		data.Cost, data.Category, data.Location)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return id, nil
 }
 
-func (p *ShopProvider) UpdateWarehousesItem(ctx context.Context, name string, quantity, amount, id int) error {
+// UpdateWarehousesItem updates record if it wasn't changed since data.Version was read.
+func (p *ShopProvider) UpdateWarehousesItem(ctx context.Context, data *dto.WarehousesData) error {
 	const op = "ShopRepo.UpdateWarehousesItem"
 
-	_, err := p.db.ExecContext(ctx, _updateWarehousesItem, name, quantity, amount, id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _updateWarehousesItem, data.Name, data.Quantity, data.Amount,
+		data.Cost, data.Category, data.Location, data.Id, data.Version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return p.checkUpdated(ctx, op, res, dto.WarehousesTable, data.Id)
 }
 
 func (p *ShopProvider) DeleteWarehousesItem(ctx context.Context, id int) error {
 	const op = "ShopRepo.DeleteWarehousesItem"
 
-	_, err := p.db.ExecContext(ctx, _deleteWarehousesItem, id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deleteWarehousesItem, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return checkAffected(op, res)
 }
 
 func (p *ShopProvider) ShowExpenseItemsTable(ctx context.Context) ([]*dto.ExpenseItemsData, error) {
 	const op = "ShopRepo.ShowExpenseItemsTable"
 
-	rows, err := p.db.QueryContext(ctx, _showExpenseItemsTable)
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showExpenseItemsTable)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -145,7 +207,7 @@ func (p *ShopProvider) ShowExpenseItemsTable(ctx context.Context) ([]*dto.Expens
 	var exItems []*dto.ExpenseItemsData
 	for rows.Next() {
 		var exItem dto.ExpenseItemsData
-		if err = rows.Scan(&exItem.Id, &exItem.Name); err != nil {
+		if err = rows.Scan(&exItem.Id, &exItem.Name, &exItem.Version); err != nil {
 			return nil, err
 		}
 		exItems = append(exItems, &exItem)
@@ -154,44 +216,65 @@ func (p *ShopProvider) ShowExpenseItemsTable(ctx context.Context) ([]*dto.Expens
 	return exItems, nil
 }
 
-func (p *ShopProvider) CreateExpenseItem(ctx context.Context, name string) error {
+func (p *ShopProvider) GetExpenseItem(ctx context.Context, id int) (*dto.ExpenseItemsData, error) {
+	const op = "ShopRepo.GetExpenseItem"
+
+	var item dto.ExpenseItemsData
+
+	row := p.db.Executor(ctx).QueryRowxContext(ctx, _getExpenseItem, id)
+	err := row.Scan(&item.Id, &item.Name, &item.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &item, nil
+}
+
+func (p *ShopProvider) CreateExpenseItem(ctx context.Context, name string) (int, error) {
 	const op = "ShopRepo.CreateExpenseItem"
 
-	_, err := p.db.ExecContext(ctx, _insertExpenseItem, name)
+	var id int
+
+	err := p.db.Executor(ctx).GetContext(ctx, &id, _insertExpenseItem, name)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return id, nil
 }
 
-func (p *ShopProvider) UpdateExpenseItem(ctx context.Context, name string, id int) error {
+// UpdateExpenseItem updates record if it wasn't changed since data.Version was read.
+func (p *ShopProvider) UpdateExpenseItem(ctx context.Context, data *dto.ExpenseItemsData) error {
 	const op = "ShopRepo.UpdateExpenseItem"
 
-	_, err := p.db.ExecContext(ctx, _updateExpenseItem, name, id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _updateExpenseItem, data.Name, data.Id, data.Version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return p.checkUpdated(ctx, op, res, dto.ExpenseItemsTable, data.Id)
 }
 
 func (p *ShopProvider) DeleteExpenseItem(ctx context.Context, id int) error {
 	const op = "ShopRepo.DeleteExpenseItem"
 
-	_, err := p.db.ExecContext(ctx, _deleteExpenseItem, id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deleteExpenseItem, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return checkAffected(op, res)
 }
 
 // Journal's methods
 func (p *ShopProvider) ShowChargesTable(ctx context.Context) ([]*dto.ChargesData, error) {
 	const op = "ShopRepo.ShowChargesTable"
 
-	rows, err := p.db.QueryContext(ctx, _showChargesTable)
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showChargesTable)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -201,7 +284,7 @@ func (p *ShopProvider) ShowChargesTable(ctx context.Context) ([]*dto.ChargesData
 	for rows.Next() {
 		var chargesItem dto.ChargesData
 		if err = rows.Scan(&chargesItem.Id, &chargesItem.Amount, &chargesItem.ChargeDate,
-			&chargesItem.ExpenseItemId); err != nil {
+			&chargesItem.ExpenseItemId, &chargesItem.Version); err != nil {
 			return nil, err
 		}
 		chargesItems = append(chargesItems, &chargesItem)
@@ -210,43 +293,65 @@ func (p *ShopProvider) ShowChargesTable(ctx context.Context) ([]*dto.ChargesData
 	return chargesItems, nil
 }
 
-func (p *ShopProvider) CreateChargesItem(ctx context.Context, data *dto.ChargesData) error {
+func (p *ShopProvider) GetChargesItem(ctx context.Context, id int) (*dto.ChargesData, error) {
+	const op = "ShopRepo.GetChargesItem"
+
+	var item dto.ChargesData
+
+	row := p.db.Executor(ctx).QueryRowxContext(ctx, _getChargesItem, id)
+	err := row.Scan(&item.Id, &item.Amount, &item.ChargeDate, &item.ExpenseItemId, &item.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &item, nil
+}
+
+func (p *ShopProvider) CreateChargesItem(ctx context.Context, data *dto.ChargesData) (int, error) {
 	const op = "ShopRepo.CreateChargesItem"
 
-	_, err := p.db.ExecContext(ctx, _insertChargesItem, data.Amount, data.ChargeDate, data.ExpenseItemId)
+	var id int
+
+	err := p.db.Executor(ctx).GetContext(ctx, &id, _insertChargesItem, data.Amount, data.ChargeDate, data.ExpenseItemId)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return id, nil
 }
 
+// UpdateChargesItem updates record if it wasn't changed since data.Version was read.
 func (p *ShopProvider) UpdateChargesItem(ctx context.Context, data *dto.ChargesData) error {
 	const op = "ShopRepo.UpdateChargesItem"
 
-	_, err := p.db.ExecContext(ctx, _updateChargesItem, data.Amount, data.ChargeDate, data.ExpenseItemId, data.Id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _updateChargesItem, data.Amount, data.ChargeDate,
+		data.ExpenseItemId, data.Id, data.Version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return p.checkUpdated(ctx, op, res, dto.ChargesTable, data.Id)
 }
 
 func (p *ShopProvider) DeleteChargesItem(ctx context.Context, id int) error {
 	const op = "ShopRepo.DeleteChargesItem"
 
-	_, err := p.db.ExecContext(ctx, _deleteChargesItem, id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deleteChargesItem, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return checkAffected(op, res)
 }
 
 func (p *ShopProvider) ShowSalesTable(ctx context.Context) ([]*dto.SalesData, error) {
 	const op = "ShopRepo.ShowSalesTable"
 
-	rows, err := p.db.QueryContext(ctx, _showSalesTable)
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showSalesTable)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -256,7 +361,7 @@ func (p *ShopProvider) ShowSalesTable(ctx context.Context) ([]*dto.SalesData, er
 	for rows.Next() {
 		var salesItem dto.SalesData
 		if err = rows.Scan(&salesItem.Id, &salesItem.Amount, &salesItem.Quantity, &salesItem.SaleDate,
-			&salesItem.WarehousesId); err != nil {
+			&salesItem.WarehousesId, &salesItem.Customer, &salesItem.Version); err != nil {
 			return nil, err
 		}
 		salesItems = append(salesItems, &salesItem)
@@ -265,67 +370,78 @@ func (p *ShopProvider) ShowSalesTable(ctx context.Context) ([]*dto.SalesData, er
 	return salesItems, nil
 }
 
-func (p *ShopProvider) CreateSalesItem(ctx context.Context, data *dto.SalesData) error {
+func (p *ShopProvider) GetSalesItem(ctx context.Context, id int) (*dto.SalesData, error) {
+	const op = "ShopRepo.GetSalesItem"
+
+	var item dto.SalesData
+
+	row := p.db.Executor(ctx).QueryRowxContext(ctx, _getSalesItem, id)
+	err := row.Scan(&item.Id, &item.Amount, &item.Quantity, &item.SaleDate, &item.WarehousesId,
+		&item.Customer, &item.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &item, nil
+}
+
+func (p *ShopProvider) CreateSalesItem(ctx context.Context, data *dto.SalesData) (int, error) {
 	const op = "ShopRepo.CreateSalesItem"
 
-	_, err := p.db.ExecContext(ctx, _insertSalesItem, data.Amount, data.Quantity, data.SaleDate, data.WarehousesId)
+	var id int
+
+	err := p.db.Executor(ctx).GetContext(ctx, &id, _insertSalesItem, data.Amount, data.Quantity, data.SaleDate, data.WarehousesId,
+		data.Customer)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return id, nil
 }
 
+// UpdateSalesItem updates record if it wasn't changed since data.Version was read.
 func (p *ShopProvider) UpdateSalesItem(ctx context.Context, data *dto.SalesData) error {
 	const op = "ShopRepo.UpdateSalesItem"
 
-	_, err := p.db.ExecContext(ctx, _updateSalesItem, data.Amount, data.Quantity, data.SaleDate, data.WarehousesId, data.Id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _updateSalesItem, data.Amount, data.Quantity, data.SaleDate,
+		data.WarehousesId, data.Customer, data.Id, data.Version)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return p.checkUpdated(ctx, op, res, dto.SalesTable, data.Id)
 }
 
 func (p *ShopProvider) DeleteSalesItem(ctx context.Context, id int) error {
 	const op = "ShopRepo.DeleteSalesItem"
 
-	_, err := p.db.ExecContext(ctx, _deleteSalesItem, id)
+	res, err := p.db.Executor(ctx).ExecContext(ctx, _deleteSalesItem, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return checkAffected(op, res)
 }
 
-// Report's methods
-
-func (p *ShopProvider) CountMonthProfit(ctx context.Context) (int64, error) {
-	const op = "ShopRepo.CountMonthProfit"
-
-	var profit int64
-
-	err := p.db.GetContext(ctx, &profit, _countMonthProfit)
-	if err != nil {
-		return -1, fmt.Errorf("%s: %w", op, err)
-	}
-
-	return profit, nil
-}
+// Trash's methods
 
-func (p *ShopProvider) GetFiveBestItems(ctx context.Context, from string, to string) ([]*dto.BestItemsData, error) {
-	const op = "ShopRepo.GetFiveBestItems"
+func (p *ShopProvider) ShowTrash(ctx context.Context) ([]*dto.TrashItemData, error) {
+	const op = "ShopRepo.ShowTrash"
 
-	rows, err := p.db.QueryContext(ctx, _showBestItems, from, to)
+	rows, err := p.db.Executor(ctx).QueryContext(ctx, _showTrash)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var items []*dto.BestItemsData
+	var items []*dto.TrashItemData
 	for rows.Next() {
-		var item dto.BestItemsData
-		if err = rows.Scan(&item.Name, &item.TotalRevenue); err != nil {
+		var item dto.TrashItemData
+		if err = rows.Scan(&item.Table, &item.Id, &item.Description, &item.DeletedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, &item)
@@ -333,3 +449,78 @@ func (p *ShopProvider) GetFiveBestItems(ctx context.Context, from string, to str
 
 	return items, nil
 }
+
+func (p *ShopProvider) RestoreTrashItem(ctx context.Context, table string, id int) error {
+	const op = "ShopRepo.RestoreTrashItem"
+
+	query, ok := _restoreTrashItem[table]
+	if !ok {
+		return fmt.Errorf("%s: %w: %s", op, customErr.ErrUnknownTable, table)
+	}
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checkAffected(op, res)
+}
+
+// PurgeTrashItem permanently deletes soft deleted record. Records which are still referenced
+// (e.g. warehouses item with sales) can't be purged.
+func (p *ShopProvider) PurgeTrashItem(ctx context.Context, table string, id int) error {
+	const op = "ShopRepo.PurgeTrashItem"
+
+	query, ok := _purgeTrashItem[table]
+	if !ok {
+		return fmt.Errorf("%s: %w: %s", op, customErr.ErrUnknownTable, table)
+	}
+
+	res, err := p.db.Executor(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == _foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, customErr.ErrRecordInUse)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checkAffected(op, res)
+}
+
+// checkAffected returns ErrRecordNotFound if statement didn't touch any row.
+func checkAffected(op string, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// checkUpdated tells apart updates of missing records and updates made against stale version.
+func (p *ShopProvider) checkUpdated(ctx context.Context, op string, res sql.Result, table string, id int) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var active bool
+
+	err = p.db.Executor(ctx).GetContext(ctx, &active, fmt.Sprintf(_isRecordActive, table), id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !active {
+		return fmt.Errorf("%s: %w", op, customErr.ErrRecordNotFound)
+	}
+
+	return fmt.Errorf("%s: %w", op, customErr.ErrVersionConflict)
+}
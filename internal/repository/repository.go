@@ -6,13 +6,35 @@ import (
 )
 
 type Repository struct {
-	AuthRepo IAuthRepository
-	ShopRepo IShopRepository
+	AuthRepo      IAuthRepository
+	ShopRepo      IShopRepository
+	AuditRepo     IAuditRepository
+	ArchiveRepo   IArchiveRepository
+	ScheduleRepo  IScheduleRepository
+	PivotRepo     IPivotRepository
+	AnomalyRepo   IAnomalyRepository
+	AggregateRepo IAggregateRepository
+	ImportRepo    IImportRepository
+	BackupRepo    IBackupRepository
+	ExchangeRepo  IExchangeRepository
+	BankRepo      IBankRepository
+	Transactor    ITransactor
 }
 
 func NewRepository(provider *dataprovider.Provider) *Repository {
 	return &Repository{
-		AuthRepo: db.NewAuthProvider(provider),
-		ShopRepo: db.NewShopProvider(provider),
+		AuthRepo:      db.NewAuthProvider(provider),
+		ShopRepo:      db.NewShopProvider(provider),
+		AuditRepo:     db.NewAuditProvider(provider),
+		ArchiveRepo:   db.NewArchiveProvider(provider),
+		ScheduleRepo:  db.NewScheduleProvider(provider),
+		PivotRepo:     db.NewPivotProvider(provider),
+		AnomalyRepo:   db.NewAnomalyProvider(provider),
+		AggregateRepo: db.NewAggregateProvider(provider),
+		ImportRepo:    db.NewImportProvider(provider),
+		BackupRepo:    db.NewBackupProvider(provider),
+		ExchangeRepo:  db.NewExchangeProvider(provider),
+		BankRepo:      db.NewBankProvider(provider),
+		Transactor:    provider,
 	}
 }
@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"automatedShop/internal/export"
+	"automatedShop/internal/reports"
+	"automatedShop/internal/services"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// SystemUser is user scheduled reports are generated and archived on behalf of.
+var SystemUser = &dto.UserData{Login: "scheduler", IsAdmin: true}
+
+// Scheduler generates reports of due schedules, writes them to destination directories, archives
+// them and records every run in schedule's run log.
+type Scheduler struct {
+	s        *services.Service
+	shopName string
+	now      func() time.Time
+}
+
+func New(s *services.Service, shopName string) *Scheduler {
+	return &Scheduler{
+		s:        s,
+		shopName: shopName,
+		now:      time.Now,
+	}
+}
+
+// RunDue runs schedules which are due now and returns number of runs made. Failed runs are recorded
+// in run log and don't stop other schedules, their errors are returned joined.
+func (sc *Scheduler) RunDue(ctx context.Context) (int, error) {
+	ctx = session.WithUser(ctx, SystemUser)
+	now := sc.now()
+
+	schedules, err := sc.s.ScheduleService.GetDueSchedules(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		runs int
+		errs []error
+	)
+	for _, schedule := range schedules {
+		claimed, err := sc.s.ScheduleService.ClaimSchedule(ctx, schedule, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		runs++
+		if err = sc.Run(ctx, schedule); err != nil {
+			errs = append(errs, fmt.Errorf("schedule '%s': %w", schedule.Name, err))
+		}
+	}
+
+	return runs, errors.Join(errs...)
+}
+
+// Run generates report of schedule at once and records the run in run log.
+func (sc *Scheduler) Run(ctx context.Context, schedule *dto.ScheduleData) error {
+	ctx = session.WithUser(ctx, SystemUser)
+	startedAt := sc.now()
+
+	path, err := sc.generate(ctx, schedule, startedAt)
+	run := &dto.ScheduleRunData{
+		ScheduleId: schedule.Id,
+		StartedAt:  startedAt.Format(timeLayout),
+		FinishedAt: sc.now().Format(timeLayout),
+		Status:     dto.RunSucceeded,
+		FilePath:   path,
+	}
+	if err != nil {
+		run.Status = dto.RunFailed
+		run.Error = err.Error()
+	}
+
+	if logErr := sc.s.ScheduleService.SaveScheduleRun(ctx, run); logErr != nil {
+		return errors.Join(err, logErr)
+	}
+
+	return err
+}
+
+// Loop runs due schedules every interval until ctx is done. Errors are logged, so that one failed
+// report doesn't stop the others.
+func (sc *Scheduler) Loop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if runs, err := sc.RunDue(ctx); err != nil {
+			logrus.Error(err)
+		} else if runs > 0 {
+			logrus.Infof("%d scheduled reports generated", runs)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// generate renders report of schedule in its format, writes it to destination directory and archives
+// it. Path of written file is returned even if archiving failed.
+func (sc *Scheduler) generate(ctx context.Context, schedule *dto.ScheduleData, now time.Time) (string, error) {
+	report, ok := reports.Get(schedule.ReportType)
+	if !ok {
+		return "", fmt.Errorf("unknown report %q", schedule.ReportType)
+	}
+
+	out, err := report.Generate(ctx, sc.s, reports.NewValues(report.Info().Params, schedule.Params, now))
+	if err != nil {
+		return "", err
+	}
+	doc := out.Document
+
+	var (
+		buf bytes.Buffer
+		ext string
+	)
+	if schedule.Format == dto.FormatPDF {
+		doc.Shop = sc.shopName
+		doc.GeneratedAt = now
+		doc.Author = SystemUser.Login
+		if err = doc.WritePDF(&buf); err != nil {
+			return "", err
+		}
+		ext = ".pdf"
+	} else {
+		exporter, err := export.New(schedule.Format, csvOptions(schedule.Params))
+		if err != nil {
+			return "", err
+		}
+		if err = exporter.Export(&buf, out.ExportTable()); err != nil {
+			return "", err
+		}
+		ext = exporter.Extension()
+	}
+
+	fileName := report.Info().File + "_" + now.Format("2006-01-02_150405") + ext
+	if err = os.MkdirAll(schedule.Destination, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(schedule.Destination, fileName)
+	if err = os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+
+	err = sc.s.ArchiveService.SaveReport(ctx, &dto.ArchivedReportData{
+		ReportType: report.Info().Name,
+		Params:     strings.Join(doc.Lines, "; "),
+		Format:     strings.TrimPrefix(ext, "."),
+		FileName:   fileName,
+		Content:    buf.Bytes(),
+	})
+	if err != nil {
+		return path, fmt.Errorf("report was saved but not archived: %w", err)
+	}
+
+	return path, nil
+}
+
+func csvOptions(params map[string]string) export.CSVOptions {
+	options := export.CSVOptions{Delimiter: ',', Encoding: export.EncodingUTF8}
+	if delimiter, ok := export.Delimiters[params[dto.ParamDelimiter]]; ok {
+		options.Delimiter = delimiter
+	}
+	if encoding := params[dto.ParamEncoding]; encoding != "" {
+		options.Encoding = encoding
+	}
+
+	return options
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// AggregateService maintains daily aggregates of sales and charges reports are built from. Triggers
+// keep them up to date on writes, the service rebuilds them from scratch.
+type AggregateService struct {
+	l             *slog.Logger
+	AggregateRepo repository.IAggregateRepository
+	Transactor    repository.ITransactor
+}
+
+func NewAggregateService(repo repository.IAggregateRepository, transactor repository.ITransactor) *AggregateService {
+	var l *slog.Logger
+
+	return &AggregateService{
+		l:             l,
+		AggregateRepo: repo,
+		Transactor:    transactor,
+	}
+}
+
+// RebuildAggregates recounts daily aggregates from sales and charges in one transaction, so reports
+// see either old or new aggregates. It's allowed to admins only.
+func (s *AggregateService) RebuildAggregates(ctx context.Context) (*dto.AggregatesData, error) {
+	const op = "AggregateService.RebuildAggregates"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	var res dto.AggregatesData
+	err := s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if res.SalesDays, err = s.AggregateRepo.RebuildSalesDaily(ctx); err != nil {
+			return err
+		}
+		res.ChargesDays, err = s.AggregateRepo.RebuildChargesDaily(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"automatedShop/internal/period"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Upper bounds of cumulative revenue share for A and B classes and of demand variation
+// for X and Y classes.
+const (
+	classAShare     = 0.80
+	classBShare     = 0.95
+	classXVariation = 0.10
+	classYVariation = 0.25
+)
+
+// GetAbcXyzReport classifies all warehouses items by share of revenue (ABC) and by variation
+// of units sold per bucket (XYZ) over given period.
+func (s *AnalysisService) GetAbcXyzReport(ctx context.Context, params *dto.AbcXyzParams) (*dto.AbcXyzReportData, error) {
+	const op = "AnalysisService.GetAbcXyzReport"
+
+	warehouses, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	days, err := s.ShopRepo.GetItemDailySales(ctx, params.Period.From, params.Period.To)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	starts := params.Period.BucketStarts(params.Bucket)
+	bucketIndex := make(map[string]int, len(starts))
+	for i, start := range starts {
+		bucketIndex[start.Format(period.DateLayout)] = i
+	}
+
+	items := make(map[int]*dto.AbcXyzItemData, len(warehouses))
+	demand := make(map[int][]float64, len(warehouses))
+	report := &dto.AbcXyzReportData{}
+	for _, warehouse := range warehouses {
+		item := &dto.AbcXyzItemData{Id: warehouse.Id, Name: warehouse.Name}
+		items[warehouse.Id] = item
+		demand[warehouse.Id] = make([]float64, len(starts))
+		report.Items = append(report.Items, item)
+	}
+
+	var totalRevenue int64
+	for _, day := range days {
+		item, ok := items[day.WarehousesId]
+		if !ok {
+			continue
+		}
+		date, err := time.ParseInLocation(period.DateLayout, day.Day, params.Period.From.Location())
+		if err != nil {
+			return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+		}
+
+		item.Revenue += day.Revenue
+		item.Units += day.Units
+		totalRevenue += day.Revenue
+		if i, ok := bucketIndex[period.BucketStart(date, params.Bucket).Format(period.DateLayout)]; ok {
+			demand[day.WarehousesId][i] += float64(day.Units)
+		}
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].Revenue > report.Items[j].Revenue
+	})
+
+	var cumulative int64
+	for _, item := range report.Items {
+		if totalRevenue > 0 {
+			item.Share = float64(item.Revenue) / float64(totalRevenue)
+			cumulative += item.Revenue
+			item.CumulativeShare = float64(cumulative) / float64(totalRevenue)
+		}
+		item.AbcClass = abcClass(item)
+		item.Variation = variation(demand[item.Id])
+		item.XyzClass = xyzClass(item.Variation)
+
+		report.Matrix[classIndex(dto.AbcClasses, item.AbcClass)][classIndex(dto.XyzClasses, item.XyzClass)]++
+	}
+
+	return report, nil
+}
+
+// abcClass classifies item by cumulative share of revenue. Item which crosses class bound still
+// belongs to the class, so the top item is always A.
+func abcClass(item *dto.AbcXyzItemData) string {
+	switch previous := item.CumulativeShare - item.Share; {
+	case item.Revenue == 0:
+		return dto.ClassC
+	case previous < classAShare:
+		return dto.ClassA
+	case previous < classBShare:
+		return dto.ClassB
+	default:
+		return dto.ClassC
+	}
+}
+
+func xyzClass(variation float64) string {
+	switch {
+	case variation <= classXVariation:
+		return dto.ClassX
+	case variation <= classYVariation:
+		return dto.ClassY
+	default:
+		return dto.ClassZ
+	}
+}
+
+// variation returns coefficient of variation of values. Series without demand are the least
+// predictable, so +Inf is returned for them.
+func variation(values []float64) float64 {
+	if len(values) == 0 {
+		return math.Inf(1)
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	if mean == 0 {
+		return math.Inf(1)
+	}
+
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+
+	return math.Sqrt(squares/float64(len(values))) / mean
+}
+
+func classIndex(classes []string, class string) int {
+	for i, c := range classes {
+		if c == class {
+			return i
+		}
+	}
+
+	return len(classes) - 1
+}
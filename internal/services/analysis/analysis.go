@@ -0,0 +1,24 @@
+package services
+
+import (
+	"automatedShop/internal/repository"
+	"log/slog"
+	"time"
+)
+
+// AnalysisService builds reports which classify and evaluate warehouses items from sales history.
+type AnalysisService struct {
+	l        *slog.Logger
+	ShopRepo repository.IShopRepository
+	now      func() time.Time
+}
+
+func NewAnalysisService(repo repository.IShopRepository) *AnalysisService {
+	var l *slog.Logger
+
+	return &AnalysisService{
+		l:        l,
+		ShopRepo: repo,
+		now:      time.Now,
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/period"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// maxDeadDays bounds dead stock threshold by ten years.
+const maxDeadDays = 3650
+
+// GetTurnoverReport computes turnover and days of supply of each warehouses item over given period
+// and marks items in stock which weren't sold for params.DeadDays days until today as dead stock.
+func (s *AnalysisService) GetTurnoverReport(ctx context.Context, params *dto.TurnoverParams) (*dto.TurnoverReportData, error) {
+	const op = "AnalysisService.GetTurnoverReport"
+
+	if params.DeadDays < 1 || params.DeadDays > maxDeadDays {
+		return nil, fmt.Errorf("error occurred in: %v: %w: dead stock threshold must be between 1 and %d days",
+			op, customErr.ErrInvalidReportParams, maxDeadDays)
+	}
+
+	items, err := s.ShopRepo.GetItemStockSales(ctx, params.Period.From, params.Period.To)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	// Dates are compared as YYYY-MM-DD strings which sort chronologically.
+	deadSince := period.BucketStart(s.now(), period.DayBucket).AddDate(0, 0, -params.DeadDays).Format(period.DateLayout)
+	days := float64(params.Period.Days())
+
+	report := &dto.TurnoverReportData{}
+	for _, item := range items {
+		turnover := &dto.TurnoverItemData{
+			Id:           item.Id,
+			Name:         item.Name,
+			Quantity:     item.Quantity,
+			Cost:         item.Cost,
+			Units:        item.Units,
+			Turnover:     ratio(float64(item.Units), float64(item.Quantity)),
+			DaysOfSupply: ratio(float64(item.Quantity)*days, float64(item.Units)),
+			LastSale:     item.LastSale,
+			Dead:         item.Quantity > 0 && item.LastSale < deadSince,
+		}
+		if item.Cost != nil {
+			value := int64(item.Quantity) * int64(*item.Cost)
+			turnover.TiedUpValue = &value
+			report.TiedUpValue += value
+			if turnover.Dead {
+				report.DeadValue += value
+			}
+		}
+
+		report.Items = append(report.Items, turnover)
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		a, b := report.Items[i], report.Items[j]
+		if a.Dead != b.Dead {
+			return a.Dead
+		}
+
+		return tiedUpValue(a) > tiedUpValue(b)
+	})
+
+	return report, nil
+}
+
+// ratio divides a by b. Dividing positive value by zero gives +Inf, dividing zero gives zero.
+func ratio(a, b float64) float64 {
+	switch {
+	case a <= 0:
+		return 0
+	case b <= 0:
+		return math.Inf(1)
+	default:
+		return a / b
+	}
+}
+
+func tiedUpValue(item *dto.TurnoverItemData) int64 {
+	if item.TiedUpValue == nil {
+		return 0
+	}
+
+	return *item.TiedUpValue
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/period"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// minHistory is the least number of values the usual level is estimated from. Groups with shorter
+// history aren't checked.
+const minHistory = 5
+
+// AnomalyService flags unusual charges, sales and days of sales history, so that typos and mistakes
+// are reviewed. Usual levels are estimated by median and MAD, which aren't thrown off by the outliers
+// being looked for.
+type AnomalyService struct {
+	l           *slog.Logger
+	AnomalyRepo repository.IAnomalyRepository
+}
+
+func NewAnomalyService(repo repository.IAnomalyRepository) *AnomalyService {
+	var l *slog.Logger
+
+	return &AnomalyService{
+		l:           l,
+		AnomalyRepo: repo,
+	}
+}
+
+// DetectAnomalies checks the whole history and returns anomalies, newest first. Acknowledged ones are
+// left out unless params.Acknowledged is set.
+func (s *AnomalyService) DetectAnomalies(ctx context.Context, params *dto.AnomalyParams) ([]*dto.AnomalyData, error) {
+	const op = "AnomalyService.DetectAnomalies"
+
+	if params.Threshold <= 0 {
+		return nil, fmt.Errorf("error occurred in: %v: %w: threshold must be positive", op, customErr.ErrInvalidReportParams)
+	}
+
+	charges, err := s.AnomalyRepo.GetChargeHistory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	sales, err := s.AnomalyRepo.GetSaleHistory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	acks, err := s.AnomalyRepo.ShowAnomalyAcks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	var anomalies []*dto.AnomalyData
+	anomalies = append(anomalies, highCharges(charges, params.Threshold)...)
+	anomalies = append(anomalies, lowRevenueDays(sales, params.Threshold, params.Now)...)
+	anomalies = append(anomalies, negativeMargins(sales)...)
+	anomalies = append(anomalies, underpricedSales(sales, params.Threshold)...)
+
+	acknowledged := make(map[string]*dto.AnomalyAckData, len(acks))
+	for _, ack := range acks {
+		acknowledged[ack.Kind+"/"+ack.Key] = ack
+	}
+
+	res := make([]*dto.AnomalyData, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		// acknowledgement holds while the record keeps the value it was given for
+		if ack, ok := acknowledged[anomaly.Kind+"/"+anomaly.Key]; ok && ack.Value == anomaly.Value {
+			if !params.Acknowledged {
+				continue
+			}
+			anomaly.AcknowledgedBy = ack.UserLogin
+			anomaly.AcknowledgedAt = ack.AcknowledgedAt
+		}
+		res = append(res, anomaly)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Day > res[j].Day
+	})
+
+	return res, nil
+}
+
+// AcknowledgeAnomaly marks anomaly as reviewed by logged-in user.
+func (s *AnomalyService) AcknowledgeAnomaly(ctx context.Context, anomaly *dto.AnomalyData) error {
+	const op = "AnomalyService.AcknowledgeAnomaly"
+
+	login := session.Login(ctx)
+	if login == "" {
+		return fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	err := s.AnomalyRepo.SaveAnomalyAck(ctx, &dto.AnomalyAckData{
+		Kind:      anomaly.Kind,
+		Key:       anomaly.Key,
+		Value:     anomaly.Value,
+		UserLogin: login,
+	})
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// highCharges flags charges far above the usual amount of their expense item.
+func highCharges(charges []*dto.ChargeHistoryData, threshold float64) []*dto.AnomalyData {
+	byItem := make(map[int][]*dto.ChargeHistoryData)
+	for _, charge := range charges {
+		byItem[charge.ExpenseItemId] = append(byItem[charge.ExpenseItemId], charge)
+	}
+
+	var anomalies []*dto.AnomalyData
+	for _, itemCharges := range byItem {
+		if len(itemCharges) < minHistory {
+			continue
+		}
+
+		amounts := make([]float64, len(itemCharges))
+		for i, charge := range itemCharges {
+			amounts[i] = float64(charge.Amount)
+		}
+		med, scale := robustScale(amounts)
+		if scale == 0 {
+			continue
+		}
+
+		for _, charge := range itemCharges {
+			score := (float64(charge.Amount) - med) / scale
+			if score <= threshold {
+				continue
+			}
+			anomalies = append(anomalies, &dto.AnomalyData{
+				Kind:        dto.AnomalyHighCharge,
+				Key:         recordKey(dto.ChargesTable, charge.Id),
+				Table:       dto.ChargesTable,
+				RecordId:    charge.Id,
+				Day:         charge.Day,
+				Subject:     charge.ExpenseItem,
+				Value:       charge.Amount,
+				Expected:    int64(med),
+				Score:       score,
+				Description: fmt.Sprintf("charge is %.1f times the usual amount", float64(charge.Amount)/max(med, 1)),
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// lowRevenueDays flags days whose revenue is far below the usual revenue of the same weekday. Days
+// from the first sale until the day before now are checked, days without sales have zero revenue.
+func lowRevenueDays(sales []*dto.SaleHistoryData, threshold float64, now time.Time) []*dto.AnomalyData {
+	if len(sales) == 0 {
+		return nil
+	}
+
+	revenue := make(map[string]int64)
+	for _, sale := range sales {
+		revenue[sale.Day] += sale.Amount * sale.Quantity
+	}
+
+	first, err := time.ParseInLocation(period.DateLayout, sales[0].Day, time.Local)
+	if err != nil {
+		return nil
+	}
+	today := period.BucketStart(now, period.DayBucket)
+
+	days := make(map[time.Weekday][]string)
+	for day := first; day.Before(today); day = day.AddDate(0, 0, 1) {
+		days[day.Weekday()] = append(days[day.Weekday()], day.Format(period.DateLayout))
+	}
+
+	var anomalies []*dto.AnomalyData
+	for weekday, weekdayDays := range days {
+		if len(weekdayDays) < minHistory {
+			continue
+		}
+
+		values := make([]float64, len(weekdayDays))
+		for i, day := range weekdayDays {
+			values[i] = float64(revenue[day])
+		}
+		med, scale := robustScale(values)
+		if scale == 0 {
+			continue
+		}
+
+		for i, day := range weekdayDays {
+			score := (values[i] - med) / scale
+			if score >= -threshold {
+				continue
+			}
+			anomalies = append(anomalies, &dto.AnomalyData{
+				Kind:        dto.AnomalyLowRevenueDay,
+				Key:         day,
+				Day:         day,
+				Subject:     weekday.String(),
+				Value:       revenue[day],
+				Expected:    int64(med),
+				Score:       score,
+				Description: "revenue is far below usual revenue of " + weekday.String(),
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// negativeMargins flags sales made below cost of their items.
+func negativeMargins(sales []*dto.SaleHistoryData) []*dto.AnomalyData {
+	var anomalies []*dto.AnomalyData
+	for _, sale := range sales {
+		if sale.Cost == nil || sale.Amount >= int64(*sale.Cost) {
+			continue
+		}
+
+		anomalies = append(anomalies, &dto.AnomalyData{
+			Kind:        dto.AnomalyNegativeMargin,
+			Key:         recordKey(dto.SalesTable, sale.Id),
+			Table:       dto.SalesTable,
+			RecordId:    sale.Id,
+			Day:         sale.Day,
+			Subject:     sale.Product,
+			Value:       sale.Amount,
+			Expected:    int64(*sale.Cost),
+			Description: fmt.Sprintf("sold below cost, margin is %d", (sale.Amount-int64(*sale.Cost))*sale.Quantity),
+		})
+	}
+
+	return anomalies
+}
+
+// underpricedSales flags sales whose discount from current price of their item is far above the
+// usual discount of the item.
+func underpricedSales(sales []*dto.SaleHistoryData, threshold float64) []*dto.AnomalyData {
+	byProduct := make(map[int][]*dto.SaleHistoryData)
+	for _, sale := range sales {
+		if sale.Price > 0 {
+			byProduct[sale.WarehousesId] = append(byProduct[sale.WarehousesId], sale)
+		}
+	}
+
+	var anomalies []*dto.AnomalyData
+	for _, productSales := range byProduct {
+		if len(productSales) < minHistory {
+			continue
+		}
+
+		discounts := make([]float64, len(productSales))
+		for i, sale := range productSales {
+			discounts[i] = float64(sale.Price-sale.Amount) / float64(sale.Price)
+		}
+		med, scale := robustScale(discounts)
+		if scale == 0 {
+			continue
+		}
+
+		for i, sale := range productSales {
+			score := (discounts[i] - med) / scale
+			if sale.Amount >= sale.Price || score <= threshold {
+				continue
+			}
+			anomalies = append(anomalies, &dto.AnomalyData{
+				Kind:        dto.AnomalyUnderpricedSale,
+				Key:         recordKey(dto.SalesTable, sale.Id),
+				Table:       dto.SalesTable,
+				RecordId:    sale.Id,
+				Day:         sale.Day,
+				Subject:     sale.Product,
+				Value:       sale.Amount,
+				Expected:    sale.Price,
+				Score:       score,
+				Description: fmt.Sprintf("sold %.0f%% below current price", discounts[i]*100),
+			})
+		}
+	}
+
+	return anomalies
+}
+
+func recordKey(table string, id int) string {
+	return table + ":" + strconv.Itoa(id)
+}
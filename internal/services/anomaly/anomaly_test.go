@@ -0,0 +1,112 @@
+package services
+
+import (
+	"automatedShop/internal/services/dto"
+	"testing"
+	"time"
+)
+
+func keys(anomalies []*dto.AnomalyData) map[string]bool {
+	res := make(map[string]bool, len(anomalies))
+	for _, anomaly := range anomalies {
+		res[anomaly.Key] = true
+	}
+
+	return res
+}
+
+func TestHighCharges(t *testing.T) {
+	var charges []*dto.ChargeHistoryData
+	for i, amount := range []int64{100, 110, 90, 105, 1000} {
+		charges = append(charges, &dto.ChargeHistoryData{Id: i + 1, ExpenseItemId: 1, ExpenseItem: "rent",
+			Amount: amount, Day: "2024-01-01"})
+	}
+	// expense item of the same name has too short history to be checked, even though its charge is
+	// far above charges of the other one
+	charges = append(charges, &dto.ChargeHistoryData{Id: 6, ExpenseItemId: 2, ExpenseItem: "rent", Amount: 5000,
+		Day: "2024-01-02"})
+
+	got := highCharges(charges, 3)
+	if len(got) != 1 || got[0].Key != "charges:5" {
+		t.Fatalf("highCharges() = %v, want charge 5 only", keys(got))
+	}
+	if got[0].Subject != "rent" || got[0].Expected != 105 || got[0].Score <= 3 {
+		t.Errorf("highCharges() = %+v, want rent expected at 105 with score above 3", got[0])
+	}
+}
+
+func TestHighChargesEqualAmounts(t *testing.T) {
+	var charges []*dto.ChargeHistoryData
+	for i := 0; i < minHistory; i++ {
+		charges = append(charges, &dto.ChargeHistoryData{Id: i + 1, ExpenseItemId: 1, Amount: 100})
+	}
+
+	if got := highCharges(charges, 3); len(got) != 0 {
+		t.Errorf("highCharges() of equal amounts = %v, want none", keys(got))
+	}
+}
+
+func TestUnderpricedSales(t *testing.T) {
+	var sales []*dto.SaleHistoryData
+	for i, amount := range []int64{100, 100, 95, 100, 100, 50} {
+		sales = append(sales, &dto.SaleHistoryData{Id: i + 1, WarehousesId: 1, Product: "tea", Amount: amount,
+			Quantity: 1, Price: 100, Day: "2024-01-01"})
+	}
+	for i := 0; i < minHistory-1; i++ {
+		sales = append(sales, &dto.SaleHistoryData{Id: 10 + i, WarehousesId: 2, Product: "tea", Amount: 10,
+			Quantity: 1, Price: 100, Day: "2024-01-01"})
+	}
+
+	got := underpricedSales(sales, 3)
+	if len(got) != 1 || got[0].Key != "sales:6" {
+		t.Fatalf("underpricedSales() = %v, want sale 6 only", keys(got))
+	}
+	if got[0].Expected != 100 || got[0].Description != "sold 50% below current price" {
+		t.Errorf("underpricedSales() = %+v, want sale 50%% below price 100", got[0])
+	}
+}
+
+func TestNegativeMargins(t *testing.T) {
+	cost := 80
+	sales := []*dto.SaleHistoryData{
+		{Id: 1, Amount: 100, Quantity: 2, Cost: &cost},
+		{Id: 2, Amount: 70, Quantity: 2, Cost: &cost},
+		{Id: 3, Amount: 10, Quantity: 1},
+	}
+
+	got := negativeMargins(sales)
+	if len(got) != 1 || got[0].Key != "sales:2" {
+		t.Fatalf("negativeMargins() = %v, want sale 2 only", keys(got))
+	}
+	if want := "sold below cost, margin is -20"; got[0].Description != want {
+		t.Errorf("negativeMargins() description = %q, want %q", got[0].Description, want)
+	}
+}
+
+func TestLowRevenueDays(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	const days = 5 * 7
+
+	var sales []*dto.SaleHistoryData
+	for i := 0; i < days; i++ {
+		// the third Wednesday has no sales
+		if i == 16 {
+			continue
+		}
+		sales = append(sales, &dto.SaleHistoryData{Id: i + 1, Amount: 100, Quantity: 1,
+			Day: first.AddDate(0, 0, i).Format("2006-01-02")})
+	}
+
+	got := lowRevenueDays(sales, 3, first.AddDate(0, 0, days).Add(12*time.Hour))
+	if len(got) != 1 || got[0].Key != "2024-01-17" {
+		t.Fatalf("lowRevenueDays() = %v, want 2024-01-17 only", keys(got))
+	}
+	if got[0].Subject != "Wednesday" || got[0].Value != 0 || got[0].Expected != 100 {
+		t.Errorf("lowRevenueDays() = %+v, want Wednesday with no revenue instead of 100", got[0])
+	}
+
+	// four weeks aren't enough history
+	if got = lowRevenueDays(sales, 3, first.AddDate(0, 0, 4*7)); len(got) != 0 {
+		t.Errorf("lowRevenueDays() of short history = %v, want none", keys(got))
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// madScale makes MAD a consistent estimator of standard deviation of normal distribution
+	madScale = 1.4826
+	// meanDeviationScale does the same for mean absolute deviation, it's used when MAD is zero
+	meanDeviationScale = 1.253314
+)
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// robustScale returns median of values and their spread estimated by MAD. More than half of equal
+// values give zero MAD, then mean absolute deviation from median is used. Zero spread means all
+// values are equal.
+func robustScale(values []float64) (float64, float64) {
+	med := median(values)
+
+	deviations := make([]float64, len(values))
+	var sum float64
+	for i, value := range values {
+		deviations[i] = math.Abs(value - med)
+		sum += deviations[i]
+	}
+
+	if mad := median(deviations); mad > 0 {
+		return med, madScale * mad
+	}
+	if len(values) == 0 {
+		return med, 0
+	}
+
+	return med, meanDeviationScale * sum / float64(len(values))
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"odd length", []float64{5, 1, 3}, 3},
+		{"even length", []float64{4, 1, 3, 2}, 2.5},
+		{"single value", []float64{7}, 7},
+		{"no values", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := slices.Clone(tt.values)
+			if got := median(values); got != tt.want {
+				t.Errorf("median() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(values, tt.values) {
+				t.Errorf("median() reordered values to %v", values)
+			}
+		})
+	}
+}
+
+func TestRobustScale(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []float64
+		wantMed   float64
+		wantScale float64
+	}{
+		{"mad", []float64{1, 2, 3, 4, 100}, 3, madScale},
+		{"zero mad falls back to mean deviation", []float64{5, 5, 5, 5, 15}, 5, meanDeviationScale * 2},
+		{"equal values", []float64{4, 4, 4}, 4, 0},
+		{"no values", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			med, scale := robustScale(tt.values)
+			if med != tt.wantMed || math.Abs(scale-tt.wantScale) > 1e-9 {
+				t.Errorf("robustScale() = %v, %v, want %v, %v", med, scale, tt.wantMed, tt.wantScale)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// ArchiveService keeps generated reports, so they can be downloaded again later.
+type ArchiveService struct {
+	l           *slog.Logger
+	ArchiveRepo repository.IArchiveRepository
+}
+
+func NewArchiveService(repo repository.IArchiveRepository) *ArchiveService {
+	var l *slog.Logger
+
+	return &ArchiveService{
+		l:           l,
+		ArchiveRepo: repo,
+	}
+}
+
+// SaveReport stores report generated by logged-in user.
+func (s *ArchiveService) SaveReport(ctx context.Context, report *dto.ArchivedReportData) error {
+	const op = "ArchiveService.SaveReport"
+
+	report.UserLogin = session.Login(ctx)
+	id, err := s.ArchiveRepo.SaveReport(ctx, report)
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	report.Id = id
+
+	return nil
+}
+
+// ShowReports returns archived reports. Admins see reports of all users, others only their own.
+func (s *ArchiveService) ShowReports(ctx context.Context) ([]*dto.ArchivedReportData, error) {
+	const op = "ArchiveService.ShowReports"
+
+	login := session.Login(ctx)
+	switch {
+	case session.IsAdmin(ctx):
+		login = ""
+	case login == "":
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	res, err := s.ArchiveRepo.ShowReports(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// GetReport returns archived report with its file. Users other than admins get only their own reports.
+func (s *ArchiveService) GetReport(ctx context.Context, id int64) (*dto.ArchivedReportData, error) {
+	const op = "ArchiveService.GetReport"
+
+	res, err := s.ArchiveRepo.GetReport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	if !session.IsAdmin(ctx) && res.UserLogin != session.Login(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type AuditService struct {
+	l         *slog.Logger
+	AuditRepo repository.IAuditRepository
+}
+
+func NewAuditService(repo repository.IAuditRepository) *AuditService {
+	var l *slog.Logger
+
+	return &AuditService{
+		l:         l,
+		AuditRepo: repo,
+	}
+}
+
+// ShowAuditLog returns audit records matching filter. Only admins can read audit log.
+func (s *AuditService) ShowAuditLog(ctx context.Context, filter *dto.AuditFilterData) ([]*dto.AuditRecordData, error) {
+	const op = "AuditService.ShowAuditLog"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	res, err := s.AuditRepo.ShowAuditLog(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
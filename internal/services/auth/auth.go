@@ -3,6 +3,7 @@ package services
 import (
 	customErr "automatedShop/internal/errors"
 	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
 	"context"
 	"errors"
 	"fmt"
@@ -98,3 +99,19 @@ func (s *AuthService) IsRootUser(ctx context.Context, userID int64) (bool, error
 
 	return isRoot, nil
 }
+
+// GetUser returns public data of user with given login.
+func (s *AuthService) GetUser(ctx context.Context, login string) (*dto.UserData, error) {
+	const op = "Auth.GetUser"
+
+	user, err := s.AuthRepo.FindUser(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.UserData{
+		Id:      int64(user.ID),
+		Login:   user.Login,
+		IsAdmin: user.IsAdmin,
+	}, nil
+}
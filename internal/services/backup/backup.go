@@ -0,0 +1,279 @@
+package services
+
+import (
+	"automatedShop/internal/backup"
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// restoreBatchSize is number of rows inserted by one query on restore.
+const restoreBatchSize = 500
+
+// userColumns are columns of users saved to backups. Passwords are saved only as their hashes.
+var userColumns = []string{"login", "pass_hash", "is_admin"}
+
+// BackupService saves shop data to portable archives and restores it, see package backup for
+// the format. Both are allowed to admins only.
+type BackupService struct {
+	l             *slog.Logger
+	BackupRepo    repository.IBackupRepository
+	AggregateRepo repository.IAggregateRepository
+	Transactor    repository.ITransactor
+}
+
+func NewBackupService(repo repository.IBackupRepository, aggregateRepo repository.IAggregateRepository,
+	transactor repository.ITransactor) *BackupService {
+	var l *slog.Logger
+
+	return &BackupService{
+		l:             l,
+		BackupRepo:    repo,
+		AggregateRepo: aggregateRepo,
+		Transactor:    transactor,
+	}
+}
+
+// Backup writes all shop data to w. Tables are read from one snapshot, so the backup is consistent
+// even if data is changed meanwhile.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, params *dto.BackupParams) (*dto.BackupData, error) {
+	const op = "BackupService.Backup"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	var manifest *backup.Manifest
+	err := s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.BackupRepo.SetSnapshot(ctx); err != nil {
+			return err
+		}
+		version, err := s.BackupRepo.SchemaVersion(ctx)
+		if err != nil {
+			return err
+		}
+
+		writer := backup.NewWriter(w, version, time.Now())
+		dump := func(table string, columns []string) error {
+			return writer.WriteTable(table, func(emit func([]byte) error) error {
+				return s.BackupRepo.DumpTable(ctx, table, columns, emit)
+			})
+		}
+
+		for _, table := range dto.BackupTables {
+			if err = dump(table, nil); err != nil {
+				return err
+			}
+		}
+		if params.Users {
+			if err = dump(dto.UsersTable, userColumns); err != nil {
+				return err
+			}
+		}
+
+		manifest, err = writer.Close()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return backupData(manifest, nil), nil
+}
+
+// Restore loads backup read from r into database in one transaction. Checksums of the whole backup
+// are verified first. Rows of backups made with older schema are upgraded to schema of database,
+// backups made with newer schema are refused. Daily aggregates are rebuilt afterwards.
+func (s *BackupService) Restore(ctx context.Context, r io.ReaderAt, size int64, params *dto.RestoreParams) (*dto.BackupData, error) {
+	const op = "BackupService.Restore"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	archive, err := backup.OpenReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w: %w", op, customErr.ErrInvalidBackup, err)
+	}
+	if err = archive.Verify(); err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w: %w", op, customErr.ErrInvalidBackup, err)
+	}
+
+	restored := make(map[string]int64)
+	err = s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		version, err := s.BackupRepo.SchemaVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if archive.Manifest.SchemaVersion > version {
+			return fmt.Errorf("%w: backup has schema version %d, apply migrations up to it first",
+				customErr.ErrInvalidBackup, archive.Manifest.SchemaVersion)
+		}
+
+		if err = s.prepareTables(ctx, params.Replace); err != nil {
+			return err
+		}
+
+		for _, table := range dto.BackupTables {
+			if archive.Table(table) == nil {
+				continue
+			}
+
+			columns, err := s.BackupRepo.TableColumns(ctx, table)
+			if err != nil {
+				return err
+			}
+			restored[table], err = restoreTable(archive, table, columns, version,
+				func(columns []string, rows []byte) (int64, error) {
+					return s.BackupRepo.RestoreRows(ctx, table, columns, rows)
+				})
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(columns, "id") {
+				continue
+			}
+			if err = s.BackupRepo.ResetSequence(ctx, table); err != nil {
+				return err
+			}
+		}
+
+		if params.Users && archive.Table(dto.UsersTable) != nil {
+			restored[dto.UsersTable], err = restoreTable(archive, dto.UsersTable, userColumns, version,
+				func(_ []string, rows []byte) (int64, error) {
+					return s.BackupRepo.RestoreUsers(ctx, rows)
+				})
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err = s.AggregateRepo.RebuildSalesDaily(ctx); err != nil {
+			return err
+		}
+		_, err = s.AggregateRepo.RebuildChargesDaily(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return backupData(archive.Manifest, restored), nil
+}
+
+// prepareTables clears tables of shop data when replace is set and checks they're empty otherwise.
+func (s *BackupService) prepareTables(ctx context.Context, replace bool) error {
+	if replace {
+		return s.BackupRepo.ClearTables(ctx, dto.BackupTables)
+	}
+
+	for _, table := range dto.BackupTables {
+		hasRows, err := s.BackupRepo.HasRows(ctx, table)
+		if err != nil {
+			return err
+		}
+		if hasRows {
+			return fmt.Errorf("%w: %s isn't empty, restore with replace to delete existing data",
+				customErr.ErrInvalidBackup, table)
+		}
+	}
+
+	return nil
+}
+
+// restoreTable upgrades rows of table from backup to schema version of database and passes them to
+// insert in batches. Rows may lack some of columns, but mustn't have other columns.
+func restoreTable(archive *backup.Reader, table string, columns []string, version int,
+	insert func(columns []string, rows []byte) (int64, error)) (int64, error) {
+	var (
+		batch    []map[string]any
+		restored int64
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		rows, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		n, err := insert(rowColumns(batch[0]), rows)
+		if err != nil {
+			return err
+		}
+
+		restored += n
+		batch = batch[:0]
+		return nil
+	}
+
+	err := archive.ReadTable(table, func(line []byte) error {
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+
+		var row map[string]any
+		if err := decoder.Decode(&row); err != nil {
+			return fmt.Errorf("%w: row of %s isn't JSON object: %w", customErr.ErrInvalidBackup, table, err)
+		}
+		if err := backup.UpgradeRow(archive.Manifest.SchemaVersion, version, table, row); err != nil {
+			return err
+		}
+		for column := range row {
+			if !slices.Contains(columns, column) {
+				return fmt.Errorf("%w: %s has no column %s", customErr.ErrInvalidBackup, table, column)
+			}
+		}
+
+		batch = append(batch, row)
+		if len(batch) == restoreBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err = flush(); err != nil {
+		return 0, err
+	}
+
+	return restored, nil
+}
+
+func rowColumns(row map[string]any) []string {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+
+	return columns
+}
+
+// backupData describes backup by its manifest. Numbers of rows are taken from restored when it's
+// given, users skipped on restore aren't counted.
+func backupData(manifest *backup.Manifest, restored map[string]int64) *dto.BackupData {
+	res := &dto.BackupData{SchemaVersion: manifest.SchemaVersion, CreatedAt: manifest.CreatedAt}
+	for _, table := range manifest.Tables {
+		rows := table.Rows
+		if restored != nil {
+			var ok bool
+			if rows, ok = restored[table.Name]; !ok {
+				continue
+			}
+		}
+		res.Tables = append(res.Tables, &dto.BackupTableData{Name: table.Name, Rows: rows})
+	}
+
+	return res
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxPatternLen is maximal length of patterns of rules.
+const maxPatternLen = 200
+
+// BankService imports outgoing payments of bank statements as charges. Expense items of payments
+// are suggested by rules and reviewed by user before import. Keys of imported payments are kept,
+// so payments of statements imported again are recognised as duplicates.
+type BankService struct {
+	l           *slog.Logger
+	ShopRepo    repository.IShopRepository
+	BankRepo    repository.IBankRepository
+	ShopService IShopService
+	Transactor  repository.ITransactor
+}
+
+// IShopService is the part of shop service charges are created through, so that they're recorded
+// in audit log.
+type IShopService interface {
+	CreateChargesItem(context.Context, *dto.ChargesData) error
+}
+
+func NewBankService(shopRepo repository.IShopRepository, bankRepo repository.IBankRepository,
+	shopService IShopService, transactor repository.ITransactor) *BankService {
+	var l *slog.Logger
+
+	return &BankService{
+		l:           l,
+		ShopRepo:    shopRepo,
+		BankRepo:    bankRepo,
+		ShopService: shopService,
+		Transactor:  transactor,
+	}
+}
+
+func (s *BankService) ShowBankRules(ctx context.Context) ([]*dto.BankRuleData, error) {
+	const op = "BankService.ShowBankRules"
+
+	res, err := s.BankRepo.ShowBankRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// CreateBankRule saves rule. Rule must have at least one pattern and existing expense item.
+func (s *BankService) CreateBankRule(ctx context.Context, rule *dto.BankRuleData) error {
+	const op = "BankService.CreateBankRule"
+
+	rule.Counterparty, rule.Description = strings.TrimSpace(rule.Counterparty), strings.TrimSpace(rule.Description)
+	switch {
+	case rule.Counterparty == "" && rule.Description == "":
+		return fmt.Errorf("error occurred in: %v: %w: counterparty or description must be given", op,
+			customErr.ErrInvalidBankRule)
+	case utf8.RuneCountInString(rule.Counterparty) > maxPatternLen || utf8.RuneCountInString(rule.Description) > maxPatternLen:
+		return fmt.Errorf("error occurred in: %v: %w: patterns must be at most %d characters", op,
+			customErr.ErrInvalidBankRule, maxPatternLen)
+	}
+	if _, err := s.ShopRepo.GetExpenseItem(ctx, rule.ExpenseItemId); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	id, err := s.BankRepo.CreateBankRule(ctx, rule)
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	rule.Id = id
+
+	return nil
+}
+
+func (s *BankService) DeleteBankRule(ctx context.Context, id int) error {
+	const op = "BankService.DeleteBankRule"
+
+	if err := s.BankRepo.DeleteBankRule(ctx, id); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReviewStatement prepares outgoing payments for review. Lines get expense item of the first
+// matching rule and are confirmed when they have one, unless they were imported before.
+func (s *BankService) ReviewStatement(ctx context.Context, payments []*dto.BankPaymentData) (*dto.StatementReviewData, error) {
+	const op = "BankService.ReviewStatement"
+
+	rules, err := s.BankRepo.ShowBankRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	imported, err := s.importedPayments(ctx, payments)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	res := &dto.StatementReviewData{}
+	for _, payment := range payments {
+		if !payment.Outgoing {
+			res.Incoming++
+			continue
+		}
+
+		line := &dto.StatementLineData{Payment: payment, Duplicate: imported[payment.Key]}
+		if rule := matchRule(rules, payment); rule != nil {
+			line.ExpenseItemId, line.RuleId = rule.ExpenseItemId, rule.Id
+		}
+		line.Confirmed = !line.Duplicate && line.ExpenseItemId != 0 && payment.Amount > 0
+		res.Lines = append(res.Lines, line)
+	}
+
+	return res, nil
+}
+
+// ImportStatement creates charge of each confirmed line in one transaction and records it in audit
+// log. Lines imported before, e.g. by someone else since review, are skipped.
+func (s *BankService) ImportStatement(ctx context.Context, lines []*dto.StatementLineData) (*dto.StatementResultData, error) {
+	const op = "BankService.ImportStatement"
+
+	var confirmed []*dto.BankPaymentData
+	for _, line := range lines {
+		if !line.Confirmed {
+			continue
+		}
+		if line.ExpenseItemId == 0 {
+			return nil, fmt.Errorf("error occurred in: %v: %w: payment %s of %s has no expense item", op,
+				customErr.ErrInvalidStatement, line.Payment.Number, line.Payment.Date)
+		}
+		if line.Payment.Amount <= 0 {
+			return nil, fmt.Errorf("error occurred in: %v: %w: payment %s of %s has no amount", op,
+				customErr.ErrInvalidStatement, line.Payment.Number, line.Payment.Date)
+		}
+		confirmed = append(confirmed, line.Payment)
+	}
+
+	res := &dto.StatementResultData{}
+	err := s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		imported, err := s.importedPayments(ctx, confirmed)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			switch {
+			case !line.Confirmed:
+				res.Skipped++
+				continue
+			case imported[line.Payment.Key]:
+				res.Duplicates++
+				continue
+			}
+
+			if err = s.createCharge(ctx, line); err != nil {
+				return err
+			}
+			imported[line.Payment.Key] = true
+			res.Created++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (s *BankService) createCharge(ctx context.Context, line *dto.StatementLineData) error {
+	charge := &dto.ChargesData{
+		Amount: line.Payment.Amount,
+		// payments have only dates
+		ChargeDate:    line.Payment.Date + " 00:00:00",
+		ExpenseItemId: line.ExpenseItemId,
+	}
+
+	if err := s.ShopService.CreateChargesItem(ctx, charge); err != nil {
+		return err
+	}
+
+	return s.BankRepo.SaveImportedPayment(ctx, line.Payment.Key, charge.Id)
+}
+
+// importedPayments tells which of payments were imported before.
+func (s *BankService) importedPayments(ctx context.Context, payments []*dto.BankPaymentData) (map[string]bool, error) {
+	keys := make([]string, 0, len(payments))
+	for _, payment := range payments {
+		keys = append(keys, payment.Key)
+	}
+
+	res := make(map[string]bool)
+	if len(keys) == 0 {
+		return res, nil
+	}
+
+	imported, err := s.BankRepo.ShowImportedPayments(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range imported {
+		res[key] = true
+	}
+
+	return res, nil
+}
+
+// matchRule returns the first rule payment matches or nil.
+func matchRule(rules []*dto.BankRuleData, payment *dto.BankPaymentData) *dto.BankRuleData {
+	counterparty, description := strings.ToLower(payment.Counterparty), strings.ToLower(payment.Description)
+	for _, rule := range rules {
+		if strings.Contains(counterparty, strings.ToLower(rule.Counterparty)) &&
+			strings.Contains(description, strings.ToLower(rule.Description)) {
+			return rule
+		}
+	}
+
+	return nil
+}
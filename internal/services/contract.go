@@ -1,38 +1,127 @@
 package services
 
 import (
+	"automatedShop/internal/period"
 	"automatedShop/internal/services/dto"
 	"context"
+	"io"
+	"time"
 )
 
 type IShopService interface {
 	// Handbook's methods
 	ShowWarehousesTable(context.Context) ([]*dto.WarehousesData, error)
+	GetWarehousesItem(context.Context, int) (*dto.WarehousesData, error)
 	CreateWarehousesItem(context.Context, *dto.WarehousesData) error
 	UpdateWarehousesItem(context.Context, *dto.WarehousesData) error
 	DeleteWarehousesItem(context.Context, int) error
 	ShowExpenseItemsTable(context.Context) ([]*dto.ExpenseItemsData, error)
+	GetExpenseItem(context.Context, int) (*dto.ExpenseItemsData, error)
 	CreateExpenseItem(context.Context, string) error
 	UpdateExpenseItem(context.Context, *dto.ExpenseItemsData) error
 	DeleteExpenseItem(context.Context, int) error
 
 	// Journal's methods
 	ShowChargesTable(context.Context) ([]*dto.ChargesData, error)
+	GetChargesItem(context.Context, int) (*dto.ChargesData, error)
 	CreateChargesItem(context.Context, *dto.ChargesData) error
 	UpdateChargesItem(context.Context, *dto.ChargesData) error
 	DeleteChargesItem(context.Context, int) error
 	ShowSalesTable(context.Context) ([]*dto.SalesData, error)
+	GetSalesItem(context.Context, int) (*dto.SalesData, error)
 	CreateSalesItem(context.Context, *dto.SalesData) error
 	UpdateSalesItem(context.Context, *dto.SalesData) error
 	DeleteSalesItem(context.Context, int) error
 
 	// Report's methods
-	CountMonthProfit(context.Context) (int64, error)
-	GetFiveBestItems(context.Context, string, string) ([]*dto.BestItemsData, error)
+	GetProfitReport(context.Context, period.Period) (*dto.ProfitReportData, error)
+	GetRankingReport(context.Context, *dto.RankingParams) ([]*dto.RankingItemData, error)
+	GetProductSales(context.Context, period.Period) ([]*dto.ProductSalesData, error)
+	GetComparisonReport(context.Context, *dto.ComparisonParams) (*dto.ComparisonReportData, error)
+	GetSalesTrend(context.Context, *dto.TrendParams) ([]*dto.TrendPointData, error)
+	GetSalesHeatmap(context.Context, period.Period) (*dto.HeatmapData, error)
+	GetDashboard(context.Context, *dto.DashboardParams) (*dto.DashboardData, error)
+
+	// Trash's methods
+	ShowTrash(context.Context) ([]*dto.TrashItemData, error)
+	RestoreTrashItem(context.Context, string, int) error
+	PurgeTrashItem(context.Context, string, int) error
+}
+
+type IAnalysisService interface {
+	GetAbcXyzReport(context.Context, *dto.AbcXyzParams) (*dto.AbcXyzReportData, error)
+	GetTurnoverReport(context.Context, *dto.TurnoverParams) (*dto.TurnoverReportData, error)
+}
+
+type IForecastService interface {
+	GetDemandForecast(context.Context, *dto.ForecastParams) (*dto.ForecastReportData, error)
+}
+
+type IArchiveService interface {
+	SaveReport(context.Context, *dto.ArchivedReportData) error
+	ShowReports(context.Context) ([]*dto.ArchivedReportData, error)
+	GetReport(context.Context, int64) (*dto.ArchivedReportData, error)
+}
+
+type IScheduleService interface {
+	CreateSchedule(context.Context, *dto.ScheduleData) error
+	ShowSchedules(context.Context) ([]*dto.ScheduleData, error)
+	DeleteSchedule(context.Context, int) error
+	GetDueSchedules(context.Context, time.Time) ([]*dto.ScheduleData, error)
+	ClaimSchedule(context.Context, *dto.ScheduleData, time.Time) (bool, error)
+	SaveScheduleRun(context.Context, *dto.ScheduleRunData) error
+	ShowScheduleRuns(context.Context, int) ([]*dto.ScheduleRunData, error)
+}
+
+type IPivotService interface {
+	GetPivotReport(context.Context, *dto.PivotParams) (*dto.PivotReportData, error)
+	SavePivotDefinition(context.Context, *dto.SavedPivotData) error
+	ShowPivotDefinitions(context.Context) ([]*dto.SavedPivotData, error)
+	DeletePivotDefinition(context.Context, int) error
+}
+
+type IAnomalyService interface {
+	DetectAnomalies(context.Context, *dto.AnomalyParams) ([]*dto.AnomalyData, error)
+	AcknowledgeAnomaly(context.Context, *dto.AnomalyData) error
+}
+
+type IAggregateService interface {
+	RebuildAggregates(context.Context) (*dto.AggregatesData, error)
+}
+
+type IImportService interface {
+	ImportCSV(context.Context, *dto.ImportParams) (*dto.ImportResultData, error)
+}
+
+type IBackupService interface {
+	Backup(context.Context, io.Writer, *dto.BackupParams) (*dto.BackupData, error)
+	Restore(context.Context, io.ReaderAt, int64, *dto.RestoreParams) (*dto.BackupData, error)
+}
+
+type ISeedService interface {
+	Seed(context.Context, *dto.SeedParams) (*dto.SeedData, error)
+}
+
+type IExchangeService interface {
+	Export(context.Context, io.Writer, *dto.ExchangeExportParams) error
+	Import(context.Context, io.Reader) (*dto.ExchangeResultData, error)
+}
+
+type IBankService interface {
+	ShowBankRules(context.Context) ([]*dto.BankRuleData, error)
+	CreateBankRule(context.Context, *dto.BankRuleData) error
+	DeleteBankRule(context.Context, int) error
+	ReviewStatement(context.Context, []*dto.BankPaymentData) (*dto.StatementReviewData, error)
+	ImportStatement(context.Context, []*dto.StatementLineData) (*dto.StatementResultData, error)
+}
+
+type IAuditService interface {
+	ShowAuditLog(context.Context, *dto.AuditFilterData) ([]*dto.AuditRecordData, error)
 }
 
 type IAuthService interface {
 	AuthoriseUser(context.Context, string, string) bool
 	RegisterUser(context.Context, string, string) error
 	IsRootUser(context.Context, int64) (bool, error)
+	GetUser(context.Context, string) (*dto.UserData, error)
 }
@@ -1,33 +1,887 @@
 package dto
 
+import (
+	"automatedShop/internal/period"
+	"time"
+)
+
 type WarehousesData struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Amount   int    `json:"amount"`
+	// Cost is purchase price of one item. It's nil when unknown.
+	Cost     *int   `json:"cost"`
+	Category string `json:"category"`
+	Location string `json:"location"`
+	Version  int    `json:"version"`
+}
+
+type ExpenseItemsData struct {
+	Id      int    `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// Metrics items can be ranked by.
+const (
+	MetricRevenue = "revenue"
+	MetricUnits   = "units"
+	MetricMargin  = "margin"
+	MetricSales   = "sales"
+)
+
+// Groupings of ranked items.
+const (
+	GroupByProduct  = "product"
+	GroupByCategory = "category"
+	GroupByLocation = "location"
+)
+
+type RankingParams struct {
+	Period  period.Period
+	Metric  string
+	GroupBy string
+	Limit   int
+	// Worst ranks items in ascending order of metric.
+	Worst bool
+}
+
+type RankingPreset struct {
+	Name   string
+	Params RankingParams
+}
+
+// RankingPresets are commonly used rankings. Period of preset is chosen by user.
+var RankingPresets = []RankingPreset{
+	{Name: "Five best items", Params: RankingParams{Metric: MetricRevenue, GroupBy: GroupByProduct, Limit: 5}},
+	{Name: "Ten worst sellers", Params: RankingParams{Metric: MetricUnits, GroupBy: GroupByProduct, Limit: 10, Worst: true}},
+	{Name: "Most profitable categories", Params: RankingParams{Metric: MetricMargin, GroupBy: GroupByCategory, Limit: 10}},
+}
+
+// RankingItemData holds all metrics of ranked group, Value is the one it was ranked by.
+type RankingItemData struct {
+	Name       string
+	Revenue    int64
+	Units      int64
+	Margin     int64
+	SalesCount int64
+	Value      int64
+}
+
+// ProductSalesData holds sales totals of warehouses item in period.
+type ProductSalesData struct {
+	Id      int
+	Name    string
+	Revenue int64
+	Units   int64
+}
+
+// Sections of comparison report.
+const (
+	SectionProfit   = "profit"
+	SectionCharges  = "charges"
+	SectionProducts = "products"
+)
+
+// ComparisonParams sets up comparison of Period with the previous period and the same period a
+// year ago. Rows which changed by at least MoverPercent percent are marked as big movers.
+type ComparisonParams struct {
+	Period       period.Period
+	MoverPercent float64
+}
+
+// ComparisonDeltaData holds value of report line in base period and its change since then.
+// Percent is nil when base value is zero, such lines are big movers whenever they changed.
+type ComparisonDeltaData struct {
+	Base     int64
+	Delta    int64
+	Percent  *float64
+	BigMover bool
+}
+
+// ComparisonRowData holds value of report line in period compared with both base periods.
+type ComparisonRowData struct {
+	Section  string
+	Name     string
+	Current  int64
+	Previous ComparisonDeltaData
+	YearAgo  ComparisonDeltaData
+}
+
+type ComparisonReportData struct {
+	Current  string
+	Previous string
+	YearAgo  string
+	Rows     []*ComparisonRowData
+}
+
+type ExpenseTotalData struct {
+	Id    int
+	Name  string
+	Total int64
+}
+
+// ProfitReportData is profit and loss statement for a period. Cogs counts only sales of items
+// with known cost at their current cost, revenue of the rest is given in RevenueWithoutCost.
+// GrossProfit and NetProfit are of revenue with known cost only.
+type ProfitReportData struct {
+	From               string
+	To                 string
+	Revenue            int64
+	Cogs               int64
+	RevenueWithoutCost int64
+	GrossProfit        int64
+	Expenses           []*ExpenseTotalData
+	TotalExpenses      int64
+	NetProfit          int64
+}
+
+type DailySalesData struct {
+	Day        string
+	Revenue    int64
+	Units      int64
+	SalesCount int64
+}
+
+type ItemDailySalesData struct {
+	WarehousesId int
+	Day          string
+	Revenue      int64
+	Units        int64
+}
+
+// ItemStockSalesData holds current stock of warehouses item with units sold in period and the
+// last day (YYYY-MM-DD) it was ever sold. LastSale is empty when item was never sold.
+type ItemStockSalesData struct {
 	Id       int
 	Name     string
 	Quantity int
-	Amount   int
+	Cost     *int
+	Units    int64
+	LastSale string
 }
 
-type ExpenseItemsData struct {
-	Id   int
-	Name string
+// HourlySalesData holds sales totals of hour (0-23) of ISO weekday (1 is Monday, 7 is Sunday).
+type HourlySalesData struct {
+	Weekday    int
+	Hour       int
+	Revenue    int64
+	SalesCount int64
 }
 
-type BestItemsData struct {
-	Name         string
-	TotalRevenue int
+// HeatmapData holds sales totals by weekday and hour, the first index is weekday starting
+// from Monday.
+type HeatmapData struct {
+	From       string
+	To         string
+	Revenue    [7][24]int64
+	SalesCount [7][24]int64
+}
+
+type TrendParams struct {
+	Period period.Period
+	Bucket period.Bucket
 }
 
+// TrendPointData holds sales totals of bucket starting at Start (YYYY-MM-DD).
+type TrendPointData struct {
+	Start      string
+	Revenue    int64
+	Units      int64
+	SalesCount int64
+}
+
+// TimeLayout is format dates of sales and charges are passed to repositories in.
+const TimeLayout = "2006-01-02 15:04:05"
+
 type SalesData struct {
+	Id           int    `json:"id"`
+	Amount       int    `json:"amount"`
+	Quantity     int    `json:"quantity"`
+	SaleDate     string `json:"sale_date"`
+	WarehousesId int    `json:"warehouses_id"`
+	Customer     string `json:"customer"`
+	Version      int    `json:"version"`
+}
+
+type ChargesData struct {
+	Id            int    `json:"id"`
+	Amount        int    `json:"amount"`
+	ChargeDate    string `json:"charge_date"`
+	ExpenseItemId int    `json:"expense_item_id"`
+	Version       int    `json:"version"`
+}
+
+// Names of the handbooks and journals which support soft delete.
+const (
+	WarehousesTable   = "warehouses"
+	ExpenseItemsTable = "expense_items"
+	ChargesTable      = "charges"
+	SalesTable        = "sales"
+)
+
+type TrashItemData struct {
+	Table       string
+	Id          int
+	Description string
+	DeletedAt   string
+}
+
+type UserData struct {
+	Id      int64
+	Login   string
+	IsAdmin bool
+}
+
+// Actions recorded to audit log.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+type AuditRecordData struct {
+	Id        int64
+	UserLogin string
+	ChangedAt string
+	Table     string
+	RecordId  int
+	Action    string
+	Before    []byte
+	After     []byte
+}
+
+// ArchivedReportData is report saved to archive when it was generated. Content is loaded only
+// when the report is downloaded.
+type ArchivedReportData struct {
+	Id         int64
+	ReportType string
+	Params     string
+	Format     string
+	FileName   string
+	UserLogin  string
+	CreatedAt  string
+	Content    []byte
+}
+
+// AuditFilterData narrows audit log. Zero values mean no filtering by the field.
+type AuditFilterData struct {
+	UserLogin string
+	Table     string
+	RecordId  int
+	From      string
+	To        string
+}
+
+// ABC classes by share of revenue and XYZ classes by variation of demand.
+const (
+	ClassA = "A"
+	ClassB = "B"
+	ClassC = "C"
+	ClassX = "X"
+	ClassY = "Y"
+	ClassZ = "Z"
+)
+
+var (
+	AbcClasses = []string{ClassA, ClassB, ClassC}
+	XyzClasses = []string{ClassX, ClassY, ClassZ}
+)
+
+type AbcXyzParams struct {
+	Period period.Period
+	// Bucket is the step demand variation is measured with.
+	Bucket period.Bucket
+}
+
+// AbcXyzItemData holds classification of warehouses item. Share values are fractions of total revenue,
+// Variation is coefficient of variation of units sold per bucket.
+type AbcXyzItemData struct {
+	Id              int
+	Name            string
+	Revenue         int64
+	Units           int64
+	Share           float64
+	CumulativeShare float64
+	Variation       float64
+	AbcClass        string
+	XyzClass        string
+}
+
+// AbcXyzReportData holds classified items ordered by revenue and counts of items in each
+// class pair, Matrix[abc][xyz] follows order of AbcClasses and XyzClasses.
+type AbcXyzReportData struct {
+	Items  []*AbcXyzItemData
+	Matrix [3][3]int
+}
+
+// Methods of demand forecasting.
+const (
+	ForecastMovingAverage        = "moving_average"
+	ForecastExponentialSmoothing = "exponential_smoothing"
+)
+
+var ForecastMethods = []string{ForecastMovingAverage, ForecastExponentialSmoothing}
+
+// ForecastParams sets up weekly demand forecast. All durations are in weeks.
+type ForecastParams struct {
+	Method string
+	// Horizon is number of weeks to forecast starting from the current one.
+	Horizon int
+	// History is number of full weeks of sales the forecast is built from.
+	History int
+	// LeadTime is number of weeks between ordering items and their arrival.
+	LeadTime int
+	// SafetyWeeks is average weekly demand kept in stock on top of forecast.
+	SafetyWeeks int
+}
+
+type ForecastItemData struct {
+	Id    int
+	Name  string
+	Stock int
+	// Demand holds forecast units for each week of horizon.
+	Demand []float64
+	// Seasonal is true when forecast accounts for yearly seasonality.
+	Seasonal        bool
+	TotalDemand     float64
+	ReorderQuantity int
+	// StockOutDate is YYYY-MM-DD when stock is expected to run out. It's empty when stock
+	// lasts longer than horizon.
+	StockOutDate string
+	// OrderByDate is YYYY-MM-DD of the latest order which arrives before stock runs out.
+	OrderByDate string
+}
+
+type ForecastReportData struct {
+	// WeekStarts holds YYYY-MM-DD of the first day of each week of horizon.
+	WeekStarts []string
+	Items      []*ForecastItemData
+}
+
+type TurnoverParams struct {
+	Period period.Period
+	// DeadDays is number of days without sales after which item is considered dead stock.
+	DeadDays int
+}
+
+// TurnoverItemData holds turnover of warehouses item over period. Turnover is units sold per unit
+// in stock, DaysOfSupply is number of days current stock lasts at period's sales rate. Both are
+// +Inf when there's nothing to divide by. TiedUpValue is nil when cost of item is unknown.
+type TurnoverItemData struct {
 	Id           int
-	Amount       int
+	Name         string
 	Quantity     int
-	SaleDate     string
+	Cost         *int
+	TiedUpValue  *int64
+	Units        int64
+	Turnover     float64
+	DaysOfSupply float64
+	LastSale     string
+	Dead         bool
+}
+
+// TurnoverReportData holds items with dead stock first, then by tied-up value.
+type TurnoverReportData struct {
+	Items       []*TurnoverItemData
+	TiedUpValue int64
+	DeadValue   int64
+}
+
+// DashboardParams sets up dashboard at moment Now. Items with quantity below LowStock are
+// counted as low in stock.
+type DashboardParams struct {
+	Now      time.Time
+	LowStock int
+}
+
+// DashboardData holds key figures of the shop. Day figures are of the day containing Now,
+// Sparkline holds daily revenue of the last days ending with that day.
+type DashboardData struct {
+	Day            string
+	DayRevenue     int64
+	DaySalesCount  int64
+	AverageReceipt int64
+	MonthNetProfit int64
+	LowStockCount  int
+	TopProducts    []*RankingItemData
+	Sparkline      []*TrendPointData
+}
+
+// Frequencies of scheduled reports.
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+var Frequencies = []string{FrequencyDaily, FrequencyWeekly, FrequencyMonthly}
+
+// FormatPDF is format of scheduled reports besides export formats.
+const FormatPDF = "pdf"
+
+// Parameters of scheduled CSV files, the rest of schedule's parameters belong to its report.
+const (
+	ParamDelimiter = "delimiter"
+	ParamEncoding  = "encoding"
+)
+
+// ScheduleData describes registered report generated periodically to Destination directory. Day is
+// weekday (1 is Monday) of weekly schedules and day of month (1-28) of monthly ones, reports are
+// generated at Hour o'clock. Params hold parameters of report, its periods are relative ones.
+type ScheduleData struct {
+	Id          int
+	Name        string
+	ReportType  string
+	Params      map[string]string
+	Format      string
+	Destination string
+	Frequency   string
+	Day         int
+	Hour        int
+	NextRunAt   time.Time
+	CreatedBy   string
+}
+
+// Statuses of schedule runs.
+const (
+	RunSucceeded = "ok"
+	RunFailed    = "failed"
+)
+
+// ScheduleRunData is record of schedule's run log. Times are YYYY-MM-DD HH:MM:SS.
+type ScheduleRunData struct {
+	Id           int64
+	ScheduleId   int
+	ScheduleName string
+	StartedAt    string
+	FinishedAt   string
+	Status       string
+	FilePath     string
+	Error        string
+}
+
+// Measures of pivot reports. Count is number of sales and charges records.
+const (
+	MeasureRevenue      = "revenue"
+	MeasureQuantity     = "quantity"
+	MeasureChargeAmount = "charge_amount"
+	MeasureCount        = "count"
+)
+
+var PivotMeasures = []string{MeasureRevenue, MeasureQuantity, MeasureChargeAmount, MeasureCount}
+
+// Dimensions of pivot reports. Expense item is known for charges only, product, category, location
+// and customer are known for sales only, dates are known for both.
+const (
+	DimensionProduct     = "product"
+	DimensionCategory    = "category"
+	DimensionLocation    = "location"
+	DimensionCustomer    = "customer"
+	DimensionExpenseItem = "expense_item"
+	DimensionDay         = "day"
+	DimensionWeek        = "week"
+	DimensionMonth       = "month"
+)
+
+var PivotDimensions = []string{DimensionProduct, DimensionCategory, DimensionLocation, DimensionCustomer,
+	DimensionExpenseItem, DimensionDay, DimensionWeek, DimensionMonth}
+
+// Operators of pivot filters. Contains ignores case.
+const (
+	FilterEquals    = "equals"
+	FilterNotEquals = "not_equals"
+	FilterContains  = "contains"
+)
+
+var FilterOperators = []string{FilterEquals, FilterNotEquals, FilterContains}
+
+// PivotFilter keeps records whose value of dimension matches Value.
+type PivotFilter struct {
+	Dimension string `json:"dimension"`
+	Operator  string `json:"operator"`
+	Value     string `json:"value"`
+}
+
+// PivotDefinition is pivot report built by user. Rows are dimensions of rows, subtotals are counted
+// for each of them but the last one. Column is optional dimension spread over columns.
+type PivotDefinition struct {
+	Measures []string      `json:"measures"`
+	Rows     []string      `json:"rows"`
+	Column   string        `json:"column"`
+	Filters  []PivotFilter `json:"filters"`
+}
+
+// PivotParams are definition of pivot report and its period, the latter is chosen by user every time
+// like periods of ranking presets.
+type PivotParams struct {
+	Period     period.Period
+	Definition PivotDefinition
+}
+
+// PivotGroupData holds measures of records grouped by values of row dimensions followed by value of
+// column dimension if any. Values are in order of definition's measures.
+type PivotGroupData struct {
+	Keys   []string
+	Values []int64
+}
+
+// PivotRowData is row of pivot table. Keys of detail rows are values of all row dimensions, Level is
+// their number. Subtotal rows have keys of their group only and Level of its depth, grand total has
+// Level 0. Values[c][m] is measure m in column c, the last column is total of row.
+type PivotRowData struct {
+	Keys   []string
+	Level  int
+	Values [][]int64
+}
+
+// PivotReportData is pivot table. Columns are values of column dimension, rows are ordered by keys
+// and every group is followed by its subtotal, grand total goes last.
+type PivotReportData struct {
+	Definition PivotDefinition
+	Columns    []string
+	Rows       []*PivotRowData
+}
+
+// SavedPivotData is pivot definition saved by user under a name.
+type SavedPivotData struct {
+	Id         int
+	Name       string
+	Definition PivotDefinition
+	UserLogin  string
+	CreatedAt  string
+}
+
+// Kinds of anomalies.
+const (
+	AnomalyHighCharge      = "high_charge"
+	AnomalyLowRevenueDay   = "low_revenue_day"
+	AnomalyNegativeMargin  = "negative_margin"
+	AnomalyUnderpricedSale = "underpriced_sale"
+)
+
+// DefaultAnomalyThreshold is robust z-score records are usually flagged above.
+const DefaultAnomalyThreshold = 3.5
+
+// AnomalyParams set up anomaly detection. Records whose robust z-score exceeds Threshold are flagged,
+// days are checked up to the day before Now. Acknowledged anomalies are returned too when
+// Acknowledged is set.
+type AnomalyParams struct {
+	Threshold    float64
+	Now          time.Time
+	Acknowledged bool
+}
+
+// AnomalyData is unusual record or day. Key identifies it among anomalies of its kind, Table and
+// RecordId point to flagged record, they're empty for days. Expected is the usual level Value is
+// compared with, Score is robust z-score of Value.
+type AnomalyData struct {
+	Kind           string
+	Key            string
+	Table          string
+	RecordId       int
+	Day            string
+	Subject        string
+	Value          int64
+	Expected       int64
+	Score          float64
+	Description    string
+	AcknowledgedBy string
+	AcknowledgedAt string
+}
+
+// AnomalyAckData is acknowledgement of anomaly. It holds Value acknowledged, so that anomaly is
+// flagged again when its record changes.
+type AnomalyAckData struct {
+	Kind           string
+	Key            string
+	Value          int64
+	UserLogin      string
+	AcknowledgedAt string
+}
+
+// ChargeHistoryData is charge with name of its expense item. Day is YYYY-MM-DD.
+type ChargeHistoryData struct {
+	Id            int
+	ExpenseItemId int
+	ExpenseItem   string
+	Amount        int64
+	Day           string
+}
+
+// SaleHistoryData is sale with current price and cost of its item. Day is YYYY-MM-DD.
+type SaleHistoryData struct {
+	Id           int
 	WarehousesId int
+	Product      string
+	Amount       int64
+	Quantity     int64
+	Price        int64
+	Cost         *int
+	Day          string
 }
 
-type ChargesData struct {
+// AggregatesData holds number of rows of daily aggregates after they were rebuilt.
+type AggregatesData struct {
+	SalesDays   int64
+	ChargesDays int64
+}
+
+// ImportField is field of imported records mapped to a column of CSV file.
+type ImportField struct {
+	Key      string
+	Required bool
+}
+
+// Fields referencing records by name, they're alternatives of fields with ids.
+const (
+	ImportFieldExpenseItem = "expense_item"
+	ImportFieldWarehouse   = "warehouse"
+)
+
+// ImportTables are tables records are imported to.
+var ImportTables = []string{WarehousesTable, ExpenseItemsTable, ChargesTable, SalesTable}
+
+// ImportFields are fields of records of each imported table. Charges and sales reference their
+// expense item and warehouses item either by name or by id.
+var ImportFields = map[string][]ImportField{
+	WarehousesTable: {
+		{Key: "name", Required: true},
+		{Key: "quantity", Required: true},
+		{Key: "amount", Required: true},
+		{Key: "cost"},
+		{Key: "category"},
+		{Key: "location"},
+	},
+	ExpenseItemsTable: {
+		{Key: "name", Required: true},
+	},
+	ChargesTable: {
+		{Key: "amount", Required: true},
+		{Key: "charge_date", Required: true},
+		{Key: ImportFieldExpenseItem},
+		{Key: "expense_item_id"},
+	},
+	SalesTable: {
+		{Key: "amount", Required: true},
+		{Key: "quantity", Required: true},
+		{Key: "sale_date", Required: true},
+		{Key: ImportFieldWarehouse},
+		{Key: "warehouses_id"},
+		{Key: "customer"},
+	},
+}
+
+// ImportParams describe import of rows of CSV file to Table. Mapping maps fields to headers of
+// columns, unmapped optional fields are left empty. Dates are parsed with Go layout DateLayout, it's
+// detected from the file when empty. Nothing is written in dry run.
+type ImportParams struct {
+	Table      string
+	Headers    []string
+	Rows       [][]string
+	Mapping    map[string]string
+	DateLayout string
+	DryRun     bool
+}
+
+// ImportRowError is error of row of imported file. Line is number of line in file, header is line 1.
+type ImportRowError struct {
+	Line    int
+	Field   string
+	Message string
+}
+
+// ImportResultData is result of import. Rows are imported all at once or not at all, so Imported is
+// zero when there are errors. Copied tells that rows were loaded by COPY.
+type ImportResultData struct {
+	Rows       int
+	Imported   int
+	DateLayout string
+	Copied     bool
+	Errors     []*ImportRowError
+}
+
+// UsersTable is table of users. Backups keep only logins, hashes of passwords and roles of users.
+const UsersTable = "users"
+
+// BackupTables are tables saved to backups, in order they are restored in. Daily aggregates aren't
+// saved, they're rebuilt after restore.
+var BackupTables = []string{
+	ExpenseItemsTable,
+	WarehousesTable,
+	ChargesTable,
+	SalesTable,
+	"audit_log",
+	"report_archive",
+	"report_schedules",
+	"report_schedule_runs",
+	"pivot_definitions",
+	"anomaly_acknowledgements",
+	"exchange_ids",
+	"bank_rules",
+	"bank_payments",
+}
+
+// BackupParams set up backup. Users are left out unless Users is set.
+type BackupParams struct {
+	Users bool
+}
+
+// RestoreParams set up restore. Backup is restored only into database without shop data unless
+// Replace is set, then the data is deleted first. Users are added when Users is set and backup
+// has them, existing logins are kept as they are.
+type RestoreParams struct {
+	Replace bool
+	Users   bool
+}
+
+type BackupTableData struct {
+	Name string
+	Rows int64
+}
+
+// BackupData describes backup that was made or restored.
+type BackupData struct {
+	SchemaVersion int
+	CreatedAt     string
+	Tables        []*BackupTableData
+}
+
+// SeedParams set up generation of demo data. Sales are generated for Days days before Now, about
+// SalesPerDay a day on average. The same Seed gives the same data.
+type SeedParams struct {
+	Seed        int64
+	Items       int
+	Days        int
+	SalesPerDay int
+	Users       int
+	Now         time.Time
+	// Append allows to seed database which already has warehouses items.
+	Append bool
+}
+
+// SeedUserData is generated user. Password is given only to be shown once after seeding.
+type SeedUserData struct {
+	Login    string
+	Password string
+	IsAdmin  bool
+}
+
+// SeedData holds numbers of generated records. Users lists only created users, users with taken
+// logins are skipped.
+type SeedData struct {
+	Items        int
+	ExpenseItems int
+	Charges      int
+	Sales        int
+	Users        []*SeedUserData
+}
+
+// Kinds of CommerceML files exchanged with 1C.
+const (
+	ExchangeCatalog = "catalog"
+	ExchangeOffers  = "offers"
+	ExchangeSales   = "sales"
+)
+
+var ExchangeKinds = []string{ExchangeCatalog, ExchangeOffers, ExchangeSales}
+
+// ExchangeIdData maps id of 1C object to record of Table.
+type ExchangeIdData struct {
+	Table      string
+	ExternalId string
+	RecordId   int
+}
+
+// ExchangeExportParams set up export of CommerceML file. Period is used for sales only.
+type ExchangeExportParams struct {
+	Kind   string
+	Period period.Period
+	Now    time.Time
+}
+
+// ExchangeResultData holds numbers of records of imported CommerceML file. Nothing is saved when
+// there are Errors.
+type ExchangeResultData struct {
+	Created int
+	Updated int
+	Skipped int
+	Errors  []string
+}
+
+// Formats of bank statements.
+const (
+	StatementClientBank = "1c"
+	StatementCSV        = "csv"
+)
+
+// Fields of payments mapped to columns of CSV bank statement. Amount is negative for outgoing
+// payments, alternatively outgoing payments are read from debit column.
+const (
+	StatementFieldDate         = "date"
+	StatementFieldAmount       = "amount"
+	StatementFieldDebit        = "debit"
+	StatementFieldCounterparty = "counterparty"
+	StatementFieldDescription  = "description"
+	StatementFieldNumber       = "number"
+)
+
+var StatementFields = []ImportField{
+	{Key: StatementFieldDate, Required: true},
+	{Key: StatementFieldAmount},
+	{Key: StatementFieldDebit},
+	{Key: StatementFieldCounterparty},
+	{Key: StatementFieldDescription},
+	{Key: StatementFieldNumber},
+}
+
+// BankPaymentData is payment of bank statement. Date is YYYY-MM-DD, Amount is rounded to whole
+// units. Key identifies payment among payments of all statements.
+type BankPaymentData struct {
+	Key          string
+	Number       string
+	Date         string
+	Amount       int
+	Counterparty string
+	Description  string
+	Outgoing     bool
+}
+
+// BankRuleData matches payments to expense item. Payment matches when its counterparty contains
+// Counterparty and its description contains Description, case ignored. Empty patterns match any
+// payment.
+type BankRuleData struct {
 	Id            int
-	Amount        int
-	ChargeDate    string
+	Counterparty  string
+	Description   string
 	ExpenseItemId int
+	ExpenseItem   string
+}
+
+// StatementLineData is outgoing payment under review. ExpenseItemId is suggested by rule RuleId
+// and may be changed by user, zero means no expense item. Duplicate payments were imported before.
+// Only Confirmed lines are imported.
+type StatementLineData struct {
+	Payment       *BankPaymentData
+	ExpenseItemId int
+	RuleId        int
+	Duplicate     bool
+	Confirmed     bool
+}
+
+// StatementReviewData holds outgoing payments of statement. Incoming payments are only counted.
+type StatementReviewData struct {
+	Lines    []*StatementLineData
+	Incoming int
+}
+
+// StatementResultData holds numbers of charges created from statement and of lines skipped as
+// duplicates or not confirmed.
+type StatementResultData struct {
+	Created    int
+	Duplicates int
+	Skipped    int
 }
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"automatedShop/internal/commerceml"
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"unicode/utf8"
+)
+
+// Limits of lengths of fields of imported records.
+const (
+	maxNameLen       = 20
+	maxCategoryLen   = 30
+	maxCustomerLen   = 100
+	maxExternalIdLen = 100
+)
+
+// errRollback aborts transaction of import which has errors.
+var errRollback = errors.New("import has errors")
+
+// ExchangeService exchanges catalog, offers and sales with 1C in CommerceML files. Ids of 1C
+// objects are kept for records they were imported to or exported from, so that files can be
+// exchanged again and again without duplicates.
+type ExchangeService struct {
+	l            *slog.Logger
+	ShopRepo     repository.IShopRepository
+	ExchangeRepo repository.IExchangeRepository
+	ShopService  IShopService
+	Transactor   repository.ITransactor
+}
+
+// IShopService is the part of shop service imported records are created and changed through, so
+// that they're recorded in audit log.
+type IShopService interface {
+	CreateWarehousesItem(context.Context, *dto.WarehousesData) error
+	UpdateWarehousesItem(context.Context, *dto.WarehousesData) error
+	CreateSalesItem(context.Context, *dto.SalesData) error
+}
+
+func NewExchangeService(shopRepo repository.IShopRepository, exchangeRepo repository.IExchangeRepository,
+	shopService IShopService, transactor repository.ITransactor) *ExchangeService {
+	var l *slog.Logger
+
+	return &ExchangeService{
+		l:            l,
+		ShopRepo:     shopRepo,
+		ExchangeRepo: exchangeRepo,
+		ShopService:  shopService,
+		Transactor:   transactor,
+	}
+}
+
+// Export writes CommerceML file of given kind to w. Records exported for the first time get new
+// ids, which are saved.
+func (s *ExchangeService) Export(ctx context.Context, w io.Writer, params *dto.ExchangeExportParams) error {
+	const op = "ExchangeService.Export"
+
+	if !slices.Contains(dto.ExchangeKinds, params.Kind) {
+		return fmt.Errorf("error occurred in: %v: %w: unknown kind %q", op, customErr.ErrInvalidExchange, params.Kind)
+	}
+
+	doc := &commerceml.Document{
+		SchemaVersion: commerceml.SchemaVersion,
+		CreatedAt:     params.Now.Format(exchangeTimeLayout),
+	}
+	err := s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		switch params.Kind {
+		case dto.ExchangeCatalog:
+			doc.Classifier, doc.Catalog, err = s.exportCatalog(ctx)
+		case dto.ExchangeOffers:
+			doc.Offers, err = s.exportOffers(ctx)
+		case dto.ExchangeSales:
+			doc.Orders, err = s.exportSales(ctx, params)
+		}
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	if err = commerceml.Write(w, doc); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// Import reads CommerceML file and imports its catalog, offers and documents in one transaction.
+// Errors of products, offers and documents are returned in result and nothing is saved then,
+// errors of the file as a whole are returned as error.
+func (s *ExchangeService) Import(ctx context.Context, r io.Reader) (*dto.ExchangeResultData, error) {
+	const op = "ExchangeService.Import"
+
+	doc, err := commerceml.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w: %v", op, customErr.ErrInvalidExchange, err)
+	}
+	if doc.Catalog == nil && doc.Offers == nil && len(doc.Orders) == 0 {
+		return nil, fmt.Errorf("error occurred in: %v: %w: file has no catalog, offers or documents", op,
+			customErr.ErrInvalidExchange)
+	}
+
+	res := &dto.ExchangeResultData{}
+	err = s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if doc.Catalog != nil {
+			if err := s.importCatalog(ctx, doc.Classifier, doc.Catalog, res); err != nil {
+				return err
+			}
+		}
+		if doc.Offers != nil {
+			if err := s.importOffers(ctx, doc.Offers, res); err != nil {
+				return err
+			}
+		}
+		if len(doc.Orders) > 0 {
+			if err := s.importOrders(ctx, doc.Orders, res); err != nil {
+				return err
+			}
+		}
+
+		if len(res.Errors) > 0 {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// externalIds returns ids of 1C objects mapped to records of table by record ids.
+func (s *ExchangeService) externalIds(ctx context.Context, table string) (map[int]string, error) {
+	ids, err := s.ExchangeRepo.ShowExchangeIds(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int]string, len(ids))
+	for _, id := range ids {
+		res[id.RecordId] = id.ExternalId
+	}
+
+	return res, nil
+}
+
+// recordIds returns ids of records of table by ids of 1C objects mapped to them.
+func (s *ExchangeService) recordIds(ctx context.Context, table string) (map[string]int, error) {
+	ids, err := s.ExchangeRepo.ShowExchangeIds(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]int, len(ids))
+	for _, id := range ids {
+		res[id.ExternalId] = id.RecordId
+	}
+
+	return res, nil
+}
+
+func (s *ExchangeService) saveId(ctx context.Context, table, externalId string, recordId int) error {
+	return s.ExchangeRepo.SaveExchangeId(ctx, &dto.ExchangeIdData{
+		Table:      table,
+		ExternalId: externalId,
+		RecordId:   recordId,
+	})
+}
+
+// cut shortens text to n characters.
+func cut(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+
+	return string([]rune(text)[:n])
+}
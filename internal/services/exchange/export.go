@@ -0,0 +1,221 @@
+package services
+
+import (
+	"automatedShop/internal/commerceml"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// exchangeTimeLayout is format of dates of CommerceML files.
+const exchangeTimeLayout = "2006-01-02T15:04:05"
+
+// Currency of prices and documents.
+const currency = "RUB"
+
+// retailPriceType is name of price type items are sold at.
+const retailPriceType = "Розничная"
+
+// retailCustomer is counterparty of sales without customer.
+const retailCustomer = "Розничный покупатель"
+
+// Ids of objects the shop has only one of. They're the same in every exported file.
+var (
+	classifierId = commerceml.StableId("classifier")
+	catalogId    = commerceml.StableId("catalog")
+	offersId     = commerceml.StableId("offers")
+	priceTypeId  = commerceml.StableId("price type:" + retailPriceType)
+)
+
+func groupId(category string) string {
+	return commerceml.StableId("group:" + category)
+}
+
+func customerId(customer string) string {
+	return commerceml.StableId("customer:" + customer)
+}
+
+// itemIds returns ids of 1C products of items by item ids. Items which have none get new ones.
+func (s *ExchangeService) itemIds(ctx context.Context, items []*dto.WarehousesData) (map[int]string, error) {
+	ids, err := s.externalIds(ctx, dto.WarehousesTable)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if _, ok := ids[item.Id]; ok {
+			continue
+		}
+		ids[item.Id] = commerceml.NewId()
+		if err = s.saveId(ctx, dto.WarehousesTable, ids[item.Id], item.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// exportCatalog makes catalog of warehouses items. Categories become groups of classifier.
+func (s *ExchangeService) exportCatalog(ctx context.Context) (*commerceml.Classifier, *commerceml.Catalog, error) {
+	items, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, err := s.itemIds(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	classifier := &commerceml.Classifier{Id: classifierId, Name: "Классификатор (Основной каталог товаров)"}
+	catalog := &commerceml.Catalog{
+		OnlyChanges:  "false",
+		Id:           catalogId,
+		ClassifierId: classifierId,
+		Name:         "Основной каталог товаров",
+	}
+
+	groups := make(map[string]bool)
+	for _, item := range items {
+		product := &commerceml.Product{Id: ids[item.Id], Name: item.Name, BaseUnit: commerceml.Piece}
+		if item.Category != "" {
+			product.Groups = &commerceml.GroupIds{Ids: []string{groupId(item.Category)}}
+			if !groups[item.Category] {
+				groups[item.Category] = true
+				classifier.Groups = append(classifier.Groups, &commerceml.Group{
+					Id:   groupId(item.Category),
+					Name: item.Category,
+				})
+			}
+		}
+		catalog.Products = append(catalog.Products, product)
+	}
+	sort.Slice(classifier.Groups, func(i, j int) bool {
+		return classifier.Groups[i].Name < classifier.Groups[j].Name
+	})
+
+	return classifier, catalog, nil
+}
+
+// exportOffers makes offers with current prices and stock of warehouses items.
+func (s *ExchangeService) exportOffers(ctx context.Context) (*commerceml.OfferPackage, error) {
+	items, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.itemIds(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &commerceml.OfferPackage{
+		OnlyChanges:  "false",
+		Id:           offersId,
+		Name:         "Пакет предложений (Основной каталог товаров)",
+		CatalogId:    catalogId,
+		ClassifierId: classifierId,
+		PriceTypes:   []*commerceml.PriceType{{Id: priceTypeId, Name: retailPriceType, Currency: currency}},
+	}
+	for _, item := range items {
+		pkg.Offers = append(pkg.Offers, &commerceml.Offer{
+			Id:       ids[item.Id],
+			Name:     item.Name,
+			BaseUnit: commerceml.Piece,
+			Prices: []*commerceml.Price{{
+				Presentation: fmt.Sprintf("%d %s за %s", item.Amount, currency, commerceml.Piece.Name),
+				PriceTypeId:  priceTypeId,
+				PerUnit:      commerceml.FormatNumber(int64(item.Amount)),
+				Currency:     currency,
+				Unit:         commerceml.Piece.Name,
+				Ratio:        "1",
+			}},
+			Quantity: strconv.Itoa(item.Quantity),
+		})
+	}
+
+	return pkg, nil
+}
+
+// exportSales makes document of sale of goods of each sale of period.
+func (s *ExchangeService) exportSales(ctx context.Context, params *dto.ExchangeExportParams) ([]*commerceml.Order, error) {
+	sales, err := s.ExchangeRepo.ShowSales(ctx, params.Period.From, params.Period.To)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.saleItems(ctx, sales)
+	if err != nil {
+		return nil, err
+	}
+	itemIds, err := s.itemIds(ctx, slices.Collect(maps.Values(items)))
+	if err != nil {
+		return nil, err
+	}
+	saleIds, err := s.externalIds(ctx, dto.SalesTable)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*commerceml.Order, 0, len(sales))
+	for _, sale := range sales {
+		if _, ok := saleIds[sale.Id]; !ok {
+			saleIds[sale.Id] = commerceml.NewId()
+			if err = s.saveId(ctx, dto.SalesTable, saleIds[sale.Id], sale.Id); err != nil {
+				return nil, err
+			}
+		}
+
+		customer := sale.Customer
+		if customer == "" {
+			customer = retailCustomer
+		}
+		sum := commerceml.FormatNumber(int64(sale.Amount) * int64(sale.Quantity))
+		// sale dates are YYYY-MM-DD HH:MM:SS
+		orders = append(orders, &commerceml.Order{
+			Id:        saleIds[sale.Id],
+			Number:    strconv.Itoa(sale.Id),
+			Date:      sale.SaleDate[:10],
+			Operation: commerceml.OperationSale,
+			Role:      commerceml.RoleSeller,
+			Currency:  currency,
+			Rate:      "1",
+			Sum:       sum,
+			Counterparties: []*commerceml.Counterparty{{
+				Id:   customerId(customer),
+				Name: customer,
+				Role: commerceml.RoleBuyer,
+			}},
+			Time: sale.SaleDate[11:],
+			Products: []*commerceml.OrderProduct{{
+				Id:       itemIds[sale.WarehousesId],
+				Name:     items[sale.WarehousesId].Name,
+				BaseUnit: commerceml.Piece,
+				PerUnit:  commerceml.FormatNumber(int64(sale.Amount)),
+				Quantity: strconv.Itoa(sale.Quantity),
+				Sum:      sum,
+			}},
+		})
+	}
+
+	return orders, nil
+}
+
+// saleItems returns warehouses items of sales by ids, deleted items included.
+func (s *ExchangeService) saleItems(ctx context.Context, sales []*dto.SalesData) (map[int]*dto.WarehousesData, error) {
+	items := make(map[int]*dto.WarehousesData)
+	for _, sale := range sales {
+		if _, ok := items[sale.WarehousesId]; ok {
+			continue
+		}
+		item, err := s.ShopRepo.GetWarehousesItem(ctx, sale.WarehousesId)
+		if err != nil {
+			return nil, err
+		}
+		items[item.Id] = item
+	}
+
+	return items, nil
+}
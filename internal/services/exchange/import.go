@@ -0,0 +1,410 @@
+package services
+
+import (
+	"automatedShop/internal/commerceml"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// itemIndex holds warehouses items and ids of 1C products mapped to them.
+type itemIndex struct {
+	items  map[int]*dto.WarehousesData
+	mapped map[string]int
+}
+
+func (s *ExchangeService) newItemIndex(ctx context.Context) (*itemIndex, error) {
+	items, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := s.recordIds(ctx, dto.WarehousesTable)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &itemIndex{items: make(map[int]*dto.WarehousesData, len(items)), mapped: mapped}
+	for _, item := range items {
+		index.items[item.Id] = item
+	}
+
+	return index, nil
+}
+
+// item returns item 1C product is mapped to or nil. Deleted items aren't returned.
+func (x *itemIndex) item(productId string) *dto.WarehousesData {
+	id, ok := x.mapped[productId]
+	if !ok {
+		return nil
+	}
+
+	return x.items[id]
+}
+
+// unmappedByName returns items no 1C product is mapped to by lowercase names.
+func (x *itemIndex) unmappedByName() map[string][]*dto.WarehousesData {
+	mapped := make(map[int]bool, len(x.mapped))
+	for _, id := range x.mapped {
+		mapped[id] = true
+	}
+
+	res := make(map[string][]*dto.WarehousesData)
+	for _, item := range x.items {
+		if !mapped[item.Id] {
+			key := strings.ToLower(item.Name)
+			res[key] = append(res[key], item)
+		}
+	}
+
+	return res
+}
+
+// importCatalog creates and renames warehouses items after products of catalog. Product is
+// matched with item it was imported to or exported from before, otherwise with the only unmapped
+// item of the same name. Products without match become new items without price and stock. Items
+// missing from catalog are kept as they are.
+func (s *ExchangeService) importCatalog(ctx context.Context, classifier *commerceml.Classifier,
+	catalog *commerceml.Catalog, res *dto.ExchangeResultData) error {
+	groups := make(map[string]string)
+	if classifier != nil {
+		collectGroups(classifier.Groups, groups)
+	}
+
+	index, err := s.newItemIndex(ctx)
+	if err != nil {
+		return err
+	}
+	byName := index.unmappedByName()
+
+	for i, product := range catalog.Products {
+		if err := checkExternalId(product.Id); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("product %d: %v", i+1, err))
+			continue
+		}
+		name := cut(strings.TrimSpace(product.Name), maxNameLen)
+		if name == "" {
+			res.Errors = append(res.Errors, fmt.Sprintf("product %s: name is empty", product.Id))
+			continue
+		}
+
+		item := index.item(product.Id)
+		if item == nil {
+			if matches := byName[strings.ToLower(name)]; len(matches) == 1 {
+				item = matches[0]
+				delete(byName, strings.ToLower(name))
+			}
+		}
+
+		// category is changed only when group of product is known
+		category, known := "", product.Groups == nil || len(product.Groups.Ids) == 0
+		if !known {
+			category, known = groups[product.Groups.Ids[0]]
+			category = cut(category, maxCategoryLen)
+		}
+
+		if item == nil {
+			item = &dto.WarehousesData{Name: name, Category: category}
+			if err = s.ShopService.CreateWarehousesItem(ctx, item); err != nil {
+				return err
+			}
+			index.items[item.Id] = item
+			res.Created++
+		} else {
+			updated := *item
+			updated.Name = name
+			if known {
+				updated.Category = category
+			}
+			if updated != *item {
+				if err = s.updateItem(ctx, item, &updated); err != nil {
+					return err
+				}
+				res.Updated++
+			} else {
+				res.Skipped++
+			}
+		}
+
+		if index.mapped[product.Id] != item.Id {
+			if err = s.saveId(ctx, dto.WarehousesTable, product.Id, item.Id); err != nil {
+				return err
+			}
+			index.mapped[product.Id] = item.Id
+		}
+	}
+
+	return nil
+}
+
+// collectGroups adds names of groups and their subgroups to names by ids.
+func collectGroups(groups []*commerceml.Group, names map[string]string) {
+	for _, group := range groups {
+		names[group.Id] = strings.TrimSpace(group.Name)
+		if group.Subgroups != nil {
+			collectGroups(group.Subgroups.Groups, names)
+		}
+	}
+}
+
+// updateItem saves changes of item and records them in audit log. item is updated to new state.
+func (s *ExchangeService) updateItem(ctx context.Context, item, updated *dto.WarehousesData) error {
+	if err := s.ShopService.UpdateWarehousesItem(ctx, updated); err != nil {
+		return err
+	}
+	updated.Version++
+	*item = *updated
+
+	return nil
+}
+
+// offerChange is price and stock of product taken from its offers.
+type offerChange struct {
+	item     *dto.WarehousesData
+	amount   *int
+	quantity *int
+}
+
+// importOffers sets prices and stock of warehouses items after offers. Price of retail price type
+// is taken when package has one, otherwise the first price. Stock of offers of characteristics of
+// the same product is summed up.
+func (s *ExchangeService) importOffers(ctx context.Context, pkg *commerceml.OfferPackage,
+	res *dto.ExchangeResultData) error {
+	index, err := s.newItemIndex(ctx)
+	if err != nil {
+		return err
+	}
+	priceType := retailPriceTypeId(pkg.PriceTypes)
+
+	var changes []*offerChange
+	byItem := make(map[int]*offerChange)
+	for i, offer := range pkg.Offers {
+		if err = checkExternalId(offer.Id); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("offer %d: %v", i+1, err))
+			continue
+		}
+		productId, _, _ := strings.Cut(offer.Id, "#")
+		item := index.item(productId)
+		if item == nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("offer %s: product isn't in catalog, import catalog first",
+				offer.Id))
+			continue
+		}
+
+		change, ok := byItem[item.Id]
+		if !ok {
+			change = &offerChange{item: item}
+			byItem[item.Id] = change
+			changes = append(changes, change)
+		}
+
+		if price := offerPrice(offer, priceType); price != nil && change.amount == nil {
+			amount, err := commerceml.ParseNumber(price.PerUnit)
+			if err != nil || amount < 0 {
+				res.Errors = append(res.Errors, fmt.Sprintf("offer %s: invalid price %q", offer.Id, price.PerUnit))
+				continue
+			}
+			change.amount = &amount
+		}
+		if offer.Quantity != "" {
+			quantity, err := commerceml.ParseCount(offer.Quantity)
+			if err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("offer %s: %v", offer.Id, err))
+				continue
+			}
+			if change.quantity != nil {
+				quantity += *change.quantity
+			}
+			change.quantity = &quantity
+		}
+	}
+
+	for _, change := range changes {
+		updated := *change.item
+		if change.amount != nil {
+			updated.Amount = *change.amount
+		}
+		if change.quantity != nil {
+			updated.Quantity = *change.quantity
+		}
+		if updated == *change.item {
+			res.Skipped++
+			continue
+		}
+		if err = s.updateItem(ctx, change.item, &updated); err != nil {
+			return err
+		}
+		res.Updated++
+	}
+
+	return nil
+}
+
+func retailPriceTypeId(types []*commerceml.PriceType) string {
+	for _, t := range types {
+		if strings.EqualFold(strings.TrimSpace(t.Name), retailPriceType) {
+			return t.Id
+		}
+	}
+	if len(types) > 0 {
+		return types[0].Id
+	}
+
+	return ""
+}
+
+// offerPrice returns price of offer of given type or its first price if type is empty.
+func offerPrice(offer *commerceml.Offer, priceType string) *commerceml.Price {
+	for _, price := range offer.Prices {
+		if priceType == "" || price.PriceTypeId == priceType {
+			return price
+		}
+	}
+
+	return nil
+}
+
+// importOrders creates sale of each product of sales of goods. Documents imported before are
+// skipped, as well as orders, returns and other operations. Orders are shipped by sales of goods
+// of their own, so importing them too would count the same goods twice.
+func (s *ExchangeService) importOrders(ctx context.Context, orders []*commerceml.Order,
+	res *dto.ExchangeResultData) error {
+	index, err := s.newItemIndex(ctx)
+	if err != nil {
+		return err
+	}
+	imported, err := s.recordIds(ctx, dto.SalesTable)
+	if err != nil {
+		return err
+	}
+
+	for i, order := range orders {
+		if err = checkExternalId(order.Id); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("document %d: %v", i+1, err))
+			continue
+		}
+		if order.Operation != commerceml.OperationSale {
+			res.Skipped += len(order.Products)
+			continue
+		}
+		saleDate, err := orderDate(order)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("document %s: %v", order.Id, err))
+			continue
+		}
+		customer := orderCustomer(order)
+
+		for n, line := range order.Products {
+			// each product of document is a sale of its own
+			key := order.Id
+			if len(order.Products) > 1 {
+				key += "#" + strconv.Itoa(n+1)
+			}
+			if _, ok := imported[key]; ok {
+				res.Skipped++
+				continue
+			}
+
+			sale, err := orderSale(line, index)
+			if err != nil {
+				res.Errors = append(res.Errors, fmt.Sprintf("document %s, product %d: %v", order.Id, n+1, err))
+				continue
+			}
+			sale.SaleDate, sale.Customer = saleDate, customer
+
+			if err = s.ShopService.CreateSalesItem(ctx, sale); err != nil {
+				return err
+			}
+			if err = s.saveId(ctx, dto.SalesTable, key, sale.Id); err != nil {
+				return err
+			}
+			imported[key] = sale.Id
+			res.Created++
+		}
+	}
+
+	return nil
+}
+
+// orderSale makes sale of product of document. Price per unit is worked out of sum when it's
+// missing.
+func orderSale(line *commerceml.OrderProduct, index *itemIndex) (*dto.SalesData, error) {
+	item := index.item(line.Id)
+	if item == nil {
+		return nil, fmt.Errorf("product %s isn't in catalog, import catalog first", line.Id)
+	}
+
+	quantity, err := commerceml.ParseCount(line.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	if quantity == 0 {
+		return nil, fmt.Errorf("quantity is zero")
+	}
+
+	var amount int
+	if line.PerUnit != "" {
+		amount, err = commerceml.ParseNumber(line.PerUnit)
+	} else {
+		var sum int
+		sum, err = commerceml.ParseNumber(line.Sum)
+		amount = int(math.Round(float64(sum) / float64(quantity)))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if amount < 0 {
+		return nil, fmt.Errorf("price is negative")
+	}
+
+	return &dto.SalesData{Amount: amount, Quantity: quantity, WarehousesId: item.Id}, nil
+}
+
+// orderDate returns date of document. 1C writes date and time separately, some shops write them
+// together.
+func orderDate(order *commerceml.Order) (string, error) {
+	date := strings.TrimSpace(order.Date)
+	if !strings.Contains(date, "T") {
+		clock := strings.TrimSpace(order.Time)
+		if clock == "" {
+			clock = "00:00:00"
+		}
+		date += "T" + clock
+	}
+
+	t, err := time.ParseInLocation(exchangeTimeLayout, date, time.Local)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q", date)
+	}
+
+	return t.Format(dto.TimeLayout), nil
+}
+
+// orderCustomer returns name of buyer of document or empty string if it has none.
+func orderCustomer(order *commerceml.Order) string {
+	for _, counterparty := range order.Counterparties {
+		if counterparty.Role == commerceml.RoleBuyer {
+			name := strings.TrimSpace(counterparty.Name)
+			if name == retailCustomer {
+				return ""
+			}
+			return cut(name, maxCustomerLen)
+		}
+	}
+
+	return ""
+}
+
+func checkExternalId(id string) error {
+	switch {
+	case strings.TrimSpace(id) == "":
+		return fmt.Errorf("id is empty")
+	case len(id) > maxExternalIdLen:
+		return fmt.Errorf("id is longer than %d characters", maxExternalIdLen)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/period"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+)
+
+// Bounds of forecast parameters in weeks.
+const (
+	maxHorizon = 52
+	maxHistory = 520
+)
+
+// ForecastService forecasts demand of warehouses items from sales history and suggests
+// replenishment.
+type ForecastService struct {
+	l        *slog.Logger
+	ShopRepo repository.IShopRepository
+	now      func() time.Time
+}
+
+func NewForecastService(repo repository.IShopRepository) *ForecastService {
+	var l *slog.Logger
+
+	return &ForecastService{
+		l:        l,
+		ShopRepo: repo,
+		now:      time.Now,
+	}
+}
+
+// GetDemandForecast forecasts weekly demand of each warehouses item starting from today and
+// suggests how many items to reorder and when stock runs out.
+func (s *ForecastService) GetDemandForecast(ctx context.Context, params *dto.ForecastParams) (*dto.ForecastReportData, error) {
+	const op = "ForecastService.GetDemandForecast"
+
+	if err := validateParams(params); err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	today := period.BucketStart(s.now(), period.DayBucket)
+	from := today.AddDate(0, 0, -7*params.History)
+
+	warehouses, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	days, err := s.ShopRepo.GetItemDailySales(ctx, from, today)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	history := make(map[int][]float64, len(warehouses))
+	for _, warehouse := range warehouses {
+		history[warehouse.Id] = make([]float64, params.History)
+	}
+	for _, day := range days {
+		weeks, ok := history[day.WarehousesId]
+		if !ok {
+			continue
+		}
+		date, err := time.ParseInLocation(period.DateLayout, day.Day, today.Location())
+		if err != nil {
+			return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+		}
+		if i := daysBetween(from, date) / 7; i >= 0 && i < len(weeks) {
+			weeks[i] += float64(day.Units)
+		}
+	}
+
+	report := &dto.ForecastReportData{}
+	for week := 0; week < params.Horizon; week++ {
+		report.WeekStarts = append(report.WeekStarts, today.AddDate(0, 0, 7*week).Format(period.DateLayout))
+	}
+
+	for _, warehouse := range warehouses {
+		demand, seasonal := forecastDemand(history[warehouse.Id], params.Method, params.Horizon)
+		item := &dto.ForecastItemData{
+			Id:       warehouse.Id,
+			Name:     warehouse.Name,
+			Stock:    warehouse.Quantity,
+			Demand:   demand,
+			Seasonal: seasonal,
+		}
+		for _, units := range demand {
+			item.TotalDemand += units
+		}
+
+		safety := float64(params.SafetyWeeks) * item.TotalDemand / float64(params.Horizon)
+		if lack := math.Ceil(item.TotalDemand + safety - float64(item.Stock)); lack > 0 {
+			item.ReorderQuantity = int(lack)
+		}
+		if day, ok := stockOutDay(float64(item.Stock), demand); ok {
+			item.StockOutDate = today.AddDate(0, 0, day).Format(period.DateLayout)
+			item.OrderByDate = today.AddDate(0, 0, max(0, day-7*params.LeadTime)).Format(period.DateLayout)
+		}
+
+		report.Items = append(report.Items, item)
+	}
+
+	return report, nil
+}
+
+func validateParams(params *dto.ForecastParams) error {
+	switch {
+	case !slices.Contains(dto.ForecastMethods, params.Method):
+		return fmt.Errorf("%w: unknown forecast method %q", customErr.ErrInvalidReportParams, params.Method)
+	case params.Horizon < 1 || params.Horizon > maxHorizon:
+		return fmt.Errorf("%w: horizon must be between 1 and %d weeks", customErr.ErrInvalidReportParams, maxHorizon)
+	case params.History < 1 || params.History > maxHistory:
+		return fmt.Errorf("%w: history must be between 1 and %d weeks", customErr.ErrInvalidReportParams, maxHistory)
+	case params.LeadTime < 0 || params.LeadTime > params.Horizon:
+		return fmt.Errorf("%w: lead time must be between 0 and horizon", customErr.ErrInvalidReportParams)
+	case params.SafetyWeeks < 0:
+		return fmt.Errorf("%w: safety stock can't be negative", customErr.ErrInvalidReportParams)
+	default:
+		return nil
+	}
+}
+
+// daysBetween returns number of calendar days from one date to another regardless of DST shifts.
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
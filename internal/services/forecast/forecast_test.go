@@ -0,0 +1,211 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type fakeShopRepo struct {
+	repository.IShopRepository
+	warehouses []*dto.WarehousesData
+	days       []*dto.ItemDailySalesData
+	from, to   time.Time
+}
+
+func (r *fakeShopRepo) ShowWarehousesTable(context.Context) ([]*dto.WarehousesData, error) {
+	return r.warehouses, nil
+}
+
+func (r *fakeShopRepo) GetItemDailySales(_ context.Context, from, to time.Time) ([]*dto.ItemDailySalesData, error) {
+	r.from, r.to = from, to
+	return r.days, nil
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestMovingAverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		history []float64
+		window  int
+		want    []float64
+	}{
+		{"last window", []float64{100, 1, 2, 3, 6}, 4, []float64{3, 3}},
+		{"short history", []float64{2, 4}, 4, []float64{3, 3}},
+		{"no history", nil, 4, []float64{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := movingAverage(tt.history, tt.window, 2); !equalFloats(got, tt.want) {
+				t.Errorf("movingAverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExponentialSmoothing(t *testing.T) {
+	got := exponentialSmoothing([]float64{10, 20, 20}, 0.5, 3)
+	if want := []float64{17.5, 17.5, 17.5}; !equalFloats(got, want) {
+		t.Errorf("exponentialSmoothing() = %v, want %v", got, want)
+	}
+
+	if got := exponentialSmoothing([]float64{5, 5, 5, 5}, 0.3, 1); !equalFloats(got, []float64{5}) {
+		t.Errorf("exponentialSmoothing() of constant demand = %v, want [5]", got)
+	}
+}
+
+func TestHoltWintersRepeatsSeason(t *testing.T) {
+	season := []float64{10, 0, 5, 20}
+	var history []float64
+	for i := 0; i < 3; i++ {
+		history = append(history, season...)
+	}
+
+	got := holtWinters(history, len(season), 0.3, 0.1, 0.3, 6)
+	if want := []float64{10, 0, 5, 20, 10, 0}; !equalFloats(got, want) {
+		t.Errorf("holtWinters() = %v, want %v", got, want)
+	}
+}
+
+func TestForecastDemandSeasonality(t *testing.T) {
+	short := make([]float64, 2*seasonLength-1)
+	if _, seasonal := forecastDemand(short, dto.ForecastExponentialSmoothing, 1); seasonal {
+		t.Error("forecastDemand() is seasonal for history shorter than two seasons")
+	}
+
+	long := make([]float64, 2*seasonLength)
+	if _, seasonal := forecastDemand(long, dto.ForecastExponentialSmoothing, 1); !seasonal {
+		t.Error("forecastDemand() isn't seasonal for history of two seasons")
+	}
+	if _, seasonal := forecastDemand(long, dto.ForecastMovingAverage, 1); seasonal {
+		t.Error("forecastDemand() with moving average is seasonal")
+	}
+}
+
+func TestStockOutDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		stock   float64
+		demand  []float64
+		wantDay int
+		wantOk  bool
+	}{
+		{"empty stock", 0, []float64{7}, 0, true},
+		{"first week", 7, []float64{14, 14}, 3, true},
+		{"second week", 10, []float64{7, 7}, 10, true},
+		{"lasts longer", 20, []float64{7, 7}, 0, false},
+		{"no demand", 5, []float64{0, 0}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, ok := stockOutDay(tt.stock, tt.demand)
+			if day != tt.wantDay || ok != tt.wantOk {
+				t.Errorf("stockOutDay() = %d, %v, want %d, %v", day, ok, tt.wantDay, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetDemandForecast(t *testing.T) {
+	now := time.Date(2024, 3, 29, 15, 30, 0, 0, time.UTC)
+	repo := &fakeShopRepo{
+		warehouses: []*dto.WarehousesData{
+			{Id: 1, Name: "tea", Quantity: 10},
+			{Id: 2, Name: "coffee", Quantity: 100},
+		},
+		days: []*dto.ItemDailySalesData{
+			{WarehousesId: 1, Day: "2024-03-15", Units: 7},
+			{WarehousesId: 1, Day: "2024-03-28", Units: 7},
+			{WarehousesId: 3, Day: "2024-03-28", Units: 50},
+		},
+	}
+	s := NewForecastService(repo)
+	s.now = func() time.Time { return now }
+
+	report, err := s.GetDemandForecast(context.Background(), &dto.ForecastParams{
+		Method:      dto.ForecastMovingAverage,
+		Horizon:     2,
+		History:     2,
+		LeadTime:    1,
+		SafetyWeeks: 1,
+	})
+	if err != nil {
+		t.Fatalf("GetDemandForecast() error = %v", err)
+	}
+
+	if want := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC); !repo.from.Equal(want) {
+		t.Errorf("history starts at %v, want %v", repo.from, want)
+	}
+	if want := time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC); !repo.to.Equal(want) {
+		t.Errorf("history ends at %v, want %v", repo.to, want)
+	}
+	if want := []string{"2024-03-29", "2024-04-05"}; len(report.WeekStarts) != 2 ||
+		report.WeekStarts[0] != want[0] || report.WeekStarts[1] != want[1] {
+		t.Errorf("WeekStarts = %v, want %v", report.WeekStarts, want)
+	}
+	if len(report.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(report.Items))
+	}
+
+	tea := report.Items[0]
+	if !equalFloats(tea.Demand, []float64{7, 7}) {
+		t.Errorf("tea demand = %v, want [7 7]", tea.Demand)
+	}
+	if tea.ReorderQuantity != 11 {
+		t.Errorf("tea reorder quantity = %d, want 11", tea.ReorderQuantity)
+	}
+	if tea.StockOutDate != "2024-04-08" || tea.OrderByDate != "2024-04-01" {
+		t.Errorf("tea stock out on %q, order by %q, want 2024-04-08 and 2024-04-01",
+			tea.StockOutDate, tea.OrderByDate)
+	}
+
+	coffee := report.Items[1]
+	if coffee.ReorderQuantity != 0 || coffee.StockOutDate != "" || coffee.OrderByDate != "" {
+		t.Errorf("coffee without demand got reorder %d, stock out %q, order by %q",
+			coffee.ReorderQuantity, coffee.StockOutDate, coffee.OrderByDate)
+	}
+}
+
+func TestGetDemandForecastInvalidParams(t *testing.T) {
+	valid := dto.ForecastParams{Method: dto.ForecastExponentialSmoothing, Horizon: 4, History: 26, LeadTime: 1}
+	tests := []struct {
+		name   string
+		modify func(*dto.ForecastParams)
+	}{
+		{"unknown method", func(p *dto.ForecastParams) { p.Method = "guess" }},
+		{"zero horizon", func(p *dto.ForecastParams) { p.Horizon = 0 }},
+		{"too long history", func(p *dto.ForecastParams) { p.History = maxHistory + 1 }},
+		{"lead time beyond horizon", func(p *dto.ForecastParams) { p.LeadTime = 5 }},
+		{"negative safety stock", func(p *dto.ForecastParams) { p.SafetyWeeks = -1 }},
+	}
+
+	s := NewForecastService(&fakeShopRepo{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.modify(&params)
+			if _, err := s.GetDemandForecast(context.Background(), &params); !errors.Is(err, customErr.ErrInvalidReportParams) {
+				t.Errorf("GetDemandForecast() error = %v, want %v", err, customErr.ErrInvalidReportParams)
+			}
+		})
+	}
+}
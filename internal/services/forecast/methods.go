@@ -0,0 +1,126 @@
+package services
+
+import (
+	"automatedShop/internal/services/dto"
+	"math"
+)
+
+// Parameters of forecasting methods. Season is a year of weeks; seasonality is accounted only
+// when history covers at least two seasons.
+const (
+	movingAverageWindow = 4
+	smoothingAlpha      = 0.3
+	smoothingBeta       = 0.1
+	smoothingGamma      = 0.3
+	seasonLength        = 52
+)
+
+// movingAverage forecasts flat demand equal to the mean of last window values of history.
+func movingAverage(history []float64, window, horizon int) []float64 {
+	if window > len(history) {
+		window = len(history)
+	}
+
+	var level float64
+	if window > 0 {
+		for _, value := range history[len(history)-window:] {
+			level += value
+		}
+		level /= float64(window)
+	}
+
+	return flat(level, horizon)
+}
+
+// exponentialSmoothing forecasts flat demand equal to exponentially smoothed level of history.
+func exponentialSmoothing(history []float64, alpha float64, horizon int) []float64 {
+	if len(history) == 0 {
+		return flat(0, horizon)
+	}
+
+	level := history[0]
+	for _, value := range history[1:] {
+		level = alpha*value + (1-alpha)*level
+	}
+
+	return flat(level, horizon)
+}
+
+// holtWinters forecasts demand with additive level, trend and seasonality. History must cover
+// at least two seasons: the first one initializes seasonal components and the second one trend.
+func holtWinters(history []float64, season int, alpha, beta, gamma float64, horizon int) []float64 {
+	first, second := mean(history[:season]), mean(history[season:2*season])
+	level := first
+	trend := (second - first) / float64(season)
+	seasonal := make([]float64, season)
+	for i := range seasonal {
+		seasonal[i] = history[i] - first
+	}
+
+	for t := season; t < len(history); t++ {
+		previous := seasonal[t%season]
+		next := alpha*(history[t]-previous) + (1-alpha)*(level+trend)
+		trend = beta*(next-level) + (1-beta)*trend
+		seasonal[t%season] = gamma*(history[t]-next) + (1-gamma)*previous
+		level = next
+	}
+
+	res := make([]float64, horizon)
+	for h := range res {
+		res[h] = math.Max(0, level+float64(h+1)*trend+seasonal[(len(history)+h)%season])
+	}
+
+	return res
+}
+
+// forecastDemand forecasts demand for horizon following weekly history. Exponential smoothing
+// switches to seasonal method when history is long enough.
+func forecastDemand(history []float64, method string, horizon int) ([]float64, bool) {
+	if method == dto.ForecastMovingAverage {
+		return movingAverage(history, movingAverageWindow, horizon), false
+	}
+	if len(history) >= 2*seasonLength {
+		return holtWinters(history, seasonLength, smoothingAlpha, smoothingBeta, smoothingGamma, horizon), true
+	}
+
+	return exponentialSmoothing(history, smoothingAlpha, horizon), false
+}
+
+// stockOutDay returns number of days since horizon start until stock runs out if demand goes as
+// forecast. The second value is false when stock lasts longer than horizon.
+func stockOutDay(stock float64, demand []float64) (int, bool) {
+	if stock <= 0 {
+		return 0, true
+	}
+
+	for week, units := range demand {
+		if units >= stock {
+			return week*7 + int(stock/units*7), true
+		}
+		stock -= units
+	}
+
+	return 0, false
+}
+
+func flat(value float64, n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = value
+	}
+
+	return res
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// copyThreshold is number of rows imports are loaded by COPY from. Smaller imports are created
+// record by record. Records are recorded in audit log either way.
+const copyThreshold = 1000
+
+// ImportService imports handbooks and journals from CSV files. Rows are validated all together and
+// written in one go only if all of them are valid.
+type ImportService struct {
+	l           *slog.Logger
+	ShopRepo    repository.IShopRepository
+	ImportRepo  repository.IImportRepository
+	ShopService IShopService
+	Transactor  repository.ITransactor
+}
+
+// IShopService is the part of shop service records of small imports are created through, so that
+// they're recorded in audit log.
+type IShopService interface {
+	CreateWarehousesItem(context.Context, *dto.WarehousesData) error
+	CreateExpenseItem(context.Context, string) error
+	CreateChargesItem(context.Context, *dto.ChargesData) error
+	CreateSalesItem(context.Context, *dto.SalesData) error
+}
+
+func NewImportService(shopRepo repository.IShopRepository, importRepo repository.IImportRepository,
+	shopService IShopService, transactor repository.ITransactor) *ImportService {
+	var l *slog.Logger
+
+	return &ImportService{
+		l:           l,
+		ShopRepo:    shopRepo,
+		ImportRepo:  importRepo,
+		ShopService: shopService,
+		Transactor:  transactor,
+	}
+}
+
+// ImportCSV validates rows of CSV file and, unless it's dry run or some rows are invalid, imports
+// them. Expense items and warehouses items referenced by name are looked up among existing ones.
+// Errors of rows are returned in result, errors of the file as a whole are returned as error.
+func (s *ImportService) ImportCSV(ctx context.Context, params *dto.ImportParams) (*dto.ImportResultData, error) {
+	const op = "ImportService.ImportCSV"
+
+	columns, err := mapColumns(params)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	res := &dto.ImportResultData{Rows: len(params.Rows), DateLayout: params.DateLayout}
+	if dateField := importDateField(params.Table); dateField != "" && res.DateLayout == "" {
+		if res.DateLayout, err = detectDateLayout(columnValues(params.Rows, columns[dateField])); err != nil {
+			return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+		}
+	}
+
+	records, errs, err := s.parseRows(ctx, params, columns, res.DateLayout)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	res.Errors = errs
+	if params.DryRun || len(errs) > 0 || len(records) == 0 {
+		return res, nil
+	}
+
+	if len(records) >= copyThreshold {
+		res.Copied = true
+		err = s.copyRecords(ctx, params.Table, records)
+	} else {
+		err = s.createRecords(ctx, records)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	res.Imported = len(records)
+
+	return res, nil
+}
+
+// mapColumns returns indexes of columns mapped fields are read from.
+func mapColumns(params *dto.ImportParams) (map[string]int, error) {
+	fields, ok := dto.ImportFields[params.Table]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown table %q", customErr.ErrInvalidImport, params.Table)
+	}
+
+	columns := make(map[string]int, len(fields))
+	for _, field := range fields {
+		header := params.Mapping[field.Key]
+		if header == "" {
+			if field.Required {
+				return nil, fmt.Errorf("%w: field %s isn't mapped", customErr.ErrInvalidImport, field.Key)
+			}
+			continue
+		}
+
+		index := slices.Index(params.Headers, header)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: file has no column %q", customErr.ErrInvalidImport, header)
+		}
+		columns[field.Key] = index
+	}
+
+	_, byName := columns[dto.ImportFieldExpenseItem]
+	_, byId := columns["expense_item_id"]
+	if params.Table == dto.ChargesTable && !byName && !byId {
+		return nil, fmt.Errorf("%w: either expense_item or expense_item_id must be mapped", customErr.ErrInvalidImport)
+	}
+	_, byName = columns[dto.ImportFieldWarehouse]
+	_, byId = columns["warehouses_id"]
+	if params.Table == dto.SalesTable && !byName && !byId {
+		return nil, fmt.Errorf("%w: either warehouse or warehouses_id must be mapped", customErr.ErrInvalidImport)
+	}
+
+	return columns, nil
+}
+
+// importDateField returns date field of records of table or empty string if they have none.
+func importDateField(table string) string {
+	switch table {
+	case dto.ChargesTable:
+		return "charge_date"
+	case dto.SalesTable:
+		return "sale_date"
+	default:
+		return ""
+	}
+}
+
+func columnValues(rows [][]string, index int) []string {
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if index < len(row) {
+			values = append(values, row[index])
+		}
+	}
+
+	return values
+}
+
+// parseRows converts rows to records of table. Rows with errors are skipped, records of the other
+// rows are returned.
+func (s *ImportService) parseRows(ctx context.Context, params *dto.ImportParams, columns map[string]int,
+	layout string) ([]any, []*dto.ImportRowError, error) {
+	var lookup *nameLookup
+	var err error
+	switch params.Table {
+	case dto.ChargesTable:
+		lookup, err = s.expenseItemLookup(ctx)
+	case dto.SalesTable:
+		lookup, err = s.warehousesLookup(ctx)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		records []any
+		errs    []*dto.ImportRowError
+	)
+	for i, row := range params.Rows {
+		r := &rowReader{row: row, columns: columns, line: i + 2, layout: layout}
+
+		var record any
+		switch params.Table {
+		case dto.WarehousesTable:
+			record = r.warehousesItem()
+		case dto.ExpenseItemsTable:
+			record = r.text("name", maxNameLen, true)
+		case dto.ChargesTable:
+			record = r.chargesItem(lookup)
+		case dto.SalesTable:
+			record = r.salesItem(lookup)
+		}
+
+		if len(r.errs) > 0 {
+			errs = append(errs, r.errs...)
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, errs, nil
+}
+
+// copyRecords loads records by COPY, repository records them in audit log.
+func (s *ImportService) copyRecords(ctx context.Context, table string, records []any) error {
+	login := session.Login(ctx)
+
+	var err error
+	switch table {
+	case dto.WarehousesTable:
+		_, err = s.ImportRepo.CopyWarehouses(ctx, typed[*dto.WarehousesData](records), login)
+	case dto.ExpenseItemsTable:
+		_, err = s.ImportRepo.CopyExpenseItems(ctx, typed[string](records), login)
+	case dto.ChargesTable:
+		_, err = s.ImportRepo.CopyCharges(ctx, typed[*dto.ChargesData](records), login)
+	case dto.SalesTable:
+		_, err = s.ImportRepo.CopySales(ctx, typed[*dto.SalesData](records), login)
+	}
+
+	return err
+}
+
+// createRecords creates records one by one in single transaction through shop service.
+func (s *ImportService) createRecords(ctx context.Context, records []any) error {
+	return s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		for _, record := range records {
+			var err error
+			switch item := record.(type) {
+			case *dto.WarehousesData:
+				err = s.ShopService.CreateWarehousesItem(ctx, item)
+			case string:
+				err = s.ShopService.CreateExpenseItem(ctx, item)
+			case *dto.ChargesData:
+				err = s.ShopService.CreateChargesItem(ctx, item)
+			case *dto.SalesData:
+				err = s.ShopService.CreateSalesItem(ctx, item)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func typed[T any](records []any) []T {
+	res := make([]T, 0, len(records))
+	for _, record := range records {
+		res = append(res, record.(T))
+	}
+
+	return res
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Maximal lengths of text columns.
+const (
+	maxNameLen     = 20
+	maxCategoryLen = 30
+	maxCustomerLen = 100
+)
+
+// dateLayouts are layouts dates of imported files are detected among, in order of preference.
+var dateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"01/02/2006 15:04",
+	"01/02/2006",
+	time.RFC3339,
+}
+
+// detectDateLayout returns the first layout all non-empty values are parsed with.
+func detectDateLayout(values []string) (string, error) {
+	for _, layout := range dateLayouts {
+		matched := true
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if _, err := time.Parse(layout, value); err != nil {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return layout, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: format of dates isn't recognized, set it explicitly", customErr.ErrInvalidImport)
+}
+
+// nameLookup finds ids of records by names, ignoring case.
+type nameLookup struct {
+	ids   map[string][]int
+	known map[int]bool
+}
+
+func newNameLookup() *nameLookup {
+	return &nameLookup{ids: make(map[string][]int), known: make(map[int]bool)}
+}
+
+func (l *nameLookup) add(id int, name string) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	l.ids[key] = append(l.ids[key], id)
+	l.known[id] = true
+}
+
+func (s *ImportService) expenseItemLookup(ctx context.Context) (*nameLookup, error) {
+	items, err := s.ShopRepo.ShowExpenseItemsTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := newNameLookup()
+	for _, item := range items {
+		lookup.add(item.Id, item.Name)
+	}
+
+	return lookup, nil
+}
+
+func (s *ImportService) warehousesLookup(ctx context.Context) (*nameLookup, error) {
+	items, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := newNameLookup()
+	for _, item := range items {
+		lookup.add(item.Id, item.Name)
+	}
+
+	return lookup, nil
+}
+
+// rowReader reads fields of one row and collects their errors.
+type rowReader struct {
+	row     []string
+	columns map[string]int
+	line    int
+	layout  string
+	errs    []*dto.ImportRowError
+}
+
+func (r *rowReader) fail(field, format string, args ...any) {
+	r.errs = append(r.errs, &dto.ImportRowError{Line: r.line, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// value returns trimmed value of field, it's empty for unmapped fields and short rows.
+func (r *rowReader) value(field string) string {
+	index, ok := r.columns[field]
+	if !ok || index >= len(r.row) {
+		return ""
+	}
+
+	return strings.TrimSpace(r.row[index])
+}
+
+func (r *rowReader) text(field string, maxLen int, required bool) string {
+	value := r.value(field)
+	if value == "" && required {
+		r.fail(field, "value is required")
+	}
+	if utf8.RuneCountInString(value) > maxLen {
+		r.fail(field, "value is longer than %d characters", maxLen)
+	}
+
+	return value
+}
+
+// number returns integer value of field or nil if it's empty. Spaces separating thousands are allowed.
+func (r *rowReader) number(field string, required bool) *int {
+	value := strings.NewReplacer(" ", "", "\u00a0", "").Replace(r.value(field))
+	if value == "" {
+		if required {
+			r.fail(field, "value is required")
+		}
+		return nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		r.fail(field, "%q isn't an integer", value)
+		return nil
+	}
+	if n < 0 {
+		r.fail(field, "value must not be negative")
+		return nil
+	}
+
+	return &n
+}
+
+// date returns value of field in dto.TimeLayout.
+func (r *rowReader) date(field string) string {
+	value := r.value(field)
+	if value == "" {
+		r.fail(field, "value is required")
+		return ""
+	}
+
+	date, err := time.ParseInLocation(r.layout, value, time.Local)
+	if err != nil {
+		r.fail(field, "%q doesn't match date format %s", value, r.layout)
+		return ""
+	}
+
+	return date.In(time.Local).Format(dto.TimeLayout)
+}
+
+// reference returns id of record referenced by id field or, if it's empty, by name field.
+func (r *rowReader) reference(idField, nameField string, lookup *nameLookup) int {
+	if id := r.number(idField, false); id != nil {
+		if !lookup.known[*id] {
+			r.fail(idField, "record %d doesn't exist", *id)
+		}
+		return *id
+	}
+
+	name := r.value(nameField)
+	if name == "" {
+		r.fail(nameField, "value is required")
+		return 0
+	}
+
+	ids := lookup.ids[strings.ToLower(name)]
+	switch len(ids) {
+	case 0:
+		r.fail(nameField, "%q isn't found", name)
+		return 0
+	case 1:
+		return ids[0]
+	default:
+		r.fail(nameField, "%q is ambiguous, use id instead", name)
+		return 0
+	}
+}
+
+func (r *rowReader) warehousesItem() *dto.WarehousesData {
+	item := &dto.WarehousesData{
+		Name:     r.text("name", maxNameLen, true),
+		Cost:     r.number("cost", false),
+		Category: r.text("category", maxCategoryLen, false),
+		Location: r.text("location", maxCategoryLen, false),
+	}
+	if quantity := r.number("quantity", true); quantity != nil {
+		item.Quantity = *quantity
+	}
+	if amount := r.number("amount", true); amount != nil {
+		item.Amount = *amount
+	}
+
+	return item
+}
+
+func (r *rowReader) chargesItem(lookup *nameLookup) *dto.ChargesData {
+	charge := &dto.ChargesData{
+		ChargeDate:    r.date("charge_date"),
+		ExpenseItemId: r.reference("expense_item_id", dto.ImportFieldExpenseItem, lookup),
+	}
+	if amount := r.number("amount", true); amount != nil {
+		charge.Amount = *amount
+	}
+
+	return charge
+}
+
+func (r *rowReader) salesItem(lookup *nameLookup) *dto.SalesData {
+	sale := &dto.SalesData{
+		SaleDate:     r.date("sale_date"),
+		WarehousesId: r.reference("warehouses_id", dto.ImportFieldWarehouse, lookup),
+		Customer:     r.text("customer", maxCustomerLen, false),
+	}
+	if amount := r.number("amount", true); amount != nil {
+		sale.Amount = *amount
+	}
+	if quantity := r.number("quantity", true); quantity != nil {
+		if *quantity == 0 {
+			r.fail("quantity", "value must be positive")
+		}
+		sale.Quantity = *quantity
+	}
+
+	return sale
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// PivotService builds pivot reports defined by users and keeps their saved definitions.
+type PivotService struct {
+	l         *slog.Logger
+	PivotRepo repository.IPivotRepository
+}
+
+func NewPivotService(repo repository.IPivotRepository) *PivotService {
+	var l *slog.Logger
+
+	return &PivotService{
+		l:         l,
+		PivotRepo: repo,
+	}
+}
+
+// GetPivotReport returns pivot table of definition for sales and charges made in period. Groups of
+// row dimensions are followed by subtotals and the table ends with grand total.
+func (s *PivotService) GetPivotReport(ctx context.Context, params *dto.PivotParams) (*dto.PivotReportData, error) {
+	const op = "PivotService.GetPivotReport"
+
+	if err := validatePivot(&params.Definition); err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	groups, err := s.PivotRepo.GetPivotData(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return buildPivot(&params.Definition, groups), nil
+}
+
+// SavePivotDefinition validates definition and saves it under a name for logged-in user.
+func (s *PivotService) SavePivotDefinition(ctx context.Context, saved *dto.SavedPivotData) error {
+	const op = "PivotService.SavePivotDefinition"
+
+	if strings.TrimSpace(saved.Name) == "" {
+		return fmt.Errorf("error occurred in: %v: %w: name is required", op, customErr.ErrInvalidReportParams)
+	}
+	if err := validatePivot(&saved.Definition); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	saved.UserLogin = session.Login(ctx)
+	id, err := s.PivotRepo.SavePivotDefinition(ctx, saved)
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	saved.Id = id
+
+	return nil
+}
+
+// ShowPivotDefinitions returns saved definitions. Admins see definitions of all users, others only
+// their own.
+func (s *PivotService) ShowPivotDefinitions(ctx context.Context) ([]*dto.SavedPivotData, error) {
+	const op = "PivotService.ShowPivotDefinitions"
+
+	login, err := ownerFilter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	res, err := s.PivotRepo.ShowPivotDefinitions(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// DeletePivotDefinition removes saved definition. Users other than admins remove only their own ones.
+func (s *PivotService) DeletePivotDefinition(ctx context.Context, id int) error {
+	const op = "PivotService.DeletePivotDefinition"
+
+	login, err := ownerFilter(ctx)
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	if err = s.PivotRepo.DeletePivotDefinition(ctx, id, login); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// ownerFilter returns login definitions are filtered by, it's empty for admins.
+func ownerFilter(ctx context.Context) (string, error) {
+	login := session.Login(ctx)
+	switch {
+	case session.IsAdmin(ctx):
+		return "", nil
+	case login == "":
+		return "", customErr.ErrAccessDenied
+	}
+
+	return login, nil
+}
+
+func validatePivot(definition *dto.PivotDefinition) error {
+	if len(definition.Measures) == 0 {
+		return fmt.Errorf("%w: at least one measure is required", customErr.ErrInvalidReportParams)
+	}
+	for i, measure := range definition.Measures {
+		if !slices.Contains(dto.PivotMeasures, measure) {
+			return fmt.Errorf("%w: unknown measure %q", customErr.ErrInvalidReportParams, measure)
+		}
+		if slices.Contains(definition.Measures[:i], measure) {
+			return fmt.Errorf("%w: measure %q is chosen twice", customErr.ErrInvalidReportParams, measure)
+		}
+	}
+
+	dimensions := definition.Rows
+	if definition.Column != "" {
+		dimensions = append(dimensions[:len(dimensions):len(dimensions)], definition.Column)
+	}
+	for i, dimension := range dimensions {
+		if !slices.Contains(dto.PivotDimensions, dimension) {
+			return fmt.Errorf("%w: unknown dimension %q", customErr.ErrInvalidReportParams, dimension)
+		}
+		if slices.Contains(dimensions[:i], dimension) {
+			return fmt.Errorf("%w: dimension %q is chosen twice", customErr.ErrInvalidReportParams, dimension)
+		}
+	}
+
+	for _, filter := range definition.Filters {
+		if !slices.Contains(dto.PivotDimensions, filter.Dimension) {
+			return fmt.Errorf("%w: unknown filter dimension %q", customErr.ErrInvalidReportParams, filter.Dimension)
+		}
+		if !slices.Contains(dto.FilterOperators, filter.Operator) {
+			return fmt.Errorf("%w: unknown filter operator %q", customErr.ErrInvalidReportParams, filter.Operator)
+		}
+	}
+
+	return nil
+}
+
+// buildPivot spreads groups ordered by keys over columns and adds subtotals and grand total.
+func buildPivot(definition *dto.PivotDefinition, groups []*dto.PivotGroupData) *dto.PivotReportData {
+	depth := len(definition.Rows)
+	report := &dto.PivotReportData{Definition: *definition}
+
+	column := make(map[string]int)
+	if definition.Column != "" {
+		for _, group := range groups {
+			column[group.Keys[depth]] = 0
+		}
+		for key := range column {
+			report.Columns = append(report.Columns, key)
+		}
+		slices.Sort(report.Columns)
+		for i, key := range report.Columns {
+			column[key] = i
+		}
+	}
+
+	newRow := func(keys []string, level int) *dto.PivotRowData {
+		row := &dto.PivotRowData{
+			Keys:   keys,
+			Level:  level,
+			Values: make([][]int64, len(report.Columns)+1),
+		}
+		for i := range row.Values {
+			row.Values[i] = make([]int64, len(definition.Measures))
+		}
+		return row
+	}
+	add := func(row *dto.PivotRowData, group *dto.PivotGroupData) {
+		for i, value := range group.Values {
+			if definition.Column != "" {
+				row.Values[column[group.Keys[depth]]][i] += value
+			}
+			row.Values[len(report.Columns)][i] += value
+		}
+	}
+
+	if depth == 0 {
+		// without row dimensions the only row is grand total
+		grand := newRow(nil, 0)
+		for _, group := range groups {
+			add(grand, group)
+		}
+		report.Rows = append(report.Rows, grand)
+		return report
+	}
+
+	// totals[level] accumulates subtotal of the current group of level keys, totals[0] is grand total
+	totals := make([]*dto.PivotRowData, depth)
+	for level := range totals {
+		totals[level] = newRow(nil, level)
+	}
+
+	var current *dto.PivotRowData
+	for _, group := range groups {
+		keys := group.Keys[:depth]
+		if current == nil || !slices.Equal(current.Keys, keys) {
+			if current != nil {
+				// close groups whose keys changed, the deepest ones first
+				changed := 0
+				for changed < depth && current.Keys[changed] == keys[changed] {
+					changed++
+				}
+				for level := depth - 1; level > changed; level-- {
+					report.Rows = append(report.Rows, totals[level])
+					totals[level] = newRow(nil, level)
+				}
+			}
+
+			current = newRow(keys, depth)
+			report.Rows = append(report.Rows, current)
+			for level := 1; level < depth; level++ {
+				totals[level].Keys = keys[:level]
+			}
+		}
+
+		add(current, group)
+		for _, total := range totals {
+			add(total, group)
+		}
+	}
+
+	if current != nil {
+		for level := depth - 1; level > 0; level-- {
+			report.Rows = append(report.Rows, totals[level])
+		}
+	}
+	report.Rows = append(report.Rows, totals[0])
+
+	return report
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/export"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ScheduleService keeps schedules of reports generated by scheduler and log of their runs.
+// Managing schedules is allowed to admins only, scheduler runs them on behalf of admin too.
+type ScheduleService struct {
+	l            *slog.Logger
+	ScheduleRepo repository.IScheduleRepository
+	now          func() time.Time
+}
+
+func NewScheduleService(repo repository.IScheduleRepository) *ScheduleService {
+	var l *slog.Logger
+
+	return &ScheduleService{
+		l:            l,
+		ScheduleRepo: repo,
+		now:          time.Now,
+	}
+}
+
+// CreateSchedule validates schedule and stores it with time of its first run.
+func (s *ScheduleService) CreateSchedule(ctx context.Context, schedule *dto.ScheduleData) error {
+	const op = "ScheduleService.CreateSchedule"
+
+	if !session.IsAdmin(ctx) {
+		return fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+	if err := validateSchedule(schedule); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	if schedule.Frequency == dto.FrequencyDaily {
+		schedule.Day = 0
+	}
+
+	schedule.CreatedBy = session.Login(ctx)
+	schedule.NextRunAt = nextRun(schedule, s.now())
+	id, err := s.ScheduleRepo.CreateSchedule(ctx, schedule)
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	schedule.Id = id
+
+	return nil
+}
+
+func (s *ScheduleService) ShowSchedules(ctx context.Context) ([]*dto.ScheduleData, error) {
+	const op = "ScheduleService.ShowSchedules"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	res, err := s.ScheduleRepo.ShowSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// DeleteSchedule removes schedule and its run log.
+func (s *ScheduleService) DeleteSchedule(ctx context.Context, id int) error {
+	const op = "ScheduleService.DeleteSchedule"
+
+	if !session.IsAdmin(ctx) {
+		return fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	if err := s.ScheduleRepo.DeleteSchedule(ctx, id); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetDueSchedules returns schedules which should have been run by now.
+func (s *ScheduleService) GetDueSchedules(ctx context.Context, now time.Time) ([]*dto.ScheduleData, error) {
+	const op = "ScheduleService.GetDueSchedules"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	res, err := s.ScheduleRepo.GetDueSchedules(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// ClaimSchedule moves due schedule to its next run after now. It reports false when the run was
+// already claimed by another scheduler. Runs missed while no scheduler worked are made up by one run.
+func (s *ScheduleService) ClaimSchedule(ctx context.Context, schedule *dto.ScheduleData, now time.Time) (bool, error) {
+	const op = "ScheduleService.ClaimSchedule"
+
+	if !session.IsAdmin(ctx) {
+		return false, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	next := nextRun(schedule, now)
+	ok, err := s.ScheduleRepo.ClaimSchedule(ctx, schedule.Id, schedule.NextRunAt, next)
+	if err != nil {
+		return false, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+	if ok {
+		schedule.NextRunAt = next
+	}
+
+	return ok, nil
+}
+
+func (s *ScheduleService) SaveScheduleRun(ctx context.Context, run *dto.ScheduleRunData) error {
+	const op = "ScheduleService.SaveScheduleRun"
+
+	if !session.IsAdmin(ctx) {
+		return fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	if err := s.ScheduleRepo.SaveScheduleRun(ctx, run); err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShowScheduleRuns returns run log of schedule or of all schedules when id is 0.
+func (s *ScheduleService) ShowScheduleRuns(ctx context.Context, id int) ([]*dto.ScheduleRunData, error) {
+	const op = "ScheduleService.ShowScheduleRuns"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	res, err := s.ScheduleRepo.ShowScheduleRuns(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func validateSchedule(schedule *dto.ScheduleData) error {
+	switch {
+	case strings.TrimSpace(schedule.Name) == "":
+		return fmt.Errorf("%w: name is required", customErr.ErrInvalidSchedule)
+	case schedule.ReportType == "":
+		return fmt.Errorf("%w: report is required", customErr.ErrInvalidSchedule)
+	case schedule.Format != dto.FormatPDF && !slices.Contains(export.Formats, schedule.Format):
+		return fmt.Errorf("%w: unknown format %q", customErr.ErrInvalidSchedule, schedule.Format)
+	case strings.TrimSpace(schedule.Destination) == "":
+		return fmt.Errorf("%w: destination directory is required", customErr.ErrInvalidSchedule)
+	case schedule.Hour < 0 || schedule.Hour > 23:
+		return fmt.Errorf("%w: hour must be between 0 and 23", customErr.ErrInvalidSchedule)
+	}
+
+	switch schedule.Frequency {
+	case dto.FrequencyDaily:
+		return nil
+	case dto.FrequencyWeekly:
+		if schedule.Day < 1 || schedule.Day > 7 {
+			return fmt.Errorf("%w: weekday must be between 1 and 7", customErr.ErrInvalidSchedule)
+		}
+	case dto.FrequencyMonthly:
+		// later days don't exist in every month
+		if schedule.Day < 1 || schedule.Day > 28 {
+			return fmt.Errorf("%w: day of month must be between 1 and 28", customErr.ErrInvalidSchedule)
+		}
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", customErr.ErrInvalidSchedule, schedule.Frequency)
+	}
+
+	return nil
+}
+
+// nextRun returns the first moment after given one schedule should run at.
+func nextRun(schedule *dto.ScheduleData, after time.Time) time.Time {
+	y, m, d := after.Date()
+	loc := after.Location()
+
+	switch schedule.Frequency {
+	case dto.FrequencyWeekly:
+		weekday := (int(after.Weekday())+6)%7 + 1
+		next := time.Date(y, m, d+(schedule.Day-weekday+7)%7, schedule.Hour, 0, 0, 0, loc)
+		if !next.After(after) {
+			next = time.Date(y, m, d+(schedule.Day-weekday+7)%7+7, schedule.Hour, 0, 0, 0, loc)
+		}
+		return next
+	case dto.FrequencyMonthly:
+		next := time.Date(y, m, schedule.Day, schedule.Hour, 0, 0, 0, loc)
+		if !next.After(after) {
+			next = time.Date(y, m+1, schedule.Day, schedule.Hour, 0, 0, 0, loc)
+		}
+		return next
+	default:
+		next := time.Date(y, m, d, schedule.Hour, 0, 0, 0, loc)
+		if !next.After(after) {
+			next = time.Date(y, m, d+1, schedule.Hour, 0, 0, 0, loc)
+		}
+		return next
+	}
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"automatedShop/internal/services/dto"
+	crand "crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"strconv"
+	"time"
+)
+
+// maxNameLen is maximal length of names of warehouses items.
+const maxNameLen = 20
+
+type product struct {
+	name  string
+	price int
+}
+
+// category of products. Demand for its products peaks on day of year peak and is lowest half a
+// year later, amplitude is relative change of demand. Products of categories with zero amplitude
+// sell evenly all year round.
+type category struct {
+	name      string
+	location  string
+	peak      int
+	amplitude float64
+	products  []product
+}
+
+var catalog = []category{
+	{name: "Drinks", location: "Aisle 1", peak: 200, amplitude: 0.5, products: []product{
+		{"Cola 0.5L", 90}, {"Mineral water 1.5L", 60}, {"Orange juice 1L", 140}, {"Lemonade 1L", 110},
+		{"Iced tea 0.5L", 85},
+	}},
+	{name: "Hot drinks", location: "Aisle 1", peak: 15, amplitude: 0.4, products: []product{
+		{"Black tea 100g", 180}, {"Green tea 100g", 210}, {"Ground coffee 250g", 450}, {"Cocoa 200g", 260},
+	}},
+	{name: "Ice cream", location: "Freezer", peak: 195, amplitude: 0.8, products: []product{
+		{"Vanilla cone", 75}, {"Chocolate ice cream", 95}, {"Ice lolly", 50},
+	}},
+	{name: "Dairy", location: "Fridge", products: []product{
+		{"Milk 1L", 95}, {"Kefir 1L", 105}, {"Butter 180g", 210}, {"Cheese 200g", 320}, {"Yogurt 125g", 55},
+	}},
+	{name: "Bakery", location: "Aisle 2", products: []product{
+		{"White bread", 55}, {"Rye bread", 65}, {"Croissant", 70}, {"Bagel", 40},
+	}},
+	{name: "Snacks", location: "Checkout", products: []product{
+		{"Potato chips 150g", 160}, {"Salted nuts 100g", 190}, {"Crackers 200g", 120}, {"Chocolate bar", 95},
+	}},
+	{name: "Household", location: "Aisle 3", products: []product{
+		{"Dish soap 500ml", 150}, {"Paper towels", 130}, {"Trash bags 30pcs", 170}, {"Sponges 5pcs", 90},
+	}},
+	{name: "Seasonal", location: "Entrance", peak: 355, amplitude: 0.9, products: []product{
+		{"Tangerines 1kg", 250}, {"Gift box", 900}, {"Candles 4pcs", 220},
+	}},
+}
+
+// weekdayDemand is relative number of sales on each weekday, starting from Sunday.
+var weekdayDemand = [7]float64{1.1, 0.85, 0.9, 0.95, 1.0, 1.2, 1.4}
+
+// hourDemand is relative number of sales in hours from openingHour on.
+var hourDemand = []float64{1, 2, 3, 4, 5, 6, 5, 4, 4, 5, 7, 8, 6, 3}
+
+const openingHour = 8
+
+var customers = []string{
+	"Anna Ivanova", "Boris Smirnov", "Daria Kuznetsova", "Egor Popov", "Elena Sokolova", "Fedor Lebedev",
+	"Galina Kozlova", "Igor Novikov", "Irina Morozova", "Kirill Petrov", "Maria Volkova", "Nikita Solovyov",
+	"Olga Vasilyeva", "Pavel Zaitsev", "Svetlana Pavlova", "Timur Semenov", "Vera Golubeva", "Yuri Vinogradov",
+}
+
+// Expense items charges are generated for.
+const (
+	expenseRent        = "Rent"
+	expenseSalaries    = "Salaries"
+	expenseUtilities   = "Utilities"
+	expenseInternet    = "Internet"
+	expenseDelivery    = "Delivery"
+	expenseRepairs     = "Repairs"
+	expenseAdvertising = "Advertising"
+	expenseEquipment   = "Equipment"
+)
+
+var expenseItems = []string{
+	expenseRent, expenseSalaries, expenseUtilities, expenseInternet, expenseDelivery, expenseRepairs,
+	expenseAdvertising, expenseEquipment,
+}
+
+// oneOffExpense is expense which happens about perYear times a year and costs from minShare to
+// maxShare of monthly revenue.
+type oneOffExpense struct {
+	name     string
+	perYear  float64
+	minShare float64
+	maxShare float64
+}
+
+var oneOffExpenses = []oneOffExpense{
+	{expenseRepairs, 3, 0.02, 0.08},
+	{expenseAdvertising, 4, 0.03, 0.10},
+	{expenseEquipment, 2, 0.05, 0.20},
+}
+
+// generator makes demo data day by day, so that any volume is generated in little memory. Sales
+// follow weekly and seasonal patterns with slow growth over the period, charges are recurring and
+// one-off ones sized after expected revenue. WarehousesId of generated sales and ExpenseItemId of
+// charges are indexes of items and expenseItems.
+type generator struct {
+	rnd    *rand.Rand
+	params *dto.SeedParams
+	first  time.Time
+	items  []*dto.WarehousesData
+	// seasons are categories of items
+	seasons []category
+	// weights are relative popularities of items
+	weights []float64
+	// revenue is expected monthly revenue
+	revenue float64
+}
+
+func newGenerator(params *dto.SeedParams) *generator {
+	g := &generator{
+		rnd:    rand.New(rand.NewPCG(uint64(params.Seed), uint64(params.Seed)^0x5eed)),
+		params: params,
+		first: time.Date(params.Now.Year(), params.Now.Month(), params.Now.Day(), 0, 0, 0, 0, params.Now.Location()).
+			AddDate(0, 0, -params.Days),
+	}
+
+	g.generateItems()
+	g.revenue = g.monthlyRevenue()
+
+	return g
+}
+
+// day returns sales and charges of i-th day of the period.
+func (g *generator) day(i int) ([]*dto.SalesData, []*dto.ChargesData) {
+	day := g.first.AddDate(0, 0, i)
+
+	return g.generateSales(day, float64(i)/float64(g.params.Days)), g.generateCharges(day)
+}
+
+// generateItems takes products from categories in turn, so that all categories are present. Names
+// of products taken again get number.
+func (g *generator) generateItems() {
+	for i := 0; i < g.params.Items; i++ {
+		cat := catalog[i%len(catalog)]
+		round := i / len(catalog)
+		p := cat.products[round%len(cat.products)]
+
+		name := p.name
+		if n := round / len(cat.products); n > 0 {
+			suffix := " #" + strconv.Itoa(n+1)
+			name = name[:min(len(name), maxNameLen-len(suffix))] + suffix
+		}
+
+		price := int(float64(p.price) * (0.9 + 0.2*g.rnd.Float64()))
+		cost := int(float64(price) * (0.5 + 0.2*g.rnd.Float64()))
+		g.items = append(g.items, &dto.WarehousesData{
+			Name:     name,
+			Quantity: 20 + g.rnd.IntN(480),
+			Amount:   price,
+			Cost:     &cost,
+			Category: cat.name,
+			Location: cat.location,
+		})
+		g.seasons = append(g.seasons, cat)
+		// popularity falls off with rank like in real assortments
+		g.weights = append(g.weights, 1/math.Pow(float64(g.rnd.IntN(g.params.Items)+1), 0.8))
+	}
+}
+
+// seasonal returns relative demand on day for category.
+func seasonal(cat category, day time.Time) float64 {
+	if cat.amplitude == 0 {
+		return 1
+	}
+
+	return 1 + cat.amplitude*math.Cos(2*math.Pi*float64(day.YearDay()-cat.peak)/365.25)
+}
+
+// generateSales adds sales of day. progress is share of the period passed, demand grows with it.
+func (g *generator) generateSales(day time.Time, progress float64) []*dto.SalesData {
+	mean := float64(g.params.SalesPerDay) * weekdayDemand[day.Weekday()] * (0.9 + 0.2*progress)
+	count := int(math.Round(mean * (0.85 + 0.3*g.rnd.Float64())))
+
+	weights := make([]float64, len(g.weights))
+	for i, weight := range g.weights {
+		weights[i] = weight * seasonal(g.seasons[i], day)
+	}
+
+	sales := make([]*dto.SalesData, 0, count)
+	for i := 0; i < count; i++ {
+		item := pick(g.rnd, weights)
+		at := day.Add(time.Duration(openingHour+pick(g.rnd, hourDemand))*time.Hour +
+			time.Duration(g.rnd.IntN(3600))*time.Second)
+
+		price := g.items[item].Amount
+		if g.rnd.Float64() < 0.08 {
+			price = int(float64(price) * (0.75 + 0.2*g.rnd.Float64()))
+		}
+
+		customer := ""
+		if g.rnd.Float64() < 0.25 {
+			customer = customers[g.rnd.IntN(len(customers))]
+		}
+
+		sales = append(sales, &dto.SalesData{
+			Amount:       price,
+			Quantity:     g.quantity(),
+			SaleDate:     at.Format(dto.TimeLayout),
+			WarehousesId: item,
+			Customer:     customer,
+		})
+	}
+
+	return sales
+}
+
+func (g *generator) quantity() int {
+	switch r := g.rnd.Float64(); {
+	case r < 0.6:
+		return 1
+	case r < 0.85:
+		return 2
+	case r < 0.95:
+		return 3
+	default:
+		return 4 + g.rnd.IntN(3)
+	}
+}
+
+// monthlyRevenue estimates revenue of month charges are sized after.
+func (g *generator) monthlyRevenue() float64 {
+	var total float64
+	for _, item := range g.items {
+		total += float64(item.Amount)
+	}
+
+	return float64(g.params.SalesPerDay) * 30 * total / float64(len(g.items)) * 1.6
+}
+
+// generateCharges adds charges of day: rent, salaries, utilities and internet monthly, delivery
+// weekly and one-off expenses now and then.
+func (g *generator) generateCharges(day time.Time) []*dto.ChargesData {
+	var charges []*dto.ChargesData
+	revenue := g.revenue
+	add := func(item string, amount float64, hour int) {
+		charges = append(charges, &dto.ChargesData{
+			Amount:        max(int(math.Round(amount)), 1),
+			ChargeDate:    day.Add(time.Duration(hour) * time.Hour).Format(dto.TimeLayout),
+			ExpenseItemId: expenseIndex(item),
+		})
+	}
+	jitter := func(spread float64) float64 {
+		return 1 - spread + 2*spread*g.rnd.Float64()
+	}
+
+	switch day.Day() {
+	case 1:
+		add(expenseRent, math.Round(revenue*0.08/1000)*1000, 10)
+	case 5, 20:
+		add(expenseSalaries, revenue*0.06*jitter(0.03), 12)
+	case 10:
+		winter := seasonal(category{peak: 15, amplitude: 0.4}, day)
+		add(expenseUtilities, revenue*0.02*winter*jitter(0.1), 11)
+	case 15:
+		add(expenseInternet, 1500, 9)
+	}
+
+	if day.Weekday() == time.Monday {
+		add(expenseDelivery, revenue/4*0.02*jitter(0.3), 8)
+	}
+
+	for _, expense := range oneOffExpenses {
+		if g.rnd.Float64() < expense.perYear/365 {
+			share := expense.minShare + (expense.maxShare-expense.minShare)*g.rnd.Float64()
+			add(expense.name, revenue*share, 9+g.rnd.IntN(9))
+		}
+	}
+
+	return charges
+}
+
+func expenseIndex(name string) int {
+	for i, item := range expenseItems {
+		if item == name {
+			return i
+		}
+	}
+
+	panic(fmt.Sprintf("unknown expense item %s", name))
+}
+
+// users returns admin and sellers with random passwords. Passwords don't depend on seed of data,
+// so that the same seed doesn't give the same passwords.
+func (g *generator) users() []*dto.SeedUserData {
+	users := make([]*dto.SeedUserData, 0, g.params.Users)
+	for i := 0; i < g.params.Users; i++ {
+		user := &dto.SeedUserData{Login: "seller" + strconv.Itoa(i), Password: password()}
+		if i == 0 {
+			user.Login, user.IsAdmin = "admin", true
+		}
+		users = append(users, user)
+	}
+
+	return users
+}
+
+func password() string {
+	const letters = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	password := make([]byte, 10)
+	for i := range password {
+		n, err := crand.Int(crand.Reader, big.NewInt(int64(len(letters))))
+		if err != nil {
+			panic(fmt.Sprintf("failed to generate password: %v", err))
+		}
+		password[i] = letters[n.Int64()]
+	}
+
+	return string(password)
+}
+
+// pick returns index chosen with probability proportional to its weight.
+func pick(rnd *rand.Rand, weights []float64) int {
+	var total float64
+	for _, weight := range weights {
+		total += weight
+	}
+
+	r := rnd.Float64() * total
+	for i, weight := range weights {
+		if r < weight {
+			return i
+		}
+		r -= weight
+	}
+
+	return len(weights) - 1
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/repository"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+)
+
+// Limits of seed parameters.
+const (
+	maxItems       = 1000
+	maxDays        = 3660
+	maxSalesPerDay = 100000
+	maxUsers       = 100
+)
+
+// SeedService fills database with demo data for demos, trying out reports and performance tests.
+// Records are created through repositories like those entered by users, so triggers and defaults
+// apply to them, but they aren't recorded in audit log.
+type SeedService struct {
+	l          *slog.Logger
+	ShopRepo   repository.IShopRepository
+	AuthRepo   repository.IAuthRepository
+	Transactor repository.ITransactor
+}
+
+func NewSeedService(shopRepo repository.IShopRepository, authRepo repository.IAuthRepository,
+	transactor repository.ITransactor) *SeedService {
+	var l *slog.Logger
+
+	return &SeedService{
+		l:          l,
+		ShopRepo:   shopRepo,
+		AuthRepo:   authRepo,
+		Transactor: transactor,
+	}
+}
+
+// Seed generates demo data and saves it in one transaction. Database must have no warehouses items
+// unless params.Append is set. It's allowed to admins only.
+func (s *SeedService) Seed(ctx context.Context, params *dto.SeedParams) (*dto.SeedData, error) {
+	const op = "SeedService.Seed"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+	if err := validateSeedParams(params); err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	g := newGenerator(params)
+
+	res := &dto.SeedData{}
+	err := s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if !params.Append {
+			items, err := s.ShopRepo.ShowWarehousesTable(ctx)
+			if err != nil {
+				return err
+			}
+			if len(items) > 0 {
+				return fmt.Errorf("%w: database already has warehouses items, use append to add more",
+					customErr.ErrInvalidSeedParams)
+			}
+		}
+
+		itemIds := make([]int, len(g.items))
+		for i, item := range g.items {
+			id, err := s.ShopRepo.CreateWarehousesItem(ctx, item)
+			if err != nil {
+				return err
+			}
+			itemIds[i] = id
+		}
+		res.Items = len(itemIds)
+
+		expenseItemIds := make([]int, len(expenseItems))
+		for i, name := range expenseItems {
+			id, err := s.ShopRepo.CreateExpenseItem(ctx, name)
+			if err != nil {
+				return err
+			}
+			expenseItemIds[i] = id
+		}
+		res.ExpenseItems = len(expenseItemIds)
+
+		for i := 0; i < params.Days; i++ {
+			sales, charges := g.day(i)
+			for _, charge := range charges {
+				charge.ExpenseItemId = expenseItemIds[charge.ExpenseItemId]
+				if _, err := s.ShopRepo.CreateChargesItem(ctx, charge); err != nil {
+					return err
+				}
+			}
+			for _, sale := range sales {
+				sale.WarehousesId = itemIds[sale.WarehousesId]
+				if _, err := s.ShopRepo.CreateSalesItem(ctx, sale); err != nil {
+					return err
+				}
+			}
+			res.Charges += len(charges)
+			res.Sales += len(sales)
+		}
+
+		for _, user := range g.users() {
+			created, err := s.createUser(ctx, user)
+			if err != nil {
+				return err
+			}
+			if created {
+				res.Users = append(res.Users, user)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// createUser creates user unless login is taken and tells whether user was created.
+func (s *SeedService) createUser(ctx context.Context, user *dto.SeedUserData) (bool, error) {
+	_, err := s.AuthRepo.FindUser(ctx, user.Login)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, customErr.ErrUserNotFound) {
+		return false, err
+	}
+
+	passHash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return false, err
+	}
+	if err = s.AuthRepo.SaveUser(ctx, user.Login, passHash); err != nil {
+		return false, err
+	}
+	if err = s.AuthRepo.SetAdmin(ctx, user.Login, user.IsAdmin); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func validateSeedParams(params *dto.SeedParams) error {
+	switch {
+	case params.Items < 1 || params.Items > maxItems:
+		return fmt.Errorf("%w: number of items must be from 1 to %d", customErr.ErrInvalidSeedParams, maxItems)
+	case params.Days < 1 || params.Days > maxDays:
+		return fmt.Errorf("%w: number of days must be from 1 to %d", customErr.ErrInvalidSeedParams, maxDays)
+	case params.SalesPerDay < 0 || params.SalesPerDay > maxSalesPerDay:
+		return fmt.Errorf("%w: sales per day must be from 0 to %d", customErr.ErrInvalidSeedParams, maxSalesPerDay)
+	case params.Users < 0 || params.Users > maxUsers:
+		return fmt.Errorf("%w: number of users must be from 0 to %d", customErr.ErrInvalidSeedParams, maxUsers)
+	}
+
+	return nil
+}
@@ -2,18 +2,59 @@ package services
 
 import (
 	"automatedShop/internal/repository"
+	aggregateService "automatedShop/internal/services/aggregate"
+	analysisService "automatedShop/internal/services/analysis"
+	anomalyService "automatedShop/internal/services/anomaly"
+	archiveService "automatedShop/internal/services/archive"
+	auditService "automatedShop/internal/services/audit"
 	authService "automatedShop/internal/services/auth"
+	backupService "automatedShop/internal/services/backup"
+	bankService "automatedShop/internal/services/bank"
+	exchangeService "automatedShop/internal/services/exchange"
+	forecastService "automatedShop/internal/services/forecast"
+	importService "automatedShop/internal/services/importer"
+	pivotService "automatedShop/internal/services/pivot"
+	scheduleService "automatedShop/internal/services/schedule"
+	seedService "automatedShop/internal/services/seed"
 	shopService "automatedShop/internal/services/shop"
 )
 
 type Service struct {
-	AuthService IAuthService
-	ShopService IShopService
+	AuthService      IAuthService
+	ShopService      IShopService
+	AuditService     IAuditService
+	AnalysisService  IAnalysisService
+	ForecastService  IForecastService
+	ArchiveService   IArchiveService
+	ScheduleService  IScheduleService
+	PivotService     IPivotService
+	AnomalyService   IAnomalyService
+	AggregateService IAggregateService
+	ImportService    IImportService
+	BackupService    IBackupService
+	SeedService      ISeedService
+	ExchangeService  IExchangeService
+	BankService      IBankService
 }
 
 func NewService(repos *repository.Repository) *Service {
+	shop := shopService.NewShopService(repos.ShopRepo, repos.AuditRepo, repos.Transactor)
+
 	return &Service{
-		AuthService: authService.NewAuthService(repos.AuthRepo),
-		ShopService: shopService.NewShopService(repos.ShopRepo),
+		AuthService:      authService.NewAuthService(repos.AuthRepo),
+		ShopService:      shop,
+		AuditService:     auditService.NewAuditService(repos.AuditRepo),
+		AnalysisService:  analysisService.NewAnalysisService(repos.ShopRepo),
+		ForecastService:  forecastService.NewForecastService(repos.ShopRepo),
+		ArchiveService:   archiveService.NewArchiveService(repos.ArchiveRepo),
+		ScheduleService:  scheduleService.NewScheduleService(repos.ScheduleRepo),
+		PivotService:     pivotService.NewPivotService(repos.PivotRepo),
+		AnomalyService:   anomalyService.NewAnomalyService(repos.AnomalyRepo),
+		AggregateService: aggregateService.NewAggregateService(repos.AggregateRepo, repos.Transactor),
+		ImportService:    importService.NewImportService(repos.ShopRepo, repos.ImportRepo, shop, repos.Transactor),
+		BackupService:    backupService.NewBackupService(repos.BackupRepo, repos.AggregateRepo, repos.Transactor),
+		SeedService:      seedService.NewSeedService(repos.ShopRepo, repos.AuthRepo, repos.Transactor),
+		ExchangeService:  exchangeService.NewExchangeService(repos.ShopRepo, repos.ExchangeRepo, shop, repos.Transactor),
+		BankService:      bankService.NewBankService(repos.ShopRepo, repos.BankRepo, shop, repos.Transactor),
 	}
 }
@@ -0,0 +1,70 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// withAudit runs change in transaction together with saving its audit record. State of the record
+// before and after the change is stored as JSON. change returns id of the changed record, so
+// created records can be audited too.
+func (s *ShopService) withAudit(ctx context.Context, action, table string, id int,
+	change func(context.Context) (int, error)) error {
+	return s.Transactor.RunInTx(ctx, func(ctx context.Context) error {
+		var before, after []byte
+		var err error
+
+		if action != dto.AuditCreate {
+			if before, err = s.recordState(ctx, table, id); err != nil {
+				return err
+			}
+		}
+
+		if id, err = change(ctx); err != nil {
+			return err
+		}
+
+		if action != dto.AuditDelete && action != dto.AuditPurge {
+			if after, err = s.recordState(ctx, table, id); err != nil {
+				return err
+			}
+		}
+
+		return s.AuditRepo.SaveAuditRecord(ctx, &dto.AuditRecordData{
+			UserLogin: session.Login(ctx),
+			Table:     table,
+			RecordId:  id,
+			Action:    action,
+			Before:    before,
+			After:     after,
+		})
+	})
+}
+
+// recordState returns JSON representation of the record with given id from given table.
+func (s *ShopService) recordState(ctx context.Context, table string, id int) ([]byte, error) {
+	var record any
+	var err error
+
+	switch table {
+	case dto.WarehousesTable:
+		record, err = s.ShopRepo.GetWarehousesItem(ctx, id)
+	case dto.ExpenseItemsTable:
+		record, err = s.ShopRepo.GetExpenseItem(ctx, id)
+	case dto.ChargesTable:
+		record, err = s.ShopRepo.GetChargesItem(ctx, id)
+	case dto.SalesTable:
+		record, err = s.ShopRepo.GetSalesItem(ctx, id)
+	default:
+		return nil, fmt.Errorf("%w: %s", customErr.ErrUnknownTable, table)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(record)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/period"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// GetComparisonReport compares profit and loss statement and sales of each product in given period
+// with the previous period and with the same period a year ago.
+func (s *ShopService) GetComparisonReport(ctx context.Context, params *dto.ComparisonParams) (*dto.ComparisonReportData, error) {
+	const op = "ShopService.GetComparisonReport"
+
+	if params.MoverPercent <= 0 {
+		return nil, fmt.Errorf("error occurred in: %v: %w: big mover threshold must be positive",
+			op, customErr.ErrInvalidReportParams)
+	}
+
+	// current period, the previous one and the same period a year ago
+	periods := [3]period.Period{params.Period, params.Period.Previous(), params.Period.YearAgo()}
+
+	var charges, products [3][]namedValue
+	var profits [3]*dto.ProfitReportData
+	for i, p := range periods {
+		profit, err := s.GetProfitReport(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+		}
+		profits[i] = profit
+		for _, expense := range profit.Expenses {
+			charges[i] = append(charges[i], namedValue{key: strconv.Itoa(expense.Id), name: expense.Name, value: expense.Total})
+		}
+
+		sales, err := s.GetProductSales(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+		}
+		for _, product := range sales {
+			products[i] = append(products[i], namedValue{key: strconv.Itoa(product.Id), name: product.Name,
+				value: product.Revenue})
+		}
+	}
+
+	report := &dto.ComparisonReportData{
+		Current:  periods[0].String(),
+		Previous: periods[1].String(),
+		YearAgo:  periods[2].String(),
+	}
+	add := func(section, name string, values [3]int64) {
+		report.Rows = append(report.Rows, &dto.ComparisonRowData{
+			Section:  section,
+			Name:     name,
+			Current:  values[0],
+			Previous: compare(values[0], values[1], params.MoverPercent),
+			YearAgo:  compare(values[0], values[2], params.MoverPercent),
+		})
+	}
+	profitLine := func(name string, value func(*dto.ProfitReportData) int64) {
+		add(dto.SectionProfit, name, [3]int64{value(profits[0]), value(profits[1]), value(profits[2])})
+	}
+
+	profitLine("Revenue", func(r *dto.ProfitReportData) int64 { return r.Revenue })
+	profitLine("Cost of goods sold", func(r *dto.ProfitReportData) int64 { return r.Cogs })
+	profitLine("Gross profit (known cost)", func(r *dto.ProfitReportData) int64 { return r.GrossProfit })
+	profitLine("Total expenses", func(r *dto.ProfitReportData) int64 { return r.TotalExpenses })
+	profitLine("Net profit (known cost)", func(r *dto.ProfitReportData) int64 { return r.NetProfit })
+
+	for _, line := range matchByKey(charges) {
+		add(dto.SectionCharges, line.name, line.values)
+	}
+	for _, line := range matchByKey(products) {
+		add(dto.SectionProducts, line.name, line.values)
+	}
+
+	return report, nil
+}
+
+// namedValue is value of report line, lines of different periods with the same key are the same.
+type namedValue struct {
+	key   string
+	name  string
+	value int64
+}
+
+type valueLine struct {
+	name   string
+	values [3]int64
+}
+
+// matchByKey joins values of all periods by key keeping order of the current period. Keys found
+// only in base periods follow in their order.
+func matchByKey(periods [3][]namedValue) []*valueLine {
+	var lines []*valueLine
+	index := make(map[string]*valueLine)
+	for i, values := range periods {
+		for _, v := range values {
+			line, ok := index[v.key]
+			if !ok {
+				line = &valueLine{name: v.name}
+				index[v.key] = line
+				lines = append(lines, line)
+			}
+			line.values[i] = v.value
+		}
+	}
+
+	return lines
+}
+
+func compare(current, base int64, moverPercent float64) dto.ComparisonDeltaData {
+	delta := dto.ComparisonDeltaData{Base: base, Delta: current - base}
+
+	if base == 0 {
+		delta.BigMover = delta.Delta != 0
+		return delta
+	}
+
+	percent := float64(delta.Delta) / math.Abs(float64(base)) * 100
+	delta.Percent = &percent
+	delta.BigMover = math.Abs(percent) >= moverPercent
+
+	return delta
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"automatedShop/internal/period"
+	"automatedShop/internal/services/dto"
+	"context"
+	"fmt"
+)
+
+const (
+	dashboardSparklineDays = 30
+	dashboardTopProducts   = 5
+)
+
+// GetDashboard returns key figures of the shop: today's sales, this month's profit, items low in stock,
+// top products of this week and daily revenue of the last days.
+func (s *ShopService) GetDashboard(ctx context.Context, params *dto.DashboardParams) (*dto.DashboardData, error) {
+	const op = "ShopService.GetDashboard"
+
+	days := period.LastDays(params.Now, dashboardSparklineDays)
+	sparkline, err := s.GetSalesTrend(ctx, &dto.TrendParams{Period: days, Bucket: period.DayBucket})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	profit, err := s.GetProfitReport(ctx, period.CurrentMonth(params.Now))
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	week := period.Period{From: period.BucketStart(params.Now, period.WeekBucket), To: days.To}
+	top, err := s.GetRankingReport(ctx, &dto.RankingParams{
+		Period:  week,
+		Metric:  dto.MetricRevenue,
+		GroupBy: dto.GroupByProduct,
+		Limit:   dashboardTopProducts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	warehouses, err := s.ShopRepo.ShowWarehousesTable(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	today := sparkline[len(sparkline)-1]
+	dashboard := &dto.DashboardData{
+		Day:            today.Start,
+		DayRevenue:     today.Revenue,
+		DaySalesCount:  today.SalesCount,
+		MonthNetProfit: profit.NetProfit,
+		Sparkline:      sparkline,
+	}
+	if today.SalesCount > 0 {
+		dashboard.AverageReceipt = today.Revenue / today.SalesCount
+	}
+	// Ranking includes items without sales, they aren't top products.
+	for _, item := range top {
+		if item.Revenue > 0 {
+			dashboard.TopProducts = append(dashboard.TopProducts, item)
+		}
+	}
+	for _, warehouse := range warehouses {
+		if warehouse.Quantity < params.LowStock {
+			dashboard.LowStockCount++
+		}
+	}
+
+	return dashboard, nil
+}
@@ -1,24 +1,35 @@
 package services
 
 import (
+	customErr "automatedShop/internal/errors"
+	"automatedShop/internal/period"
 	"automatedShop/internal/repository"
 	"automatedShop/internal/services/dto"
+	"automatedShop/internal/session"
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 )
 
+const maxRankingLimit = 1000
+
 type ShopService struct {
-	l        *slog.Logger
-	ShopRepo repository.IShopRepository
+	l          *slog.Logger
+	ShopRepo   repository.IShopRepository
+	AuditRepo  repository.IAuditRepository
+	Transactor repository.ITransactor
 }
 
-func NewShopService(repo repository.IShopRepository) *ShopService {
+func NewShopService(repo repository.IShopRepository, auditRepo repository.IAuditRepository,
+	transactor repository.ITransactor) *ShopService {
 	var l *slog.Logger
 
 	return &ShopService{
-		l:        l,
-		ShopRepo: repo,
+		l:          l,
+		ShopRepo:   repo,
+		AuditRepo:  auditRepo,
+		Transactor: transactor,
 	}
 }
 
@@ -33,39 +44,60 @@ func (s *ShopService) ShowWarehousesTable(ctx context.Context) ([]*dto.Warehouse
 	return res, nil
 }
 
+func (s *ShopService) GetWarehousesItem(ctx context.Context, id int) (*dto.WarehousesData, error) {
+	const op = "ShopService.GetWarehousesItem"
+
+	res, err := s.ShopRepo.GetWarehousesItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// CreateWarehousesItem creates warehouses item and records it in audit log. Id and version of
+// the new item are set to data.
 func (s *ShopService) CreateWarehousesItem(ctx context.Context, data *dto.WarehousesData) error {
 	const op = "ShopService.CreateWarehousesItem"
 
-	err := s.ShopRepo.CreateWarehousesItem(ctx, data.Name, data.Quantity, data.Amount)
+	err := s.withAudit(ctx, dto.AuditCreate, dto.WarehousesTable, 0, func(ctx context.Context) (int, error) {
+		id, err := s.ShopRepo.CreateWarehousesItem(ctx, data)
+		if err != nil {
+			return 0, err
+		}
+		data.Id, data.Version = id, 1
+		return id, nil
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: warehouses item inserted successfully", op)
 	return nil
 }
 
 func (s *ShopService) UpdateWarehousesItem(ctx context.Context, data *dto.WarehousesData) error {
 	const op = "ShopService.UpdateWarehousesItem"
 
-	err := s.ShopRepo.UpdateWarehousesItem(ctx, data.Name, data.Quantity, data.Amount, data.Id)
+	err := s.withAudit(ctx, dto.AuditUpdate, dto.WarehousesTable, data.Id, func(ctx context.Context) (int, error) {
+		return data.Id, s.ShopRepo.UpdateWarehousesItem(ctx, data)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: warehouses item updated successfully", op)
 	return nil
 }
 
 func (s *ShopService) DeleteWarehousesItem(ctx context.Context, id int) error {
 	const op = "ShopService.DeleteWarehousesItem"
 
-	err := s.ShopRepo.DeleteWarehousesItem(ctx, id)
+	err := s.withAudit(ctx, dto.AuditDelete, dto.WarehousesTable, id, func(ctx context.Context) (int, error) {
+		return id, s.ShopRepo.DeleteWarehousesItem(ctx, id)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: warehouses item deleted successfully", op)
 	return nil
 }
 
@@ -80,39 +112,53 @@ func (s *ShopService) ShowExpenseItemsTable(ctx context.Context) ([]*dto.Expense
 	return res, nil
 }
 
+func (s *ShopService) GetExpenseItem(ctx context.Context, id int) (*dto.ExpenseItemsData, error) {
+	const op = "ShopService.GetExpenseItem"
+
+	res, err := s.ShopRepo.GetExpenseItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
 func (s *ShopService) CreateExpenseItem(ctx context.Context, name string) error {
 	const op = "ShopService.CreateExpenseItem"
 
-	err := s.ShopRepo.CreateExpenseItem(ctx, name)
+	err := s.withAudit(ctx, dto.AuditCreate, dto.ExpenseItemsTable, 0, func(ctx context.Context) (int, error) {
+		return s.ShopRepo.CreateExpenseItem(ctx, name)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: expense item inserted successfully", op)
 	return nil
 }
 
 func (s *ShopService) UpdateExpenseItem(ctx context.Context, data *dto.ExpenseItemsData) error {
 	const op = "ShopService.UpdateExpenseItem"
 
-	err := s.ShopRepo.UpdateExpenseItem(ctx, data.Name, data.Id)
+	err := s.withAudit(ctx, dto.AuditUpdate, dto.ExpenseItemsTable, data.Id, func(ctx context.Context) (int, error) {
+		return data.Id, s.ShopRepo.UpdateExpenseItem(ctx, data)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: expense item updated successfully", op)
 	return nil
 }
 
 func (s *ShopService) DeleteExpenseItem(ctx context.Context, id int) error {
 	const op = "ShopService.DeleteExpenseItem"
 
-	err := s.ShopRepo.DeleteExpenseItem(ctx, id)
+	err := s.withAudit(ctx, dto.AuditDelete, dto.ExpenseItemsTable, id, func(ctx context.Context) (int, error) {
+		return id, s.ShopRepo.DeleteExpenseItem(ctx, id)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: expense item deleted successfully", op)
 	return nil
 }
 
@@ -128,12 +174,32 @@ func (s *ShopService) ShowChargesTable(ctx context.Context) ([]*dto.ChargesData,
 	return res, nil
 }
 
+func (s *ShopService) GetChargesItem(ctx context.Context, id int) (*dto.ChargesData, error) {
+	const op = "ShopService.GetChargesItem"
+
+	res, err := s.ShopRepo.GetChargesItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// CreateChargesItem creates charge and records it in audit log. Id and version of the new
+// charge are set to data.
 func (s *ShopService) CreateChargesItem(ctx context.Context, data *dto.ChargesData) error {
 	const op = "ShopService.CreateChargesItem"
 
-	err := s.ShopRepo.CreateChargesItem(ctx, data)
+	err := s.withAudit(ctx, dto.AuditCreate, dto.ChargesTable, 0, func(ctx context.Context) (int, error) {
+		id, err := s.ShopRepo.CreateChargesItem(ctx, data)
+		if err != nil {
+			return 0, err
+		}
+		data.Id, data.Version = id, 1
+		return id, nil
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return nil
@@ -142,9 +208,11 @@ func (s *ShopService) CreateChargesItem(ctx context.Context, data *dto.ChargesDa
 func (s *ShopService) UpdateChargesItem(ctx context.Context, data *dto.ChargesData) error {
 	const op = "ShopService.UpdateChargesItem"
 
-	err := s.ShopRepo.UpdateChargesItem(ctx, data)
+	err := s.withAudit(ctx, dto.AuditUpdate, dto.ChargesTable, data.Id, func(ctx context.Context) (int, error) {
+		return data.Id, s.ShopRepo.UpdateChargesItem(ctx, data)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return nil
@@ -153,9 +221,11 @@ func (s *ShopService) UpdateChargesItem(ctx context.Context, data *dto.ChargesDa
 func (s *ShopService) DeleteChargesItem(ctx context.Context, id int) error {
 	const op = "ShopService.DeleteChargesItem"
 
-	err := s.ShopRepo.DeleteChargesItem(ctx, id)
+	err := s.withAudit(ctx, dto.AuditDelete, dto.ChargesTable, id, func(ctx context.Context) (int, error) {
+		return id, s.ShopRepo.DeleteChargesItem(ctx, id)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return nil
@@ -172,12 +242,32 @@ func (s *ShopService) ShowSalesTable(ctx context.Context) ([]*dto.SalesData, err
 	return res, nil
 }
 
+func (s *ShopService) GetSalesItem(ctx context.Context, id int) (*dto.SalesData, error) {
+	const op = "ShopService.GetSalesItem"
+
+	res, err := s.ShopRepo.GetSalesItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// CreateSalesItem creates sale and records it in audit log. Id and version of the new sale are
+// set to data.
 func (s *ShopService) CreateSalesItem(ctx context.Context, data *dto.SalesData) error {
 	const op = "ShopService.CreateSalesItem"
 
-	err := s.ShopRepo.CreateSalesItem(ctx, data)
+	err := s.withAudit(ctx, dto.AuditCreate, dto.SalesTable, 0, func(ctx context.Context) (int, error) {
+		id, err := s.ShopRepo.CreateSalesItem(ctx, data)
+		if err != nil {
+			return 0, err
+		}
+		data.Id, data.Version = id, 1
+		return id, nil
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return nil
@@ -186,9 +276,11 @@ func (s *ShopService) CreateSalesItem(ctx context.Context, data *dto.SalesData)
 func (s *ShopService) UpdateSalesItem(ctx context.Context, data *dto.SalesData) error {
 	const op = "ShopService.UpdateSalesItem"
 
-	err := s.ShopRepo.UpdateSalesItem(ctx, data)
+	err := s.withAudit(ctx, dto.AuditUpdate, dto.SalesTable, data.Id, func(ctx context.Context) (int, error) {
+		return data.Id, s.ShopRepo.UpdateSalesItem(ctx, data)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return nil
@@ -197,9 +289,11 @@ func (s *ShopService) UpdateSalesItem(ctx context.Context, data *dto.SalesData)
 func (s *ShopService) DeleteSalesItem(ctx context.Context, id int) error {
 	const op = "ShopService.DeleteSalesItem"
 
-	err := s.ShopRepo.DeleteSalesItem(ctx, id)
+	err := s.withAudit(ctx, dto.AuditDelete, dto.SalesTable, id, func(ctx context.Context) (int, error) {
+		return id, s.ShopRepo.DeleteSalesItem(ctx, id)
+	})
 	if err != nil {
-		return fmt.Errorf("error occurred in: %v: %v", op, err)
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return nil
@@ -207,25 +301,168 @@ func (s *ShopService) DeleteSalesItem(ctx context.Context, id int) error {
 
 // Report's methods
 
-func (s *ShopService) CountMonthProfit(ctx context.Context) (int64, error) {
-	const op = "ShopService.CountMonthProfit"
+// GetProfitReport returns profit and loss statement for given period. Costs of sold items aren't
+// kept with sales, so cost of goods sold is counted at current costs, and revenue of items with
+// unknown cost is left out of profit.
+func (s *ShopService) GetProfitReport(ctx context.Context, p period.Period) (*dto.ProfitReportData, error) {
+	const op = "ShopService.GetProfitReport"
 
-	profit, err := s.ShopRepo.CountMonthProfit(ctx)
+	report, err := s.ShopRepo.GetProfitReport(ctx, p.From, p.To)
 	if err != nil {
-		return -1, fmt.Errorf("error occurred in: %v: %v", op, err)
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
-	fmt.Printf("%v: profit counted successfully", op)
-	return profit, nil
+	report.From = p.FirstDay()
+	report.To = p.LastDay()
+	report.GrossProfit = report.Revenue - report.RevenueWithoutCost - report.Cogs
+	for _, expense := range report.Expenses {
+		report.TotalExpenses += expense.Total
+	}
+	report.NetProfit = report.GrossProfit - report.TotalExpenses
+
+	return report, nil
 }
 
-func (s *ShopService) GetFiveBestItems(ctx context.Context, from string, to string) ([]*dto.BestItemsData, error) {
-	const op = "ShopService.GetFiveBestItems"
+// GetRankingReport returns top or bottom items ranked by chosen metric for given period.
+func (s *ShopService) GetRankingReport(ctx context.Context, params *dto.RankingParams) ([]*dto.RankingItemData, error) {
+	const op = "ShopService.GetRankingReport"
 
-	res, err := s.ShopRepo.GetFiveBestItems(ctx, from, to)
+	if params.Limit < 1 || params.Limit > maxRankingLimit {
+		return nil, fmt.Errorf("error occurred in: %v: %w: N must be between 1 and %d",
+			op, customErr.ErrInvalidReportParams, maxRankingLimit)
+	}
+
+	res, err := s.ShopRepo.GetRankingReport(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("error occurred in: %v: %v", op, err)
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
 	}
 
 	return res, nil
 }
+
+// GetProductSales returns sales totals of every item sold in given period, deleted items included.
+func (s *ShopService) GetProductSales(ctx context.Context, p period.Period) ([]*dto.ProductSalesData, error) {
+	const op = "ShopService.GetProductSales"
+
+	if !p.From.Before(p.To) {
+		return nil, fmt.Errorf("error occurred in: %v: %w: period must end after it starts",
+			op, customErr.ErrInvalidReportParams)
+	}
+
+	res, err := s.ShopRepo.GetProductSales(ctx, p.From, p.To)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// GetSalesTrend returns sales totals bucketed by day, week or month. Buckets without sales are
+// filled with zeros. Partial buckets on the edges of period count only days inside the period.
+func (s *ShopService) GetSalesTrend(ctx context.Context, params *dto.TrendParams) ([]*dto.TrendPointData, error) {
+	const op = "ShopService.GetSalesTrend"
+
+	days, err := s.ShopRepo.GetDailySales(ctx, params.Period.From, params.Period.To)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	starts := params.Period.BucketStarts(params.Bucket)
+	points := make([]*dto.TrendPointData, len(starts))
+	index := make(map[string]*dto.TrendPointData, len(starts))
+	for i, start := range starts {
+		points[i] = &dto.TrendPointData{Start: start.Format(period.DateLayout)}
+		index[points[i].Start] = points[i]
+	}
+
+	for _, day := range days {
+		date, err := time.ParseInLocation(period.DateLayout, day.Day, params.Period.From.Location())
+		if err != nil {
+			return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+		}
+
+		point, ok := index[period.BucketStart(date, params.Bucket).Format(period.DateLayout)]
+		if !ok {
+			continue
+		}
+		point.Revenue += day.Revenue
+		point.Units += day.Units
+		point.SalesCount += day.SalesCount
+	}
+
+	return points, nil
+}
+
+// Trash's methods
+
+func (s *ShopService) ShowTrash(ctx context.Context) ([]*dto.TrashItemData, error) {
+	const op = "ShopService.ShowTrash"
+
+	if !session.IsAdmin(ctx) {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	res, err := s.ShopRepo.ShowTrash(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (s *ShopService) RestoreTrashItem(ctx context.Context, table string, id int) error {
+	const op = "ShopService.RestoreTrashItem"
+
+	if !session.IsAdmin(ctx) {
+		return fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	err := s.withAudit(ctx, dto.AuditRestore, table, id, func(ctx context.Context) (int, error) {
+		return id, s.ShopRepo.RestoreTrashItem(ctx, table, id)
+	})
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeTrashItem permanently deletes record from trash. Records which are still referenced
+// by other records (e.g. warehouses item with sales) are kept.
+func (s *ShopService) PurgeTrashItem(ctx context.Context, table string, id int) error {
+	const op = "ShopService.PurgeTrashItem"
+
+	if !session.IsAdmin(ctx) {
+		return fmt.Errorf("error occurred in: %v: %w", op, customErr.ErrAccessDenied)
+	}
+
+	err := s.withAudit(ctx, dto.AuditPurge, table, id, func(ctx context.Context) (int, error) {
+		return id, s.ShopRepo.PurgeTrashItem(ctx, table, id)
+	})
+	if err != nil {
+		return fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetSalesHeatmap returns sales totals of given period by weekday and hour of sale.
+func (s *ShopService) GetSalesHeatmap(ctx context.Context, p period.Period) (*dto.HeatmapData, error) {
+	const op = "ShopService.GetSalesHeatmap"
+
+	hours, err := s.ShopRepo.GetHourlySales(ctx, p.From, p.To)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred in: %v: %w", op, err)
+	}
+
+	heatmap := &dto.HeatmapData{From: p.FirstDay(), To: p.LastDay()}
+	for _, hour := range hours {
+		if hour.Weekday < 1 || hour.Weekday > 7 || hour.Hour < 0 || hour.Hour > 23 {
+			continue
+		}
+		heatmap.Revenue[hour.Weekday-1][hour.Hour] += hour.Revenue
+		heatmap.SalesCount[hour.Weekday-1][hour.Hour] += hour.SalesCount
+	}
+
+	return heatmap, nil
+}
@@ -0,0 +1,34 @@
+package session
+
+import (
+	"automatedShop/internal/services/dto"
+	"context"
+)
+
+type userKey struct{}
+
+// WithUser returns copy of ctx which carries user performing the request.
+func WithUser(ctx context.Context, user *dto.UserData) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns user stored by WithUser or nil if request is anonymous.
+func UserFromContext(ctx context.Context) *dto.UserData {
+	user, _ := ctx.Value(userKey{}).(*dto.UserData)
+	return user
+}
+
+// Login returns login of user performing the request or empty string.
+func Login(ctx context.Context) string {
+	if user := UserFromContext(ctx); user != nil {
+		return user.Login
+	}
+
+	return ""
+}
+
+// IsAdmin reports whether user performing the request is admin.
+func IsAdmin(ctx context.Context) bool {
+	user := UserFromContext(ctx)
+	return user != nil && user.IsAdmin
+}